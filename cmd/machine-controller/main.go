@@ -23,6 +23,7 @@ import (
 	"log"
 	"net/http"
 	"net/http/pprof"
+	"slices"
 	"strings"
 	"time"
 
@@ -30,6 +31,8 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
+	"k8c.io/machine-controller/pkg/audit"
+	"k8c.io/machine-controller/pkg/bootstrap"
 	cloudprovidertypes "k8c.io/machine-controller/pkg/cloudprovider/types"
 	"k8c.io/machine-controller/pkg/cloudprovider/util"
 	clusterinfo "k8c.io/machine-controller/pkg/clusterinfo"
@@ -41,10 +44,15 @@ import (
 	machinecontrollerlog "k8c.io/machine-controller/pkg/log"
 	"k8c.io/machine-controller/pkg/migrations"
 	"k8c.io/machine-controller/pkg/node"
+	"k8c.io/machine-controller/pkg/node/eviction"
+	"k8c.io/machine-controller/pkg/secretstore"
 	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
 	machinesv1alpha1 "k8c.io/machine-controller/sdk/apis/machines/v1alpha1"
+	"k8c.io/machine-controller/sdk/providerconfig"
 
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -70,15 +78,32 @@ var (
 	joinClusterTimeout               string
 	workerCount                      int
 	bootstrapTokenServiceAccountName string
+	bootstrapTokenTTL                time.Duration
 	skipEvictionAfter                time.Duration
+	drainGracePeriod                 int64
+	drainSkipPodSelector             string
+	drainDeleteEmptyDirData          bool
+	drainPodEvictionTimeout          time.Duration
+	drainDeleteAfterEvictionTimeout  bool
+	volumeDetachProviders            string
+	preDrainTaintEffect              string
+	preDrainDelay                    time.Duration
 	caBundleFile                     string
+	bootstrapHTTPAllowedURLs         string
+	secretStoreConfig                string
+	workloadIdentityTokenFile        string
+	auditConfig                      string
 	enableLeaderElection             bool
 	leaderElectionNamespace          string
 
 	useExternalBootstrap              bool
 	overrideBootstrapKubeletAPIServer string
 	nodeCSRApprover                   bool
+	nodeCSRApproverVerifyInstance     bool
+	nodeCSRApproverClientCertificates bool
+	nodeCSRClientCertificateInterval  time.Duration
 	nodePortRange                     string
+	allowCrossNamespaceRefs           bool
 
 	nodeHTTPProxy                 string
 	nodeNoProxy                   string
@@ -125,6 +150,9 @@ type controllerRunOptions struct {
 	// if this is nil
 	bootstrapTokenServiceAccountName *types.NamespacedName
 
+	// Lifetime of the bootstrap tokens created per Machine. Zero disables creating them.
+	bootstrapTokenTTL time.Duration
+
 	// prometheusRegisterer is used by the MachineController instance to register its metrics.
 	prometheusRegisterer prometheus.Registerer
 
@@ -138,9 +166,24 @@ type controllerRunOptions struct {
 	// Will instruct the machine-controller to skip the eviction if the machine deletion is older than skipEvictionAfter.
 	skipEvictionAfter time.Duration
 
+	// The default drain policy for nodes, which MachineDeployments can override.
+	drainPolicy eviction.Policy
+
+	// Cloud providers whose instances are only deleted once all volumes were detached from their node.
+	volumeDetachProviders []providerconfig.CloudProvider
+
 	// Enable NodeCSRApprover controller to automatically approve node serving certificate requests.
 	nodeCSRApprover bool
 
+	// Validate node serving certificate requests against the instance reported by the cloud provider.
+	nodeCSRApproverVerifyInstance bool
+
+	// Enable NodeCSRApprover controller to approve kubelet client certificate renewals of nodes backed by a Machine.
+	nodeCSRApproverClientCertificates bool
+
+	// The minimum interval between two approved client certificate requests of the same node.
+	nodeCSRClientCertificateInterval time.Duration
+
 	node machinecontroller.NodeSettings
 
 	// A port range to reserve for services with NodePort visibility.
@@ -148,6 +191,9 @@ type controllerRunOptions struct {
 
 	overrideBootstrapKubeletAPIServer string
 
+	// Allow secret and configmap references of providerSpecs to other namespaces than the one of the object.
+	allowCrossNamespaceRefs bool
+
 	log *zap.SugaredLogger
 }
 
@@ -175,13 +221,30 @@ func main() {
 
 	flag.StringVar(&joinClusterTimeout, "join-cluster-timeout", "", "when set, machines that have an owner and do not join the cluster within the configured duration will be deleted, so the owner re-creates them")
 	flag.StringVar(&bootstrapTokenServiceAccountName, "bootstrap-token-service-account-name", "", "When set use the service account token from this SA as bootstrap token instead of creating a temporary one. Passed in namespace/name format")
+	flag.DurationVar(&bootstrapTokenTTL, "bootstrap-token-ttl", 0, "When set, a bootstrap token valid for this duration is created for each Machine and injected into userdata containing the bootstrap token placeholder. It is revoked once the node joined or the Machine is deleted.")
 	flag.BoolVar(&profiling, "enable-profiling", false, "when set, enables the endpoints on the http server under /debug/pprof/")
 	flag.DurationVar(&skipEvictionAfter, "skip-eviction-after", 2*time.Hour, "Skips the eviction if a machine is not gone after the specified duration.")
+	flag.Int64Var(&drainGracePeriod, "drain-grace-period", -1, "Termination grace period in seconds for pods evicted while draining a node. If negative, the grace period of the pods is used.")
+	flag.StringVar(&drainSkipPodSelector, "drain-skip-pod-selector", "", "Label selector for pods which are not evicted while draining a node.")
+	flag.BoolVar(&drainDeleteEmptyDirData, "drain-delete-emptydir-data", true, "Evict pods using emptyDir volumes while draining a node. If false, the drain waits until such pods are removed otherwise.")
+	flag.DurationVar(&drainPodEvictionTimeout, "drain-pod-eviction-timeout", 0, "How long pods are evicted while draining a node, before the drain stops waiting for them. Zero means no timeout.")
+	flag.BoolVar(&drainDeleteAfterEvictionTimeout, "drain-delete-after-eviction-timeout", false, "Delete the remaining pods, bypassing PodDisruptionBudgets, once the drain-pod-eviction-timeout passed.")
+	flag.StringVar(&preDrainTaintEffect, "pre-drain-taint-effect", "", "Effect of the ToBeDeletedByMachineController taint applied to nodes before they are drained, either NoSchedule or PreferNoSchedule. If empty, no taint is applied.")
+	flag.DurationVar(&preDrainDelay, "pre-drain-delay", 0, "How long to wait after applying the pre-drain taint, before a node is cordoned and drained.")
+	flag.StringVar(&volumeDetachProviders, "wait-for-volume-detach-providers", string(providerconfig.CloudProviderVsphere), "Comma-separated list of cloud providers whose instances are only deleted once all volumes were detached from their node. MachineDeployments can override this in their drain policy.")
 	flag.BoolVar(&useExternalBootstrap, "use-external-bootstrap", true, "DEPRECATED: This flag is no-op and will have no effect since machine-controller only supports external bootstrap mechanism. This flag is only kept for backwards compatibility and will be removed in the future")
 	flag.StringVar(&overrideBootstrapKubeletAPIServer, "override-bootstrap-kubelet-apiserver", "", "Override for the API server address used in worker nodes bootstrap-kubelet.conf")
+	flag.StringVar(&workloadIdentityTokenFile, "workload-identity-token-file", "", "path to a projected ServiceAccount token which providers exchange for short-lived cloud credentials if the providerSpec uses workload identity")
+	flag.StringVar(&secretStoreConfig, "secret-store-config", "", "path to a file configuring the secret stores external key references in providerSpecs are resolved with")
+	flag.StringVar(&auditConfig, "audit-config", "", "path to a file configuring the sinks every mutating call to a cloud provider is recorded in")
+	flag.StringVar(&bootstrapHTTPAllowedURLs, "bootstrap-http-allowed-urls", "", "Comma-separated list of https URLs below which the http bootstrap provider may request userdata. If empty, the http bootstrap provider can not be used")
 	flag.StringVar(&caBundleFile, "ca-bundle", "", "path to a file containing all PEM-encoded CA certificates (will be used instead of the host's certificates if set)")
 	flag.BoolVar(&nodeCSRApprover, "node-csr-approver", true, "Enable NodeCSRApprover controller to automatically approve node serving certificate requests")
+	flag.BoolVar(&nodeCSRApproverVerifyInstance, "node-csr-approver-verify-instance-identity", false, "Validate node serving certificate requests against the running instance and its addresses reported by the cloud provider, and deny mismatching requests")
+	flag.BoolVar(&nodeCSRApproverClientCertificates, "node-csr-approver-client-certificates", false, "Enable NodeCSRApprover controller to approve kubelet client certificate renewals of nodes backed by a Machine")
+	flag.DurationVar(&nodeCSRClientCertificateInterval, "node-csr-client-certificate-approval-interval", 10*time.Minute, "Minimum interval between two approved client certificate requests of the same node")
 	flag.StringVar(&nodePortRange, "node-port-range", "30000-32767", "A port range to reserve for services with NodePort visibility")
+	flag.BoolVar(&allowCrossNamespaceRefs, "allow-cross-namespace-refs", false, "Allow secret and configmap references in providerSpecs to other namespaces than the one of the Machine")
 
 	flag.StringVar(&nodeHTTPProxy, "node-http-proxy", "", "DEPRECATED: This flag is no-op and will have no effect. This value should be configured in the user-data provider, such as operating-system-manager.")
 	flag.StringVar(&nodeNoProxy, "node-no-proxy", "", "DEPRECATED: This flag is no-op and will have no effect. This value should be configured in the user-data provider, such as operating-system-manager.")
@@ -239,6 +302,28 @@ func main() {
 		}
 	}
 
+	if err := bootstrap.SetAllowedHTTPURLs(bootstrapHTTPAllowedURLs); err != nil {
+		log.Fatalw("-bootstrap-http-allowed-urls is invalid", zap.Error(err))
+	}
+
+	if workloadIdentityTokenFile != "" {
+		if err := util.SetWorkloadIdentityTokenFile(workloadIdentityTokenFile); err != nil {
+			log.Fatalw("-workload-identity-token-file is invalid", zap.Error(err))
+		}
+	}
+
+	if secretStoreConfig != "" {
+		if err := secretstore.SetConfigFile(log, secretStoreConfig); err != nil {
+			log.Fatalw("-secret-store-config is invalid", zap.Error(err))
+		}
+	}
+
+	if auditConfig != "" {
+		if err := audit.SetConfigFile(log, auditConfig); err != nil {
+			log.Fatalw("-audit-config is invalid", zap.Error(err))
+		}
+	}
+
 	// rest.Config has no DeepCopy() that returns another rest.Config, thus
 	// we simply build it twice
 	// We need a dedicated one for machines because we want to increase the
@@ -257,6 +342,35 @@ func main() {
 	ctrlMetrics := machinecontroller.NewMachineControllerMetrics()
 	ctrlMetrics.MustRegister(metrics.Registry)
 
+	drainPolicy := eviction.Policy{
+		GracePeriodSeconds:         drainGracePeriod,
+		DeleteEmptyDirData:         drainDeleteEmptyDirData,
+		PodEvictionTimeout:         drainPodEvictionTimeout,
+		DeleteAfterEvictionTimeout: drainDeleteAfterEvictionTimeout,
+		PreDrainTaintEffect:        corev1.TaintEffect(preDrainTaintEffect),
+		PreDrainDelay:              preDrainDelay,
+	}
+	if err := eviction.ValidatePreDrainTaintEffect(drainPolicy.PreDrainTaintEffect); err != nil {
+		log.Fatalw("Invalid pre-drain-taint-effect", zap.Error(err))
+	}
+	if drainSkipPodSelector != "" {
+		drainPolicy.SkipPodSelector, err = labels.Parse(drainSkipPodSelector)
+		if err != nil {
+			log.Fatalw("Failed to parse drain-skip-pod-selector", zap.Error(err))
+		}
+	}
+
+	var parsedVolumeDetachProviders []providerconfig.CloudProvider
+	for _, provider := range strings.Split(volumeDetachProviders, ",") {
+		if provider = strings.TrimSpace(provider); provider == "" {
+			continue
+		}
+		if !slices.Contains(providerconfig.AllCloudProviders, providerconfig.CloudProvider(provider)) {
+			log.Fatalf("Unknown cloud provider %q in wait-for-volume-detach-providers", provider)
+		}
+		parsedVolumeDetachProviders = append(parsedVolumeDetachProviders, providerconfig.CloudProvider(provider))
+	}
+
 	runOptions := controllerRunOptions{
 		log:                               log,
 		kubeClient:                        kubeClient,
@@ -265,10 +379,17 @@ func main() {
 		cfg:                               machineCfg,
 		metrics:                           ctrlMetrics,
 		prometheusRegisterer:              metrics.Registry,
+		bootstrapTokenTTL:                 bootstrapTokenTTL,
 		skipEvictionAfter:                 skipEvictionAfter,
+		drainPolicy:                       drainPolicy,
+		volumeDetachProviders:             parsedVolumeDetachProviders,
 		nodeCSRApprover:                   nodeCSRApprover,
+		nodeCSRApproverVerifyInstance:     nodeCSRApproverVerifyInstance,
+		nodeCSRApproverClientCertificates: nodeCSRApproverClientCertificates,
+		nodeCSRClientCertificateInterval:  nodeCSRClientCertificateInterval,
 		nodePortRange:                     nodePortRange,
 		overrideBootstrapKubeletAPIServer: overrideBootstrapKubeletAPIServer,
+		allowCrossNamespaceRefs:           allowCrossNamespaceRefs,
 	}
 
 	if err := nodeFlags.UpdateNodeSettings(&runOptions.node); err != nil {
@@ -279,6 +400,10 @@ func main() {
 		runOptions.joinClusterTimeout = parsedJoinClusterTimeout
 	}
 
+	if bootstrapTokenServiceAccountName != "" && bootstrapTokenTTL > 0 {
+		log.Fatal("The bootstrap-token-service-account-name and bootstrap-token-ttl flags are mutually exclusive")
+	}
+
 	if bootstrapTokenServiceAccountName != "" {
 		flagParts := strings.Split(bootstrapTokenServiceAccountName, "/")
 		if flagPartsLen := len(flagParts); flagPartsLen != 2 {
@@ -408,10 +533,14 @@ func (bs *controllerBootstrap) Start(ctx context.Context) error {
 		bs.opt.joinClusterTimeout,
 		bs.opt.name,
 		bs.opt.bootstrapTokenServiceAccountName,
+		bs.opt.bootstrapTokenTTL,
 		bs.opt.skipEvictionAfter,
+		bs.opt.drainPolicy,
+		bs.opt.volumeDetachProviders,
 		bs.opt.node,
 		bs.opt.nodePortRange,
 		bs.opt.overrideBootstrapKubeletAPIServer,
+		bs.opt.allowCrossNamespaceRefs,
 	); err != nil {
 		return fmt.Errorf("failed to add Machine controller to manager: %w", err)
 	}
@@ -420,12 +549,12 @@ func (bs *controllerBootstrap) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to add MachineSet controller to manager: %w", err)
 	}
 
-	if err := machinedeploymentcontroller.Add(bs.mgr, bs.opt.log); err != nil {
+	if err := machinedeploymentcontroller.Add(bs.mgr, bs.opt.log, bs.opt.allowCrossNamespaceRefs); err != nil {
 		return fmt.Errorf("failed to add MachineDeployment controller to manager: %w", err)
 	}
 
 	if bs.opt.nodeCSRApprover {
-		if err := nodecsrapprover.Add(bs.mgr, bs.opt.log); err != nil {
+		if err := nodecsrapprover.Add(bs.mgr, bs.opt.log, bs.opt.nodeCSRApproverVerifyInstance, bs.opt.nodeCSRApproverClientCertificates, bs.opt.nodeCSRClientCertificateInterval, bs.opt.allowCrossNamespaceRefs); err != nil {
 			return fmt.Errorf("failed to add NodeCSRApprover controller to manager: %w", err)
 		}
 	}
@@ -19,33 +19,50 @@ package main
 import (
 	"flag"
 	"log"
+	"strings"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/go-logr/zapr"
 	"go.uber.org/zap"
 
 	"k8c.io/machine-controller/pkg/admission"
+	"k8c.io/machine-controller/pkg/bootstrap"
+	"k8c.io/machine-controller/pkg/cloudprovider"
 	"k8c.io/machine-controller/pkg/cloudprovider/util"
 	machinecontrollerlog "k8c.io/machine-controller/pkg/log"
 	"k8c.io/machine-controller/pkg/node"
+	"k8c.io/machine-controller/pkg/secretstore"
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
 
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/clientcmd"
+	ctrlruntimecache "sigs.k8s.io/controller-runtime/pkg/cache"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlruntimelog "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
 )
 
 type options struct {
-	masterURL               string
-	kubeconfig              string
-	admissionListenAddress  string
-	admissionTLSCertPath    string
-	admissionTLSKeyPath     string
-	caBundleFile            string
-	useExternalBootstrap    bool
-	namespace               string
-	workerClusterKubeconfig string
-	versionConstraint       string
+	masterURL                 string
+	kubeconfig                string
+	admissionListenAddress    string
+	admissionTLSCertPath      string
+	admissionTLSKeyPath       string
+	admissionTLSClientCAPath  string
+	webhookCAPath             string
+	mutatingWebhooks          string
+	validatingWebhooks        string
+	conversionWebhookCRDs     string
+	caBundleFile              string
+	bootstrapHTTPAllowedURLs  string
+	secretStoreConfig         string
+	workloadIdentityTokenFile string
+	useExternalBootstrap      bool
+	namespace                 string
+	workerClusterKubeconfig   string
+	versionConstraint         string
+	allowCrossNamespaceRefs   bool
 }
 
 func main() {
@@ -64,10 +81,19 @@ func main() {
 	flag.StringVar(&opt.admissionListenAddress, "listen-address", ":9876", "The address on which the MutatingWebhook will listen on")
 	flag.StringVar(&opt.admissionTLSCertPath, "tls-cert-path", "/tmp/cert/tls.crt", "The path of the TLS cert for the MutatingWebhook")
 	flag.StringVar(&opt.admissionTLSKeyPath, "tls-key-path", "/tmp/cert/tls.key", "The path of the TLS key for the MutatingWebhook")
+	flag.StringVar(&opt.admissionTLSClientCAPath, "tls-client-ca-path", "", "The path of a CA bundle to verify client certificates with. If set, the admission endpoints reject requests without a client certificate issued by it")
+	flag.StringVar(&opt.webhookCAPath, "webhook-ca-path", "", "The path of the CA bundle which is written into the caBundle of the configured webhook configurations")
+	flag.StringVar(&opt.mutatingWebhooks, "mutating-webhook-configurations", "", "Comma-separated list of MutatingWebhookConfigurations whose caBundle is set to the content of -webhook-ca-path")
+	flag.StringVar(&opt.validatingWebhooks, "validating-webhook-configurations", "", "Comma-separated list of ValidatingWebhookConfigurations whose caBundle is set to the content of -webhook-ca-path")
+	flag.StringVar(&opt.conversionWebhookCRDs, "conversion-webhook-crds", "", "Comma-separated list of CustomResourceDefinitions whose conversion webhook caBundle is set to the content of -webhook-ca-path")
+	flag.StringVar(&opt.workloadIdentityTokenFile, "workload-identity-token-file", "", "path to a projected ServiceAccount token which providers exchange for short-lived cloud credentials if the providerSpec uses workload identity")
+	flag.StringVar(&opt.secretStoreConfig, "secret-store-config", "", "path to a file configuring the secret stores external key references in providerSpecs are resolved with")
+	flag.StringVar(&opt.bootstrapHTTPAllowedURLs, "bootstrap-http-allowed-urls", "", "Comma-separated list of https URLs below which the http bootstrap provider may request userdata. If empty, the http bootstrap provider can not be used")
 	flag.StringVar(&opt.caBundleFile, "ca-bundle", "", "path to a file containing all PEM-encoded CA certificates (will be used instead of the host's certificates if set)")
 	flag.StringVar(&opt.namespace, "namespace", "kubermatic", "The namespace where the webhooks will run")
 	flag.StringVar(&opt.workerClusterKubeconfig, "worker-cluster-kubeconfig", "", "Path to kubeconfig of worker/user cluster where machines and machinedeployments exist. If not specified, value from --kubeconfig or in-cluster config will be used")
 	flag.StringVar(&opt.versionConstraint, "kubernetes-version-constraints", ">=0.0.0", "")
+	flag.BoolVar(&opt.allowCrossNamespaceRefs, "allow-cross-namespace-refs", false, "Allow secret and configmap references in providerSpecs to other namespaces than the one of the object")
 
 	flag.BoolVar(&opt.useExternalBootstrap, "use-external-bootstrap", true, "DEPRECATED: This flag is no-op and will have no effect since machine-controller only supports external bootstrap mechanism. This flag is only kept for backwards compatibility and will be removed in the future")
 
@@ -92,6 +118,31 @@ func main() {
 		}
 	}
 
+	if err := bootstrap.SetAllowedHTTPURLs(opt.bootstrapHTTPAllowedURLs); err != nil {
+		log.Fatalw("-bootstrap-http-allowed-urls is invalid", zap.Error(err))
+	}
+
+	if opt.workloadIdentityTokenFile != "" {
+		if err := util.SetWorkloadIdentityTokenFile(opt.workloadIdentityTokenFile); err != nil {
+			log.Fatalw("-workload-identity-token-file is invalid", zap.Error(err))
+		}
+	}
+
+	if opt.secretStoreConfig != "" {
+		if err := secretstore.SetConfigFile(log, opt.secretStoreConfig); err != nil {
+			log.Fatalw("-secret-store-config is invalid", zap.Error(err))
+		}
+	}
+
+	// Needed to check the selectors of MachineDeployments and MachineSets for overlaps
+	if err := clusterv1alpha1.AddToScheme(scheme.Scheme); err != nil {
+		log.Fatalw("Failed to add api to scheme", "api", clusterv1alpha1.SchemeGroupVersion, zap.Error(err))
+	}
+	// Needed to write the caBundle of the conversion webhooks into the CRDs
+	if err := apiextensionsv1.AddToScheme(scheme.Scheme); err != nil {
+		log.Fatalw("Failed to add api to scheme", "api", apiextensionsv1.SchemeGroupVersion, zap.Error(err))
+	}
+
 	cfg, err := clientcmd.BuildConfigFromFlags(opt.masterURL, opt.kubeconfig)
 	if err != nil {
 		log.Fatalw("Failed to build kubeconfig", zap.Error(err))
@@ -109,9 +160,10 @@ func main() {
 
 	// Start with assuming that current cluster will be used as worker cluster
 	workerClient := client
+	workerClusterConfig := cfg
 	// Handing for worker client
 	if opt.workerClusterKubeconfig != "" {
-		workerClusterConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		workerClusterConfig, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
 			&clientcmd.ClientConfigLoadingRules{ExplicitPath: opt.workerClusterKubeconfig},
 			&clientcmd.ConfigOverrides{}).ClientConfig()
 		if err != nil {
@@ -134,12 +186,16 @@ func main() {
 		Namespace:          opt.namespace,
 		VersionConstraints: constraint,
 
+		AllowCrossNamespaceRefs: opt.allowCrossNamespaceRefs,
+
 		// we could change this to get the CertDir from the configured CertName
 		// and KeyName, but doing so does not bring us any benefits but would
 		// technically break compatibility.
 		CertDir:  "/",
 		CertName: opt.admissionTLSCertPath,
 		KeyName:  opt.admissionTLSKeyPath,
+
+		ClientCAName: opt.admissionTLSClientCAPath,
 	}.Build()
 	if err != nil {
 		log.Fatalw("Failed to create admission hook", zap.Error(err))
@@ -148,7 +204,49 @@ func main() {
 	log.Infow("Listening", "address", opt.admissionListenAddress)
 
 	serverContext := signals.SetupSignalHandler()
+
+	// Drop cached validation results once referenced credentials are rotated. They are
+	// read from the worker cluster, so that is where they are watched as well.
+	informerCache, err := ctrlruntimecache.New(workerClusterConfig, ctrlruntimecache.Options{Scheme: scheme.Scheme})
+	if err != nil {
+		log.Fatalw("Failed to build informer cache", zap.Error(err))
+	}
+	if err := cloudprovider.WatchCredentials(serverContext, log, informerCache); err != nil {
+		log.Fatalw("Failed to watch credentials", zap.Error(err))
+	}
+	go func() {
+		if err := informerCache.Start(serverContext); err != nil {
+			log.Fatalw("Failed to start informer cache", zap.Error(err))
+		}
+	}()
+
+	if opt.webhookCAPath != "" {
+		if opt.mutatingWebhooks == "" && opt.validatingWebhooks == "" && opt.conversionWebhookCRDs == "" {
+			log.Fatal("-webhook-ca-path requires -mutating-webhook-configurations, -validating-webhook-configurations or -conversion-webhook-crds")
+		}
+		injector := &admission.CABundleInjector{
+			Log:                             log,
+			Client:                          client,
+			CAFile:                          opt.webhookCAPath,
+			MutatingWebhookConfigurations:   splitList(opt.mutatingWebhooks),
+			ValidatingWebhookConfigurations: splitList(opt.validatingWebhooks),
+			CustomResourceDefinitions:       splitList(opt.conversionWebhookCRDs),
+		}
+		go injector.Start(serverContext)
+	}
+
 	if err := srv.Start(serverContext); err != nil {
 		log.Fatalw("Failed to start server", zap.Error(err))
 	}
 }
+
+// splitList returns the non-empty elements of a comma-separated list.
+func splitList(list string) []string {
+	var elements []string
+	for _, element := range strings.Split(list, ",") {
+		if element = strings.TrimSpace(element); element != "" {
+			elements = append(elements, element)
+		}
+	}
+	return elements
+}
@@ -18,12 +18,14 @@ package admission
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"path/filepath"
 	"reflect"
 	"strconv"
 
@@ -50,6 +52,10 @@ type admissionData struct {
 	nodeSettings machinecontroller.NodeSettings
 	namespace    string
 	constraints  *semver.Constraints
+
+	allowCrossNamespaceRefs bool
+
+	machinePolicyPrograms *machinePolicyPrograms
 }
 
 var jsonPatch = admissionv1.PatchTypeJSONPatch
@@ -63,9 +69,17 @@ type Builder struct {
 	Namespace          string
 	VersionConstraints *semver.Constraints
 
+	// AllowCrossNamespaceRefs allows providerSpecs to reference secrets and configmaps
+	// of other namespaces than the one of the object.
+	AllowCrossNamespaceRefs bool
+
 	CertDir  string
 	CertName string
 	KeyName  string
+	// ClientCAName is the CA bundle the client certificates of the API server are verified with.
+	// The admission endpoints reject requests without a verified client certificate if it is set.
+	// The serving certificate and the client CA bundle are reloaded once their files change.
+	ClientCAName string
 }
 
 func (build Builder) Build() (webhook.Server, error) {
@@ -75,16 +89,27 @@ func (build Builder) Build() (webhook.Server, error) {
 		workerClient: build.WorkerClient,
 		namespace:    build.Namespace,
 		constraints:  build.VersionConstraints,
+
+		allowCrossNamespaceRefs: build.AllowCrossNamespaceRefs,
+
+		machinePolicyPrograms: newMachinePolicyPrograms(),
 	}
 
 	if err := build.NodeFlags.UpdateNodeSettings(&ad.nodeSettings); err != nil {
 		return nil, fmt.Errorf("error updating nodeSettings, %w", err)
 	}
 
+	clientCAFile := ""
+	if build.ClientCAName != "" {
+		clientCAFile = filepath.Join(build.CertDir, build.ClientCAName)
+	}
+	certs, err := newCertificates(build.Log, filepath.Join(build.CertDir, build.CertName), filepath.Join(build.CertDir, build.KeyName), clientCAFile)
+	if err != nil {
+		return nil, err
+	}
+
 	options := webhook.Options{
-		CertDir:  build.CertDir,
-		CertName: build.CertName,
-		KeyName:  build.KeyName,
+		TLSOpts: []func(*tls.Config){certs.configure},
 	}
 
 	if build.ListenAddress != "" {
@@ -107,8 +132,19 @@ func (build Builder) Build() (webhook.Server, error) {
 
 	server := webhook.NewServer(options)
 
-	server.Register("/machinedeployments", handleFuncFactory(build.Log, ad.mutateMachineDeployments))
-	server.Register("/machines", handleFuncFactory(build.Log, ad.mutateMachines))
+	// The API server does not send client certificates to conversion webhooks and /validate is
+	// called by users, so only the admission endpoints require them.
+	server.Register("/machinedeployments", certs.requireClientCertificate(handleFuncFactory(build.Log, ad.mutateMachineDeployments)))
+	server.Register("/machinesets", certs.requireClientCertificate(handleFuncFactory(build.Log, ad.mutateMachineSets)))
+	server.Register("/machines", certs.requireClientCertificate(handleFuncFactory(build.Log, ad.mutateMachines)))
+	server.Register("/machinepolicies", certs.requireClientCertificate(handleFuncFactory(build.Log, ad.validateMachinePolicy)))
+	server.Register("/validate", http.HandlerFunc(ad.handleDryRunValidation))
+
+	conversionHandler, err := newConversionHandler()
+	if err != nil {
+		return nil, err
+	}
+	server.Register("/convert", conversionHandler)
 
 	checkers := healthz.Handler{
 		Checks: map[string]healthz.Checker{
@@ -117,7 +153,7 @@ func (build Builder) Build() (webhook.Server, error) {
 	}
 	server.Register("/healthz/", http.StripPrefix("/healthz/", &checkers))
 
-	return server, nil
+	return &tlsServer{Server: server, certificates: certs}, nil
 }
 
 func newJSONPatch(original, current runtime.Object) ([]jsonpatch.JsonPatchOperation, error) {
@@ -0,0 +1,172 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const defaultCABundleSyncInterval = time.Minute
+
+// CABundleInjector writes a CA bundle into the caBundle of all webhooks of the named
+// Mutating- and ValidatingWebhookConfigurations and of the conversion webhooks of the named
+// CustomResourceDefinitions, so the API server trusts the serving certificate of the webhook
+// without an external injector like the cert-manager cainjector.
+type CABundleInjector struct {
+	Log    *zap.SugaredLogger
+	Client ctrlruntimeclient.Client
+	// CAFile is the path of the PEM-encoded CA bundle. It is read again on every sync, so
+	// rotated CAs are picked up.
+	CAFile                          string
+	MutatingWebhookConfigurations   []string
+	ValidatingWebhookConfigurations []string
+	// CustomResourceDefinitions are the CRDs converted by the /convert endpoint. CRDs which
+	// do not use the Webhook conversion strategy are skipped.
+	CustomResourceDefinitions []string
+	// Interval is how often the configurations are synced. Defaults to one minute.
+	Interval time.Duration
+}
+
+// Start syncs the configurations until the context is done.
+func (i *CABundleInjector) Start(ctx context.Context) {
+	interval := i.Interval
+	if interval <= 0 {
+		interval = defaultCABundleSyncInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := i.Sync(ctx); err != nil {
+			i.Log.Errorw("Failed to sync webhook CA bundles", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync writes the CA bundle into every configuration and CRD whose webhooks do not use it yet.
+// Configurations and CRDs which do not exist are skipped.
+func (i *CABundleInjector) Sync(ctx context.Context) error {
+	caBundle, err := os.ReadFile(i.CAFile)
+	if err != nil {
+		return fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+	if len(bytes.TrimSpace(caBundle)) == 0 {
+		return fmt.Errorf("CA bundle %q is empty", i.CAFile)
+	}
+
+	var errs []error
+	for _, name := range i.MutatingWebhookConfigurations {
+		configuration := &admissionregistrationv1.MutatingWebhookConfiguration{}
+		if err := i.Client.Get(ctx, ctrlruntimeclient.ObjectKey{Name: name}, configuration); err != nil {
+			if !kerrors.IsNotFound(err) {
+				errs = append(errs, fmt.Errorf("failed to get MutatingWebhookConfiguration %q: %w", name, err))
+			}
+			continue
+		}
+
+		original := configuration.DeepCopy()
+		changed := false
+		for idx := range configuration.Webhooks {
+			changed = setCABundle(&configuration.Webhooks[idx].ClientConfig, caBundle) || changed
+		}
+		if changed {
+			if err := i.Client.Patch(ctx, configuration, ctrlruntimeclient.MergeFrom(original)); err != nil {
+				errs = append(errs, fmt.Errorf("failed to update MutatingWebhookConfiguration %q: %w", name, err))
+				continue
+			}
+			i.Log.Infow("Updated caBundle", "mutatingwebhookconfiguration", name)
+		}
+	}
+
+	for _, name := range i.ValidatingWebhookConfigurations {
+		configuration := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+		if err := i.Client.Get(ctx, ctrlruntimeclient.ObjectKey{Name: name}, configuration); err != nil {
+			if !kerrors.IsNotFound(err) {
+				errs = append(errs, fmt.Errorf("failed to get ValidatingWebhookConfiguration %q: %w", name, err))
+			}
+			continue
+		}
+
+		original := configuration.DeepCopy()
+		changed := false
+		for idx := range configuration.Webhooks {
+			changed = setCABundle(&configuration.Webhooks[idx].ClientConfig, caBundle) || changed
+		}
+		if changed {
+			if err := i.Client.Patch(ctx, configuration, ctrlruntimeclient.MergeFrom(original)); err != nil {
+				errs = append(errs, fmt.Errorf("failed to update ValidatingWebhookConfiguration %q: %w", name, err))
+				continue
+			}
+			i.Log.Infow("Updated caBundle", "validatingwebhookconfiguration", name)
+		}
+	}
+
+	for _, name := range i.CustomResourceDefinitions {
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		if err := i.Client.Get(ctx, ctrlruntimeclient.ObjectKey{Name: name}, crd); err != nil {
+			if !kerrors.IsNotFound(err) {
+				errs = append(errs, fmt.Errorf("failed to get CustomResourceDefinition %q: %w", name, err))
+			}
+			continue
+		}
+
+		conversion := crd.Spec.Conversion
+		if conversion == nil || conversion.Strategy != apiextensionsv1.WebhookConverter || conversion.Webhook == nil || conversion.Webhook.ClientConfig == nil {
+			continue
+		}
+		if bytes.Equal(conversion.Webhook.ClientConfig.CABundle, caBundle) {
+			continue
+		}
+
+		original := crd.DeepCopy()
+		conversion.Webhook.ClientConfig.CABundle = caBundle
+		if err := i.Client.Patch(ctx, crd, ctrlruntimeclient.MergeFrom(original)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to update CustomResourceDefinition %q: %w", name, err))
+			continue
+		}
+		i.Log.Infow("Updated caBundle", "customresourcedefinition", name)
+	}
+
+	return errors.Join(errs...)
+}
+
+func setCABundle(config *admissionregistrationv1.WebhookClientConfig, caBundle []byte) bool {
+	if bytes.Equal(config.CABundle, caBundle) {
+		return false
+	}
+	config.CABundle = caBundle
+	return true
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCABundleInjectorSync(t *testing.T) {
+	ctx := context.Background()
+
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	if err := os.WriteFile(caFile, []byte("first"), 0o600); err != nil {
+		t.Fatalf("failed to write CA bundle: %v", err)
+	}
+
+	testScheme := runtime.NewScheme()
+	if err := scheme.AddToScheme(testScheme); err != nil {
+		t.Fatalf("failed to add api to scheme: %v", err)
+	}
+	if err := apiextensionsv1.AddToScheme(testScheme); err != nil {
+		t.Fatalf("failed to add api to scheme: %v", err)
+	}
+
+	client := clientfake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+		&admissionregistrationv1.MutatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "machine-controller"},
+			Webhooks: []admissionregistrationv1.MutatingWebhook{
+				{Name: "machines.machine-controller.kubermatic.io"},
+				{Name: "machinesets.machine-controller.kubermatic.io", ClientConfig: admissionregistrationv1.WebhookClientConfig{CABundle: []byte("stale")}},
+			},
+		},
+		&admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "machine-controller"},
+			Webhooks:   []admissionregistrationv1.ValidatingWebhook{{Name: "machines.machine-controller.kubermatic.io"}},
+		},
+		&admissionregistrationv1.MutatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "unrelated"},
+			Webhooks:   []admissionregistrationv1.MutatingWebhook{{Name: "unrelated.example.com"}},
+		},
+		&apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "machines.cluster.k8s.io"},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Conversion: &apiextensionsv1.CustomResourceConversion{
+					Strategy: apiextensionsv1.WebhookConverter,
+					Webhook: &apiextensionsv1.WebhookConversion{
+						ClientConfig: &apiextensionsv1.WebhookClientConfig{CABundle: []byte("stale")},
+					},
+				},
+			},
+		},
+		&apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "machinepolicies.cluster.k8s.io"},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Conversion: &apiextensionsv1.CustomResourceConversion{Strategy: apiextensionsv1.NoneConverter},
+			},
+		},
+	).Build()
+
+	injector := &CABundleInjector{
+		Log:                             zap.NewNop().Sugar(),
+		Client:                          client,
+		CAFile:                          caFile,
+		MutatingWebhookConfigurations:   []string{"machine-controller", "missing"},
+		ValidatingWebhookConfigurations: []string{"machine-controller"},
+		CustomResourceDefinitions:       []string{"machines.cluster.k8s.io", "machinepolicies.cluster.k8s.io", "missing.cluster.k8s.io"},
+	}
+
+	expectCABundles := func(expected string) {
+		t.Helper()
+
+		mutating := &admissionregistrationv1.MutatingWebhookConfiguration{}
+		if err := client.Get(ctx, ctrlruntimeclient.ObjectKey{Name: "machine-controller"}, mutating); err != nil {
+			t.Fatalf("failed to get MutatingWebhookConfiguration: %v", err)
+		}
+		for _, webhook := range mutating.Webhooks {
+			if string(webhook.ClientConfig.CABundle) != expected {
+				t.Errorf("expected caBundle %q for webhook %s, got %q", expected, webhook.Name, webhook.ClientConfig.CABundle)
+			}
+		}
+
+		validating := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+		if err := client.Get(ctx, ctrlruntimeclient.ObjectKey{Name: "machine-controller"}, validating); err != nil {
+			t.Fatalf("failed to get ValidatingWebhookConfiguration: %v", err)
+		}
+		for _, webhook := range validating.Webhooks {
+			if string(webhook.ClientConfig.CABundle) != expected {
+				t.Errorf("expected caBundle %q for webhook %s, got %q", expected, webhook.Name, webhook.ClientConfig.CABundle)
+			}
+		}
+
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		if err := client.Get(ctx, ctrlruntimeclient.ObjectKey{Name: "machines.cluster.k8s.io"}, crd); err != nil {
+			t.Fatalf("failed to get CustomResourceDefinition: %v", err)
+		}
+		if caBundle := crd.Spec.Conversion.Webhook.ClientConfig.CABundle; string(caBundle) != expected {
+			t.Errorf("expected caBundle %q for the conversion webhook, got %q", expected, caBundle)
+		}
+	}
+
+	if err := injector.Sync(ctx); err != nil {
+		t.Fatalf("failed to sync: %v", err)
+	}
+	expectCABundles("first")
+
+	if err := os.WriteFile(caFile, []byte("second"), 0o600); err != nil {
+		t.Fatalf("failed to write CA bundle: %v", err)
+	}
+	if err := injector.Sync(ctx); err != nil {
+		t.Fatalf("failed to sync: %v", err)
+	}
+	expectCABundles("second")
+
+	unrelated := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	if err := client.Get(ctx, ctrlruntimeclient.ObjectKey{Name: "unrelated"}, unrelated); err != nil {
+		t.Fatalf("failed to get MutatingWebhookConfiguration: %v", err)
+	}
+	if len(unrelated.Webhooks[0].ClientConfig.CABundle) != 0 {
+		t.Error("expected configurations which are not named to be left alone")
+	}
+
+	withoutWebhook := &apiextensionsv1.CustomResourceDefinition{}
+	if err := client.Get(ctx, ctrlruntimeclient.ObjectKey{Name: "machinepolicies.cluster.k8s.io"}, withoutWebhook); err != nil {
+		t.Fatalf("failed to get CustomResourceDefinition: %v", err)
+	}
+	if withoutWebhook.Spec.Conversion.Webhook != nil {
+		t.Error("expected CRDs without conversion webhook to be left alone")
+	}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"fmt"
+	"net/http"
+
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+	clusterv1alpha2 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha2"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+)
+
+// newConversionHandler returns the handler of the CRD conversion webhook, which converts
+// Machines, MachineSets and MachineDeployments between the served versions.
+func newConversionHandler() (http.Handler, error) {
+	scheme := runtime.NewScheme()
+	if err := clusterv1alpha1.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("failed to add cluster v1alpha1 types to scheme: %w", err)
+	}
+	if err := clusterv1alpha2.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("failed to add cluster v1alpha2 types to scheme: %w", err)
+	}
+
+	return conversion.NewWebhookHandler(scheme, conversion.NewRegistry()), nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+	clusterv1alpha2 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha2"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestConversionHandler(t *testing.T) {
+	handler, err := newConversionHandler()
+	if err != nil {
+		t.Fatalf("failed to create conversion handler: %v", err)
+	}
+
+	machine := &clusterv1alpha1.Machine{
+		TypeMeta:   metav1.TypeMeta{APIVersion: clusterv1alpha1.SchemeGroupVersion.String(), Kind: "Machine"},
+		ObjectMeta: metav1.ObjectMeta{Name: "machine", Namespace: "kube-system"},
+		Spec: clusterv1alpha1.MachineSpec{
+			ProviderSpec: clusterv1alpha1.ProviderSpec{
+				Value: &runtime.RawExtension{Raw: []byte(`{"cloudProvider":"hetzner","operatingSystem":"ubuntu"}`)},
+			},
+		},
+		Status: clusterv1alpha1.MachineStatus{
+			Conditions: []corev1.NodeCondition{{Type: clusterv1alpha1.MachineDrainingCondition, Status: corev1.ConditionTrue}},
+		},
+	}
+	rawMachine, err := json.Marshal(machine)
+	if err != nil {
+		t.Fatalf("failed to marshal machine: %v", err)
+	}
+	body, err := json.Marshal(&apiextensionsv1.ConversionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: apiextensionsv1.SchemeGroupVersion.String(), Kind: "ConversionReview"},
+		Request: &apiextensionsv1.ConversionRequest{
+			UID:               types.UID("uid"),
+			DesiredAPIVersion: clusterv1alpha2.SchemeGroupVersion.String(),
+			Objects:           []runtime.RawExtension{{Raw: rawMachine}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal conversion review: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/convert", bytes.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+
+	review := &apiextensionsv1.ConversionReview{}
+	if err := json.Unmarshal(rec.Body.Bytes(), review); err != nil {
+		t.Fatalf("failed to decode conversion review: %v", err)
+	}
+	if review.Response.Result.Status != metav1.StatusSuccess {
+		t.Fatalf("conversion failed: %s", review.Response.Result.Message)
+	}
+	if len(review.Response.ConvertedObjects) != 1 {
+		t.Fatalf("expected one converted object, got %d", len(review.Response.ConvertedObjects))
+	}
+
+	converted := &clusterv1alpha2.Machine{}
+	if err := json.Unmarshal(review.Response.ConvertedObjects[0].Raw, converted); err != nil {
+		t.Fatalf("failed to decode converted machine: %v", err)
+	}
+	if converted.APIVersion != clusterv1alpha2.SchemeGroupVersion.String() {
+		t.Errorf("expected apiVersion %s, got %s", clusterv1alpha2.SchemeGroupVersion, converted.APIVersion)
+	}
+	if converted.Spec.ProviderSpec.CloudProvider != "hetzner" {
+		t.Errorf("expected cloud provider hetzner, got %q", converted.Spec.ProviderSpec.CloudProvider)
+	}
+	if value := string(converted.Spec.ProviderSpec.Value.Raw); value != `{"operatingSystem":"ubuntu"}` {
+		t.Errorf("expected the cloud provider to be removed from the value, got %s", value)
+	}
+	if len(converted.Status.Conditions) != 1 || converted.Status.Conditions[0].Type != clusterv1alpha2.MachineDrainingCondition {
+		t.Errorf("expected the Draining condition, got %v", converted.Status.Conditions)
+	}
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"k8c.io/machine-controller/pkg/cloudprovider"
+	cloudprovidertypes "k8c.io/machine-controller/pkg/cloudprovider/types"
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+	"k8c.io/machine-controller/sdk/providerconfig"
+	"k8c.io/machine-controller/sdk/providerconfig/configvar"
+	"k8c.io/machine-controller/sdk/userdata"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// maxDryRunRequestSize limits the size of dry-run validation requests.
+const maxDryRunRequestSize = 1 << 20
+
+// DryRunRequest is the body of a request to the dry-run validation endpoint.
+type DryRunRequest struct {
+	// Namespace is the namespace the providerSpec is validated for. The caller must be allowed
+	// to create Machines in it, and references and MachinePolicies are checked for it.
+	Namespace    string                       `json:"namespace"`
+	ProviderSpec clusterv1alpha1.ProviderSpec `json:"providerSpec"`
+}
+
+// DryRunResponse is the result of a dry-run validation.
+type DryRunResponse struct {
+	Valid  bool          `json:"valid"`
+	Errors []DryRunError `json:"errors,omitempty"`
+	// ProviderSpec is the defaulted providerSpec. It is omitted if the providerSpec
+	// could not be defaulted.
+	ProviderSpec *clusterv1alpha1.ProviderSpec `json:"providerSpec,omitempty"`
+}
+
+// DryRunError is a single validation error of a dry-run validation.
+type DryRunError struct {
+	Field  string `json:"field"`
+	Type   string `json:"type"`
+	Detail string `json:"detail"`
+}
+
+// handleDryRunValidation defaults and validates the providerSpec of the request without
+// persisting anything and reports all errors at once. Since the validation resolves
+// references and calls the cloud provider with the credentials of the webhook, callers
+// must authenticate with a bearer token and be allowed to create Machines in the namespace.
+func (ad *admissionData) handleDryRunValidation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	userInfo, err := ad.authenticate(r)
+	if err != nil {
+		ad.log.Errorw("Failed to authenticate dry-run validation request", zap.Error(err))
+		http.Error(w, "failed to authenticate request", http.StatusInternalServerError)
+		return
+	}
+	if userInfo == nil {
+		http.Error(w, "a valid bearer token is required", http.StatusUnauthorized)
+		return
+	}
+
+	request := DryRunRequest{}
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxDryRunRequestSize)).Decode(&request); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if request.Namespace == "" {
+		http.Error(w, "invalid request: namespace is required", http.StatusBadRequest)
+		return
+	}
+
+	allowed, err := ad.isAllowed(r.Context(), userInfo, &authorizationv1.ResourceAttributes{
+		Namespace: request.Namespace,
+		Verb:      "create",
+		Group:     clusterv1alpha1.GroupName,
+		Resource:  "machines",
+	})
+	if err != nil {
+		ad.log.Errorw("Failed to authorize dry-run validation request", zap.Error(err))
+		http.Error(w, "failed to authorize request", http.StatusInternalServerError)
+		return
+	}
+	if !allowed {
+		http.Error(w, fmt.Sprintf("user %q may not create machines in namespace %s", userInfo.Username, request.Namespace), http.StatusForbidden)
+		return
+	}
+
+	providerSpec, allErrs, err := ad.dryRunValidateProviderSpec(r.Context(), request.Namespace, userInfo, request.ProviderSpec)
+	if err != nil {
+		ad.log.Errorw("Failed to validate providerSpec", zap.Error(err))
+		http.Error(w, fmt.Sprintf("failed to validate providerSpec: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	response := DryRunResponse{
+		Valid:        len(allErrs) == 0,
+		ProviderSpec: providerSpec,
+	}
+	for _, e := range allErrs {
+		response.Errors = append(response.Errors, DryRunError{
+			Field:  e.Field,
+			Type:   string(e.Type),
+			Detail: e.Detail,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		ad.log.Errorw("Failed to write dry-run validation response", zap.Error(err))
+	}
+}
+
+// authenticate reviews the bearer token of the request. It returns nil if the request has no
+// valid token.
+func (ad *admissionData) authenticate(r *http.Request) (*authenticationv1.UserInfo, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || strings.TrimSpace(token) == "" {
+		return nil, nil
+	}
+
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: strings.TrimSpace(token)},
+	}
+	if err := ad.workerClient.Create(r.Context(), review); err != nil {
+		return nil, fmt.Errorf("failed to create TokenReview: %w", err)
+	}
+	if !review.Status.Authenticated {
+		return nil, nil
+	}
+	return &review.Status.User, nil
+}
+
+// dryRunValidateProviderSpec defaults the providerSpec like the webhook does for new machines and
+// collects all errors of it. Failures which prevent the validation are returned as error.
+func (ad *admissionData) dryRunValidateProviderSpec(ctx context.Context, namespace string, userInfo *authenticationv1.UserInfo, providerSpec clusterv1alpha1.ProviderSpec) (*clusterv1alpha1.ProviderSpec, field.ErrorList, error) {
+	fldPath := field.NewPath("spec", "providerSpec", "value")
+
+	providerConfig, err := providerconfig.GetConfig(providerSpec)
+	if err != nil {
+		return nil, field.ErrorList{field.Invalid(fldPath, field.OmitValueType{}, err.Error())}, nil
+	}
+
+	// Packet has been renamed to Equinix Metal
+	if providerConfig.CloudProvider == cloudProviderPacket {
+		if err := migrateToEquinixMetal(providerConfig); err != nil {
+			return nil, field.ErrorList{field.Invalid(fldPath.Child("cloudProviderSpec"), field.OmitValueType{}, err.Error())}, nil
+		}
+	}
+
+	credentialErrs, err := ad.validateCredentialReferences(ctx, namespace, userInfo, providerConfig, fldPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(credentialErrs) > 0 {
+		return nil, credentialErrs, nil
+	}
+
+	configResolver := configvar.NewResolver(ctx, ad.workerClient, append(
+		configvar.ForConfig(namespace, providerConfig, ad.allowCrossNamespaceRefs),
+		configvar.WithAccessor("dry-run validation"),
+	)...)
+	prov, err := cloudprovider.ForProvider(providerConfig.CloudProvider, configResolver)
+	if err != nil {
+		return nil, field.ErrorList{field.Invalid(fldPath.Child("cloudProvider"), providerConfig.CloudProvider, err.Error())}, nil
+	}
+	fieldValidator, ok := prov.(cloudprovidertypes.FieldValidator)
+	if !ok {
+		return nil, nil, errors.New("cloud provider does not support field validation")
+	}
+
+	allErrs := field.ErrorList{}
+	if err := providerConfig.OperatingSystem.Validate(); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("operatingSystem"), providerConfig.OperatingSystem, err.Error()))
+	}
+	if err := validatePublicKeys(providerConfig.SSHPublicKeys); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("sshPublicKeys"), field.OmitValueType{}, err.Error()))
+	}
+
+	operatingSystemSpec, err := userdata.DefaultOperatingSystemSpec(providerConfig.OperatingSystem, providerConfig.OperatingSystemSpec)
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("operatingSystemSpec"), field.OmitValueType{}, err.Error()))
+	} else {
+		providerConfig.OperatingSystemSpec = operatingSystemSpec
+	}
+
+	spec := clusterv1alpha1.MachineSpec{ProviderSpec: *providerSpec.DeepCopy()}
+	spec.ProviderSpec.Value.Raw, err = json.Marshal(providerConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to json marshal providerSpec: %w", err)
+	}
+
+	defaultedSpec, err := prov.AddDefaults(ad.log, spec)
+	if err != nil {
+		return nil, append(allErrs, field.Invalid(fldPath, field.OmitValueType{}, fmt.Sprintf("failed to default: %v", err))), nil
+	}
+
+	providerErrs, err := fieldValidator.ValidateFields(ctx, ad.log, defaultedSpec, fldPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	allErrs = append(allErrs, providerErrs...)
+
+	policyErrs, err := ad.validateMachinePolicies(ctx, namespace, &defaultedSpec, fldPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	allErrs = append(allErrs, policyErrs...)
+
+	return &defaultedSpec.ProviderSpec, allErrs, nil
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"k8c.io/machine-controller/pkg/cloudprovider/provider/fake"
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+	"k8c.io/machine-controller/sdk/providerconfig"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func newDryRunRequestBody(t *testing.T, namespace string, passValidation bool, os providerconfig.OperatingSystem, sshKeys ...string) []byte {
+	t.Helper()
+	fakeRaw, err := json.Marshal(&fake.CloudProviderSpec{PassValidation: passValidation})
+	if err != nil {
+		t.Fatalf("marshal fake spec: %v", err)
+	}
+	cfg := providerconfig.Config{
+		CloudProvider:     providerconfig.CloudProviderFake,
+		CloudProviderSpec: runtime.RawExtension{Raw: fakeRaw},
+		OperatingSystem:   os,
+		SSHPublicKeys:     sshKeys,
+	}
+	rawCfg, err := json.Marshal(&cfg)
+	if err != nil {
+		t.Fatalf("marshal providerconfig: %v", err)
+	}
+	body, err := json.Marshal(&DryRunRequest{
+		Namespace:    namespace,
+		ProviderSpec: clusterv1alpha1.ProviderSpec{Value: &runtime.RawExtension{Raw: rawCfg}},
+	})
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+	return body
+}
+
+// dryRunInterceptors authenticate the tokens "alice" and "bob" as the users of the same name. Only
+// alice may create Machines.
+var dryRunInterceptors = interceptor.Funcs{
+	Create: func(ctx context.Context, client ctrlruntimeclient.WithWatch, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.CreateOption) error {
+		switch review := obj.(type) {
+		case *authenticationv1.TokenReview:
+			if token := review.Spec.Token; token == "alice" || token == "bob" {
+				review.Status.Authenticated = true
+				review.Status.User = authenticationv1.UserInfo{Username: token}
+			}
+			return nil
+		case *authorizationv1.SubjectAccessReview:
+			attributes := review.Spec.ResourceAttributes
+			review.Status.Allowed = review.Spec.User == "alice" && attributes.Verb == "create" && attributes.Resource == "machines" && attributes.Namespace == "team-a"
+			return nil
+		}
+		return client.Create(ctx, obj, opts...)
+	},
+}
+
+func TestHandleDryRunValidation(t *testing.T) {
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}}
+	policy := newMachinePolicy(nil, clusterv1alpha1.MachinePolicyRule{
+		Name:       "flatcar-only",
+		Expression: `providerConfig.operatingSystem == "flatcar"`,
+		FieldPath:  "operatingSystem",
+	})
+
+	tests := []struct {
+		name           string
+		method         string
+		body           []byte
+		token          string
+		policies       []ctrlruntimeclient.Object
+		expectedStatus int
+		expectedPaths  []string
+	}{
+		{
+			name:           "valid providerSpec",
+			method:         http.MethodPost,
+			body:           newDryRunRequestBody(t, namespace.Name, true, providerconfig.OperatingSystemUbuntu),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "failing provider validation",
+			method:         http.MethodPost,
+			body:           newDryRunRequestBody(t, namespace.Name, false, providerconfig.OperatingSystemUbuntu),
+			expectedStatus: http.StatusOK,
+			expectedPaths:  []string{"spec.providerSpec.value.cloudProviderSpec.passValidation"},
+		},
+		{
+			name:           "all errors are collected",
+			method:         http.MethodPost,
+			body:           newDryRunRequestBody(t, namespace.Name, false, providerconfig.OperatingSystemUbuntu, "not-a-key"),
+			expectedStatus: http.StatusOK,
+			expectedPaths: []string{
+				"spec.providerSpec.value.sshPublicKeys",
+				"spec.providerSpec.value.cloudProviderSpec.passValidation",
+			},
+		},
+		{
+			name:           "violated machine policy",
+			method:         http.MethodPost,
+			body:           newDryRunRequestBody(t, namespace.Name, true, providerconfig.OperatingSystemUbuntu),
+			policies:       []ctrlruntimeclient.Object{policy},
+			expectedStatus: http.StatusOK,
+			expectedPaths:  []string{"spec.providerSpec.value.operatingSystem"},
+		},
+		{
+			name:           "namespace is required",
+			method:         http.MethodPost,
+			body:           newDryRunRequestBody(t, "", true, providerconfig.OperatingSystemUbuntu),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing token",
+			method:         http.MethodPost,
+			body:           newDryRunRequestBody(t, namespace.Name, true, providerconfig.OperatingSystemUbuntu),
+			token:          "-",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "invalid token",
+			method:         http.MethodPost,
+			body:           newDryRunRequestBody(t, namespace.Name, true, providerconfig.OperatingSystemUbuntu),
+			token:          "invalid",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "user may not create machines in the namespace",
+			method:         http.MethodPost,
+			body:           newDryRunRequestBody(t, namespace.Name, true, providerconfig.OperatingSystemUbuntu),
+			token:          "bob",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "invalid body",
+			method:         http.MethodPost,
+			body:           []byte("{"),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodGet,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ad := newTestAdmissionData(t, append(test.policies, namespace)...)
+			ad.workerClient = interceptor.NewClient(ad.workerClient.(ctrlruntimeclient.WithWatch), dryRunInterceptors)
+
+			req := httptest.NewRequest(test.method, "/validate", bytes.NewReader(test.body))
+			switch test.token {
+			case "":
+				req.Header.Set("Authorization", "Bearer alice")
+			case "-":
+			default:
+				req.Header.Set("Authorization", "Bearer "+test.token)
+			}
+			rec := httptest.NewRecorder()
+			ad.handleDryRunValidation(rec, req)
+
+			if rec.Code != test.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", test.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+
+			response := DryRunResponse{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if response.Valid != (len(test.expectedPaths) == 0) {
+				t.Errorf("expected valid to be %t, got %t", len(test.expectedPaths) == 0, response.Valid)
+			}
+			if len(response.Errors) != len(test.expectedPaths) {
+				t.Fatalf("expected %d errors, got %v", len(test.expectedPaths), response.Errors)
+			}
+			for i, expectedPath := range test.expectedPaths {
+				if response.Errors[i].Field != expectedPath {
+					t.Errorf("expected error for %s, got %v", expectedPath, response.Errors[i])
+				}
+			}
+
+			if response.ProviderSpec == nil {
+				t.Fatal("expected the defaulted providerSpec in the response")
+			}
+			cfg, err := providerconfig.GetConfig(*response.ProviderSpec)
+			if err != nil {
+				t.Fatalf("failed to decode defaulted providerSpec: %v", err)
+			}
+			if cfg.OperatingSystemSpec.Raw == nil {
+				t.Error("expected the operatingSystemSpec to be defaulted")
+			}
+		})
+	}
+}
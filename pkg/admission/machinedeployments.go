@@ -25,6 +25,7 @@ import (
 
 	admissionv1 "k8s.io/api/admission/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -59,8 +60,9 @@ func (ad *admissionData) mutateMachineDeployments(ctx context.Context, ar admiss
 		return nil, fmt.Errorf("validation failed: %v", errs)
 	}
 
-	// Do not validate the spec if it hasn't changed
+	// Do not validate the spec and the selector if they haven't changed
 	machineSpecNeedsValidation := true
+	selectorNeedsValidation := true
 	if ar.Operation == admissionv1.Update {
 		var oldMachineDeployment clusterv1alpha1.MachineDeployment
 		if err := json.Unmarshal(ar.OldObject.Raw, &oldMachineDeployment); err != nil {
@@ -69,10 +71,17 @@ func (ad *admissionData) mutateMachineDeployments(ctx context.Context, ar admiss
 		if equal := apiequality.Semantic.DeepEqual(oldMachineDeployment.Spec.Template.Spec, machineDeployment.Spec.Template.Spec); equal {
 			machineSpecNeedsValidation = false
 		}
+		selectorNeedsValidation = selectorChanged(&machineDeployment.Spec.Selector, &oldMachineDeployment.Spec.Selector, machineDeployment.Spec.Template.Labels, oldMachineDeployment.Spec.Template.Labels)
+	}
+
+	if selectorNeedsValidation {
+		if err := ad.validateMachineDeploymentSelectorOverlap(ctx, &machineDeployment); err != nil {
+			return nil, err
+		}
 	}
 
 	if machineSpecNeedsValidation {
-		if err := ad.defaultAndValidateMachineSpec(ctx, &machineDeployment.Spec.Template.Spec); err != nil {
+		if err := ad.defaultAndValidateMachineSpec(ctx, machineDeployment.Namespace, &ar.UserInfo, &machineDeployment.Spec.Template.Spec, field.NewPath("spec", "template", "spec")); err != nil {
 			return nil, err
 		}
 	}
@@ -33,6 +33,8 @@ import (
 	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -76,19 +78,28 @@ func TestMachineDeploymentDefaulting(t *testing.T) {
 	}
 }
 
-func newTestAdmissionData(t *testing.T) *admissionData {
+func newTestAdmissionData(t *testing.T, existing ...ctrlruntimeclient.Object) *admissionData {
 	t.Helper()
 	c, err := semver.NewConstraint(">= 1.0.0")
 	if err != nil {
 		t.Fatalf("constraint: %v", err)
 	}
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("add client-go types to scheme: %v", err)
+	}
+	if err := clusterv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add cluster types to scheme: %v", err)
+	}
 	return &admissionData{
 		log:          zaptest.NewLogger(t).Sugar(),
-		client:       clientfake.NewClientBuilder().Build(),
-		workerClient: clientfake.NewClientBuilder().Build(),
+		client:       clientfake.NewClientBuilder().WithScheme(scheme).Build(),
+		workerClient: clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(existing...).Build(),
 		nodeSettings: machinecontroller.NodeSettings{},
 		namespace:    "kube-system",
 		constraints:  c,
+
+		machinePolicyPrograms: newMachinePolicyPrograms(),
 	}
 }
 
@@ -114,6 +125,7 @@ func newAdmissionRequest(t *testing.T, op admissionv1.Operation, newMD, oldMD *c
 }
 
 type mdBuilder struct {
+	name              string
 	deletionTimestamp *metav1.Time
 	passValidation    bool
 	kubelet           string
@@ -122,13 +134,14 @@ type mdBuilder struct {
 }
 
 func mdBuild() *mdBuilder {
-	return &mdBuilder{passValidation: true, kubelet: "1.30.0", image: "img-default"}
+	return &mdBuilder{name: "md", passValidation: true, kubelet: "1.30.0", image: "img-default"}
 }
 
 func (b *mdBuilder) withDeletionTimestamp(ts metav1.Time) *mdBuilder {
 	b.deletionTimestamp = &ts
 	return b
 }
+func (b *mdBuilder) withName(s string) *mdBuilder           { b.name = s; return b }
 func (b *mdBuilder) withPassValidation(v bool) *mdBuilder   { b.passValidation = v; return b }
 func (b *mdBuilder) withKubeletVersion(v string) *mdBuilder { b.kubelet = v; return b }
 func (b *mdBuilder) withImage(s string) *mdBuilder          { b.image = s; return b }
@@ -138,7 +151,7 @@ func (b *mdBuilder) build(t *testing.T) *clusterv1alpha1.MachineDeployment {
 	t.Helper()
 
 	md := &clusterv1alpha1.MachineDeployment{
-		ObjectMeta: metav1.ObjectMeta{Name: "md", Namespace: "kube-system"},
+		ObjectMeta: metav1.ObjectMeta{Name: b.name, Namespace: "kube-system"},
 		Spec: clusterv1alpha1.MachineDeploymentSpec{
 			Selector: metav1.LabelSelector{MatchLabels: map[string]string{"foo": "bar"}},
 			Template: clusterv1alpha1.MachineTemplateSpec{
@@ -187,6 +200,7 @@ func TestMutateMachineDeployments(t *testing.T) {
 		op                admissionv1.Operation
 		newMD             *clusterv1alpha1.MachineDeployment
 		oldMD             *clusterv1alpha1.MachineDeployment
+		existing          []ctrlruntimeclient.Object
 		wantAllowed       bool
 		wantErrorContains string
 	}{
@@ -208,6 +222,42 @@ func TestMutateMachineDeployments(t *testing.T) {
 			newMD:             mdBuild().withKubeletVersion("").build(t),
 			wantErrorContains: "kubelet version must be set",
 		},
+		{
+			name:              "create_with_invalid_kubelet_rejected",
+			op:                admissionv1.Create,
+			newMD:             mdBuild().withKubeletVersion("latest").build(t),
+			wantErrorContains: "spec.template.spec.versions.kubelet: Invalid value",
+		},
+		{
+			name:              "create_with_selector_overlapping_machinedeployment_rejected",
+			op:                admissionv1.Create,
+			newMD:             mdBuild().build(t),
+			existing:          []ctrlruntimeclient.Object{mdBuild().withName("other").build(t)},
+			wantErrorContains: "selector overlaps with the one of MachineDeployment other",
+		},
+		{
+			name:  "create_with_selector_matching_orphaned_machineset",
+			op:    admissionv1.Create,
+			newMD: mdBuild().build(t),
+			existing: []ctrlruntimeclient.Object{&clusterv1alpha1.MachineSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "orphan", Namespace: "kube-system"},
+				Spec: clusterv1alpha1.MachineSetSpec{
+					Selector: metav1.LabelSelector{MatchLabels: map[string]string{"foo": "bar"}},
+					Template: clusterv1alpha1.MachineTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"foo": "bar"}},
+					},
+				},
+			}},
+			wantAllowed: true,
+		},
+		{
+			name:        "update_with_unchanged_overlapping_selector",
+			op:          admissionv1.Update,
+			newMD:       mdBuild().build(t),
+			oldMD:       mdBuild().build(t),
+			existing:    []ctrlruntimeclient.Object{mdBuild().withName("other").build(t)},
+			wantAllowed: true,
+		},
 		{
 			name:        "update_label_only_with_resolvable_image",
 			op:          admissionv1.Update,
@@ -258,7 +308,7 @@ func TestMutateMachineDeployments(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			ad := newTestAdmissionData(t)
+			ad := newTestAdmissionData(t, tc.existing...)
 			req := newAdmissionRequest(t, tc.op, tc.newMD, tc.oldMD)
 			resp, err := ad.mutateMachineDeployments(ctx, req)
 
@@ -19,7 +19,13 @@ package admission
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/Masterminds/semver/v3"
+
+	"k8c.io/machine-controller/pkg/bootstrap"
+	controllerutil "k8c.io/machine-controller/pkg/controller/util"
+	"k8c.io/machine-controller/pkg/node/eviction"
 	"k8c.io/machine-controller/sdk/apis/cluster/common"
 	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
 	providerconfigtypes "k8c.io/machine-controller/sdk/providerconfig"
@@ -28,6 +34,7 @@ import (
 	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -57,6 +64,161 @@ func validateMachineDeploymentSpec(spec *clusterv1alpha1.MachineDeploymentSpec,
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("replicas"), *spec.Replicas, "replicas must be specified and can not be negative"))
 	}
 	allErrs = append(allErrs, validateMachineDeploymentStrategy(spec.Strategy, fldPath.Child("strategy"))...)
+	allErrs = append(allErrs, validateFailureDomains(spec.FailureDomains, spec.Template.Spec.ProviderSpec, fldPath.Child("failureDomains"))...)
+	if spec.MaintenanceWindows != nil {
+		allErrs = append(allErrs, validateMaintenanceWindows(spec.MaintenanceWindows, fldPath.Child("maintenanceWindows"))...)
+	}
+	if spec.DrainPolicy != nil {
+		allErrs = append(allErrs, validateDrainPolicy(spec.DrainPolicy, fldPath.Child("drainPolicy"))...)
+	}
+	if spec.Bootstrap != nil {
+		allErrs = append(allErrs, validateBootstrap(spec.Bootstrap, fldPath.Child("bootstrap"))...)
+	}
+	allErrs = append(allErrs, validateMachineTemplateSpec(&spec.Template, fldPath.Child("template"))...)
+	return allErrs
+}
+
+// validateMachineTemplateSpec validates the fields of a machine template which can be checked
+// without the cloud provider.
+func validateMachineTemplateSpec(template *clusterv1alpha1.MachineTemplateSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, metav1validation.ValidateLabels(template.Labels, fldPath.Child("metadata", "labels"))...)
+
+	specPath := fldPath.Child("spec")
+	allErrs = append(allErrs, metav1validation.ValidateLabels(template.Spec.Labels, specPath.Child("metadata", "labels"))...)
+	if template.Spec.Versions.Kubelet != "" {
+		if _, err := semver.NewVersion(template.Spec.Versions.Kubelet); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("versions", "kubelet"), template.Spec.Versions.Kubelet, err.Error()))
+		}
+	}
+	if template.Spec.ProviderID != nil {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("providerID"), "providerID is set by the machine-controller and can not be shared by the machines of a template"))
+	}
+	if template.Spec.ConfigSource != nil {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("configSource"), "dynamic kubelet configuration is not supported"))
+	}
+	return allErrs
+}
+
+func validateBootstrap(spec *clusterv1alpha1.Bootstrap, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch spec.Provider {
+	case clusterv1alpha1.BootstrapProviderOSM:
+	case clusterv1alpha1.BootstrapProviderTemplate:
+		if _, err := bootstrap.ParseTemplate(spec.Template); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("template"), spec.Template, err.Error()))
+		}
+	case clusterv1alpha1.BootstrapProviderHTTP:
+		if spec.HTTP == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("http"), "http is required for the http provider"))
+		} else if err := bootstrap.ValidateHTTP(*spec.HTTP); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("http"), spec.HTTP.URL, err.Error()))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("provider"), spec.Provider, []clusterv1alpha1.BootstrapProviderType{
+			clusterv1alpha1.BootstrapProviderOSM,
+			clusterv1alpha1.BootstrapProviderTemplate,
+			clusterv1alpha1.BootstrapProviderHTTP,
+		}))
+	}
+	if spec.Provider != clusterv1alpha1.BootstrapProviderTemplate && spec.Template != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("template"), "template is only allowed for the template provider"))
+	}
+	if spec.Provider != clusterv1alpha1.BootstrapProviderHTTP && spec.HTTP != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("http"), "http is only allowed for the http provider"))
+	}
+	return allErrs
+}
+
+func validateDrainPolicy(policy *clusterv1alpha1.DrainPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if policy.SkipPodSelector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(policy.SkipPodSelector, metav1validation.LabelSelectorValidationOptions{}, fldPath.Child("skipPodSelector"))...)
+	}
+	if policy.PodEvictionTimeout != nil && policy.PodEvictionTimeout.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("podEvictionTimeout"), policy.PodEvictionTimeout.String(), "timeout can not be negative"))
+	}
+	if policy.PreDrainTaintEffect != nil {
+		if err := eviction.ValidatePreDrainTaintEffect(*policy.PreDrainTaintEffect); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("preDrainTaintEffect"), *policy.PreDrainTaintEffect, err.Error()))
+		}
+	}
+	if policy.PreDrainDelay != nil && policy.PreDrainDelay.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("preDrainDelay"), policy.PreDrainDelay.String(), "delay can not be negative"))
+	}
+	return allErrs
+}
+
+func validateMaintenanceWindows(windows *clusterv1alpha1.MaintenanceWindows, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	var schedules []string
+	if _, err := controllerutil.MaintenanceWindowLocation(windows); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeZone"), windows.TimeZone, err.Error()))
+	}
+	if len(windows.Windows) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("windows"), "at least one maintenance window must be set"))
+	}
+	for i, window := range windows.Windows {
+		idxPath := fldPath.Child("windows").Index(i)
+		schedules = append(schedules, window.Schedule)
+		if _, err := controllerutil.ParseMaintenanceWindowSchedule(window.Schedule); err != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("schedule"), window.Schedule, err.Error()))
+		}
+		if window.Duration.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("duration"), window.Duration.String(), "duration must be positive"))
+		}
+	}
+	// Schedules like "0 0 30 2 *" are valid, but never open a maintenance window.
+	if len(allErrs) == 0 {
+		if _, _, err := controllerutil.MaintenanceWindowOpen(windows, time.Now()); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("windows"), schedules, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+func validateFailureDomains(failureDomains []clusterv1alpha1.FailureDomain, providerSpec clusterv1alpha1.ProviderSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(failureDomains) == 0 {
+		return allErrs
+	}
+	// Errors of the providerSpec itself are reported by ApplyFailureDomain.
+	var provider providerconfigtypes.CloudProvider
+	if providerConfig, err := providerconfigtypes.GetConfig(providerSpec); err == nil {
+		provider = providerConfig.CloudProvider
+	}
+	names := sets.New[string]()
+	for i, failureDomain := range failureDomains {
+		idxPath := fldPath.Index(i)
+		if failureDomain.Name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "failure domain name must be set"))
+			continue
+		}
+		for _, msg := range utilvalidation.IsValidLabelValue(failureDomain.Name) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), failureDomain.Name, msg))
+		}
+		if names.Has(failureDomain.Name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), failureDomain.Name))
+		}
+		names.Insert(failureDomain.Name)
+
+		if provider != "" && !controllerutil.FailureDomainSupported(provider) {
+			msg := fmt.Sprintf("provider %q has no zones and subnets, use cloudProviderSpec to spread Machines across failure domains", provider)
+			if failureDomain.Zone != "" {
+				allErrs = append(allErrs, field.Forbidden(idxPath.Child("zone"), msg))
+			}
+			if failureDomain.Subnet != "" {
+				allErrs = append(allErrs, field.Forbidden(idxPath.Child("subnet"), msg))
+			}
+			if failureDomain.Zone != "" || failureDomain.Subnet != "" {
+				continue
+			}
+		}
+
+		if _, err := controllerutil.ApplyFailureDomain(providerSpec, failureDomain); err != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath, failureDomain.Name, err.Error()))
+		}
+	}
 	return allErrs
 }
 
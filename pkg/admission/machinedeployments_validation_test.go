@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateMaintenanceWindows(t *testing.T) {
+	tests := []struct {
+		name        string
+		windows     []clusterv1alpha1.MaintenanceWindow
+		timeZone    string
+		expectedErr bool
+	}{
+		{
+			name:    "nightly window",
+			windows: []clusterv1alpha1.MaintenanceWindow{{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: time.Hour}}},
+		},
+		{
+			name:        "invalid time zone",
+			windows:     []clusterv1alpha1.MaintenanceWindow{{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: time.Hour}}},
+			timeZone:    "Nowhere/Town",
+			expectedErr: true,
+		},
+		{
+			name:        "invalid schedule",
+			windows:     []clusterv1alpha1.MaintenanceWindow{{Schedule: "every night", Duration: metav1.Duration{Duration: time.Hour}}},
+			expectedErr: true,
+		},
+		{
+			name:        "window never opens",
+			windows:     []clusterv1alpha1.MaintenanceWindow{{Schedule: "0 0 30 2 *", Duration: metav1.Duration{Duration: time.Hour}}},
+			expectedErr: true,
+		},
+		{
+			name:        "no windows",
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			windows := &clusterv1alpha1.MaintenanceWindows{TimeZone: test.timeZone, Windows: test.windows}
+			errs := validateMaintenanceWindows(windows, field.NewPath("spec", "maintenanceWindows"))
+			if (len(errs) > 0) != test.expectedErr {
+				t.Errorf("expected error: %t, got %v", test.expectedErr, errs)
+			}
+		})
+	}
+}
+
+func TestValidateFailureDomains(t *testing.T) {
+	tests := []struct {
+		name           string
+		cloudProvider  string
+		failureDomains []clusterv1alpha1.FailureDomain
+		expectedFields []string
+	}{
+		{
+			name:          "zones on aws",
+			cloudProvider: "aws",
+			failureDomains: []clusterv1alpha1.FailureDomain{
+				{Name: "a", Zone: "eu-central-1a", Subnet: "subnet-a"},
+				{Name: "b", Zone: "eu-central-1b", Subnet: "subnet-b"},
+			},
+		},
+		{
+			name:          "zone on hetzner",
+			cloudProvider: "hetzner",
+			failureDomains: []clusterv1alpha1.FailureDomain{
+				{Name: "fsn1", Zone: "fsn1"},
+				{Name: "nbg1", Subnet: "net"},
+			},
+			expectedFields: []string{"spec.failureDomains[0].zone", "spec.failureDomains[1].subnet"},
+		},
+		{
+			name:          "cloudProviderSpec patch on hetzner",
+			cloudProvider: "hetzner",
+			failureDomains: []clusterv1alpha1.FailureDomain{
+				{Name: "fsn1", CloudProviderSpec: &runtime.RawExtension{Raw: []byte(`{"datacenter":"fsn1-dc14"}`)}},
+			},
+		},
+		{
+			name:          "duplicate name",
+			cloudProvider: "aws",
+			failureDomains: []clusterv1alpha1.FailureDomain{
+				{Name: "a", Zone: "eu-central-1a"},
+				{Name: "a", Zone: "eu-central-1b"},
+			},
+			expectedFields: []string{"spec.failureDomains[1].name"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			providerSpec := clusterv1alpha1.ProviderSpec{Value: &runtime.RawExtension{
+				Raw: []byte(`{"cloudProvider":"` + test.cloudProvider + `","cloudProviderSpec":{},"operatingSystem":"ubuntu"}`),
+			}}
+			errs := validateFailureDomains(test.failureDomains, providerSpec, field.NewPath("spec", "failureDomains"))
+			var fields []string
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			if !reflect.DeepEqual(fields, test.expectedFields) {
+				t.Errorf("expected errors for %v, got %v", test.expectedFields, errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// machinePolicyCostLimit limits the cost of evaluating a single rule, so expensive rules can not
+// exhaust the webhook.
+const machinePolicyCostLimit = 1000000
+
+// machinePolicyEnv returns the CEL environment the rules of MachinePolicies are compiled in.
+var machinePolicyEnv = sync.OnceValues(func() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("providerConfig", cel.DynType),
+		cel.Variable("cloudProviderSpec", cel.DynType),
+		cel.Variable("machineNamespace", cel.StringType),
+	)
+})
+
+// validateMachinePolicies evaluates the rules of all MachinePolicies selecting the namespace against
+// the providerSpec of the machine spec. fldPath is the path of the providerSpec value.
+func (ad *admissionData) validateMachinePolicies(ctx context.Context, namespace string, spec *clusterv1alpha1.MachineSpec, fldPath *field.Path) (field.ErrorList, error) {
+	policies := &clusterv1alpha1.MachinePolicyList{}
+	if err := ad.workerClient.List(ctx, policies); err != nil {
+		return nil, fmt.Errorf("failed to list MachinePolicies: %w", err)
+	}
+	ad.machinePolicyPrograms.prune(policies.Items)
+	if len(policies.Items) == 0 {
+		return nil, nil
+	}
+
+	variables, err := machinePolicyVariables(namespace, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var namespaceLabels labels.Set
+	var allErrs field.ErrorList
+	for _, policy := range policies.Items {
+		if policy.Spec.NamespaceSelector != nil {
+			if namespaceLabels == nil {
+				ns := &corev1.Namespace{}
+				if err := ad.workerClient.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+					return nil, fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+				}
+				namespaceLabels = labels.Set(ns.Labels)
+			}
+
+			selector, err := metav1.LabelSelectorAsSelector(policy.Spec.NamespaceSelector)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse namespace selector of MachinePolicy %s: %w", policy.Name, err)
+			}
+			if !selector.Matches(namespaceLabels) {
+				continue
+			}
+		}
+
+		compiled := ad.machinePolicyPrograms.get(&policy)
+		for i, rule := range policy.Spec.Rules {
+			allErrs = append(allErrs, evaluateMachinePolicyRule(policy.Name, rule, compiled.programs[i], compiled.errs[i], variables, fldPath)...)
+		}
+	}
+
+	return allErrs, nil
+}
+
+// machinePolicyVariables decodes the providerSpec into the variables available to the rules.
+func machinePolicyVariables(namespace string, spec *clusterv1alpha1.MachineSpec) (map[string]interface{}, error) {
+	providerConfig := map[string]interface{}{}
+	if spec.ProviderSpec.Value != nil {
+		if err := json.Unmarshal(spec.ProviderSpec.Value.Raw, &providerConfig); err != nil {
+			return nil, fmt.Errorf("failed to decode providerSpec: %w", err)
+		}
+	}
+
+	cloudProviderSpec, ok := providerConfig["cloudProviderSpec"].(map[string]interface{})
+	if !ok {
+		cloudProviderSpec = map[string]interface{}{}
+	}
+
+	return map[string]interface{}{
+		"providerConfig":    providerConfig,
+		"cloudProviderSpec": cloudProviderSpec,
+		"machineNamespace":  namespace,
+	}, nil
+}
+
+// evaluateMachinePolicyRule evaluates a compiled rule. compileErr is the error compiling the rule failed with.
+func evaluateMachinePolicyRule(policyName string, rule clusterv1alpha1.MachinePolicyRule, program cel.Program, compileErr error, variables map[string]interface{}, fldPath *field.Path) field.ErrorList {
+	path := fldPath
+	if rule.FieldPath != "" {
+		for _, name := range strings.Split(rule.FieldPath, ".") {
+			path = path.Child(name)
+		}
+	}
+
+	if compileErr != nil {
+		return field.ErrorList{field.InternalError(path, fmt.Errorf("rule %q of MachinePolicy %s is invalid: %w", rule.Name, policyName, compileErr))}
+	}
+
+	result, _, err := program.Eval(variables)
+	if err != nil {
+		return field.ErrorList{field.Forbidden(path, fmt.Sprintf("failed to evaluate rule %q of MachinePolicy %s: %v", rule.Name, policyName, err))}
+	}
+
+	allowed, ok := result.Value().(bool)
+	if !ok {
+		return field.ErrorList{field.InternalError(path, fmt.Errorf("rule %q of MachinePolicy %s does not evaluate to a bool", rule.Name, policyName))}
+	}
+	if allowed {
+		return nil
+	}
+
+	message := fmt.Sprintf("violates rule %q of MachinePolicy %s", rule.Name, policyName)
+	if rule.Message != "" {
+		message = fmt.Sprintf("%s: %s", message, rule.Message)
+	}
+	return field.ErrorList{field.Forbidden(path, message)}
+}
+
+// compileMachinePolicyRule compiles and type-checks the expression of a rule.
+func compileMachinePolicyRule(rule clusterv1alpha1.MachinePolicyRule) (cel.Program, error) {
+	env, err := machinePolicyEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+
+	ast, issues := env.Compile(rule.Expression)
+	if issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	// Expressions on the dynamic variables are only known to return a bool once evaluated.
+	if output := ast.OutputType(); !output.IsExactType(cel.BoolType) && !output.IsExactType(cel.DynType) {
+		return nil, fmt.Errorf("expression must evaluate to a bool, not %s", output)
+	}
+
+	return env.Program(ast, cel.CostLimit(machinePolicyCostLimit))
+}
+
+// machinePolicyPrograms caches the compiled rules of MachinePolicies, so they are only compiled
+// again once a policy changes.
+type machinePolicyPrograms struct {
+	lock     sync.Mutex
+	policies map[string]*compiledMachinePolicy
+}
+
+type compiledMachinePolicy struct {
+	uid        types.UID
+	generation int64
+	programs   []cel.Program
+	errs       []error
+}
+
+func newMachinePolicyPrograms() *machinePolicyPrograms {
+	return &machinePolicyPrograms{policies: map[string]*compiledMachinePolicy{}}
+}
+
+// get returns the compiled rules of the policy, compiling them if the cache holds none for its
+// generation.
+func (p *machinePolicyPrograms) get(policy *clusterv1alpha1.MachinePolicy) *compiledMachinePolicy {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if compiled, ok := p.policies[policy.Name]; ok && compiled.uid == policy.UID && compiled.generation == policy.Generation {
+		return compiled
+	}
+
+	compiled := &compiledMachinePolicy{
+		uid:        policy.UID,
+		generation: policy.Generation,
+		programs:   make([]cel.Program, len(policy.Spec.Rules)),
+		errs:       make([]error, len(policy.Spec.Rules)),
+	}
+	for i, rule := range policy.Spec.Rules {
+		compiled.programs[i], compiled.errs[i] = compileMachinePolicyRule(rule)
+	}
+	p.policies[policy.Name] = compiled
+
+	return compiled
+}
+
+// prune drops the compiled rules of all policies which are not listed anymore.
+func (p *machinePolicyPrograms) prune(policies []clusterv1alpha1.MachinePolicy) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	names := sets.New[string]()
+	for _, policy := range policies {
+		names.Insert(policy.Name)
+	}
+	for name := range p.policies {
+		if !names.Has(name) {
+			delete(p.policies, name)
+		}
+	}
+}
+
+// validateMachinePolicy rejects MachinePolicies whose rules do not compile, so broken rules can not
+// fail the admission of every machine.
+func (ad *admissionData) validateMachinePolicy(_ context.Context, ar admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error) {
+	policy := clusterv1alpha1.MachinePolicy{}
+	if err := json.Unmarshal(ar.Object.Raw, &policy); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal: %w", err)
+	}
+
+	if errs := validateMachinePolicySpec(&policy.Spec, field.NewPath("spec")); len(errs) > 0 {
+		return nil, fmt.Errorf("validation failed: %w", errs.ToAggregate())
+	}
+
+	return &admissionv1.AdmissionResponse{Allowed: true}, nil
+}
+
+func validateMachinePolicySpec(spec *clusterv1alpha1.MachinePolicySpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if spec.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(spec.NamespaceSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("namespaceSelector"), spec.NamespaceSelector, err.Error()))
+		}
+	}
+
+	names := sets.New[string]()
+	for i, rule := range spec.Rules {
+		rulePath := fldPath.Child("rules").Index(i)
+		switch {
+		case rule.Name == "":
+			allErrs = append(allErrs, field.Required(rulePath.Child("name"), "rule name must be set"))
+		case names.Has(rule.Name):
+			allErrs = append(allErrs, field.Duplicate(rulePath.Child("name"), rule.Name))
+		}
+		names.Insert(rule.Name)
+
+		if rule.Expression == "" {
+			allErrs = append(allErrs, field.Required(rulePath.Child("expression"), "rule expression must be set"))
+		} else if _, err := compileMachinePolicyRule(rule); err != nil {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("expression"), rule.Expression, err.Error()))
+		}
+	}
+
+	return allErrs
+}
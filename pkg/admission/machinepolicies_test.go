@@ -0,0 +1,250 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+	"k8c.io/machine-controller/sdk/providerconfig"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newMachinePolicy(namespaceSelector *metav1.LabelSelector, rules ...clusterv1alpha1.MachinePolicyRule) *clusterv1alpha1.MachinePolicy {
+	return &clusterv1alpha1.MachinePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy", UID: "policy-uid", Generation: 1},
+		Spec: clusterv1alpha1.MachinePolicySpec{
+			NamespaceSelector: namespaceSelector,
+			Rules:             rules,
+		},
+	}
+}
+
+func TestValidateMachinePolicies(t *testing.T) {
+	ctx := context.Background()
+
+	cfg := providerconfig.Config{
+		CloudProvider:     providerconfig.CloudProviderAWS,
+		CloudProviderSpec: runtime.RawExtension{Raw: []byte(`{"instanceType":"m5.large","diskSize":50,"assignPublicIP":true}`)},
+		OperatingSystem:   providerconfig.OperatingSystemUbuntu,
+	}
+	rawCfg, err := json.Marshal(&cfg)
+	if err != nil {
+		t.Fatalf("marshal providerconfig: %v", err)
+	}
+	spec := &clusterv1alpha1.MachineSpec{
+		ProviderSpec: clusterv1alpha1.ProviderSpec{Value: &runtime.RawExtension{Raw: rawCfg}},
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "team-a",
+		Labels: map[string]string{"team": "a"},
+	}}
+
+	tests := []struct {
+		name          string
+		policies      []ctrlruntimeclient.Object
+		expectedPaths []string
+		expectedType  field.ErrorType
+	}{
+		{
+			name: "no policies",
+		},
+		{
+			name: "satisfied rules",
+			policies: []ctrlruntimeclient.Object{newMachinePolicy(nil,
+				clusterv1alpha1.MachinePolicyRule{Name: "instance-types", Expression: `cloudProviderSpec.instanceType in ["m5.large", "m5.xlarge"]`},
+				clusterv1alpha1.MachinePolicyRule{Name: "disk-size", Expression: `cloudProviderSpec.diskSize <= 100`},
+				clusterv1alpha1.MachinePolicyRule{Name: "namespace", Expression: `machineNamespace == "team-a" && providerConfig.operatingSystem == "ubuntu"`},
+			)},
+		},
+		{
+			name: "violated rule",
+			policies: []ctrlruntimeclient.Object{newMachinePolicy(nil, clusterv1alpha1.MachinePolicyRule{
+				Name:       "no-public-ip",
+				Expression: `!has(cloudProviderSpec.assignPublicIP) || !cloudProviderSpec.assignPublicIP`,
+				Message:    "public IPs are forbidden",
+				FieldPath:  "cloudProviderSpec.assignPublicIP",
+			})},
+			expectedPaths: []string{"spec.providerSpec.value.cloudProviderSpec.assignPublicIP"},
+			expectedType:  field.ErrorTypeForbidden,
+		},
+		{
+			name: "violated rule in matching namespace",
+			policies: []ctrlruntimeclient.Object{newMachinePolicy(
+				&metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+				clusterv1alpha1.MachinePolicyRule{Name: "tags", Expression: `has(cloudProviderSpec.tags)`},
+			)},
+			expectedPaths: []string{"spec.providerSpec.value"},
+			expectedType:  field.ErrorTypeForbidden,
+		},
+		{
+			name: "violated rule in other namespace",
+			policies: []ctrlruntimeclient.Object{newMachinePolicy(
+				&metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}},
+				clusterv1alpha1.MachinePolicyRule{Name: "tags", Expression: `has(cloudProviderSpec.tags)`},
+			)},
+		},
+		{
+			name: "invalid rule",
+			policies: []ctrlruntimeclient.Object{newMachinePolicy(nil,
+				clusterv1alpha1.MachinePolicyRule{Name: "invalid", Expression: `cloudProviderSpec.instanceType ==`},
+			)},
+			expectedPaths: []string{"spec.providerSpec.value"},
+			expectedType:  field.ErrorTypeInternal,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ad := newTestAdmissionData(t, append(test.policies, namespace)...)
+
+			errs, err := ad.validateMachinePolicies(ctx, namespace.Name, spec, field.NewPath("spec", "providerSpec", "value"))
+			if err != nil {
+				t.Fatalf("failed to validate machine policies: %v", err)
+			}
+
+			if len(errs) != len(test.expectedPaths) {
+				t.Fatalf("expected %d errors, got %v", len(test.expectedPaths), errs)
+			}
+			for i, expectedPath := range test.expectedPaths {
+				if errs[i].Field != expectedPath || errs[i].Type != test.expectedType {
+					t.Errorf("expected %s error for %s, got %v", test.expectedType, expectedPath, errs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateMachinePolicyObject(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    *clusterv1alpha1.MachinePolicy
+		expectErr bool
+	}{
+		{
+			name: "valid policy",
+			policy: newMachinePolicy(&metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+				clusterv1alpha1.MachinePolicyRule{Name: "instance-types", Expression: `cloudProviderSpec.instanceType in ["m5.large"]`},
+				clusterv1alpha1.MachinePolicyRule{Name: "namespace", Expression: `machineNamespace == "team-a"`},
+			),
+		},
+		{
+			name:      "invalid expression",
+			policy:    newMachinePolicy(nil, clusterv1alpha1.MachinePolicyRule{Name: "invalid", Expression: `cloudProviderSpec.instanceType ==`}),
+			expectErr: true,
+		},
+		{
+			name:      "expression not evaluating to a bool",
+			policy:    newMachinePolicy(nil, clusterv1alpha1.MachinePolicyRule{Name: "namespace", Expression: `machineNamespace + "-suffix"`}),
+			expectErr: true,
+		},
+		{
+			name:      "missing expression",
+			policy:    newMachinePolicy(nil, clusterv1alpha1.MachinePolicyRule{Name: "empty"}),
+			expectErr: true,
+		},
+		{
+			name: "duplicate rule names",
+			policy: newMachinePolicy(nil,
+				clusterv1alpha1.MachinePolicyRule{Name: "rule", Expression: `true`},
+				clusterv1alpha1.MachinePolicyRule{Name: "rule", Expression: `false`},
+			),
+			expectErr: true,
+		},
+		{
+			name: "invalid namespace selector",
+			policy: newMachinePolicy(&metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Unknown"}}},
+				clusterv1alpha1.MachinePolicyRule{Name: "rule", Expression: `true`},
+			),
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ad := newTestAdmissionData(t)
+
+			raw, err := json.Marshal(test.policy)
+			if err != nil {
+				t.Fatalf("failed to marshal MachinePolicy: %v", err)
+			}
+
+			response, err := ad.validateMachinePolicy(context.Background(), admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: raw},
+			})
+			if (err != nil) != test.expectErr {
+				t.Fatalf("expected error: %t, got %v", test.expectErr, err)
+			}
+			if err == nil && !response.Allowed {
+				t.Error("expected the MachinePolicy to be allowed")
+			}
+		})
+	}
+}
+
+func TestMachinePolicyCostLimit(t *testing.T) {
+	numbers := "[" + strings.TrimSuffix(strings.Repeat("1,", 200), ",") + "]"
+	rule := clusterv1alpha1.MachinePolicyRule{
+		Name:       "expensive",
+		Expression: numbers + ".all(a, " + numbers + ".all(b, " + numbers + ".all(c, a + b + c > 0)))",
+	}
+
+	program, err := compileMachinePolicyRule(rule)
+	if err != nil {
+		t.Fatalf("failed to compile rule: %v", err)
+	}
+
+	errs := evaluateMachinePolicyRule("policy", rule, program, nil, map[string]interface{}{
+		"providerConfig":    map[string]interface{}{},
+		"cloudProviderSpec": map[string]interface{}{},
+		"machineNamespace":  "team-a",
+	}, field.NewPath("spec"))
+	if len(errs) != 1 || !strings.Contains(errs[0].Detail, "cost limit") {
+		t.Errorf("expected the rule to exceed the cost limit, got %v", errs)
+	}
+}
+
+func TestMachinePolicyPrograms(t *testing.T) {
+	programs := newMachinePolicyPrograms()
+	policy := newMachinePolicy(nil, clusterv1alpha1.MachinePolicyRule{Name: "rule", Expression: `true`})
+
+	compiled := programs.get(policy)
+	if programs.get(policy.DeepCopy()) != compiled {
+		t.Error("expected the compiled rules to be reused for the same generation")
+	}
+
+	policy.Generation++
+	policy.Spec.Rules[0].Expression = `false`
+	if programs.get(policy) == compiled {
+		t.Error("expected the rules to be compiled again for a new generation")
+	}
+
+	programs.prune(nil)
+	if len(programs.policies) != 0 {
+		t.Errorf("expected the compiled rules of deleted policies to be dropped, got %v", programs.policies)
+	}
+}
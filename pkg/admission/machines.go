@@ -33,7 +33,9 @@ import (
 	"k8c.io/machine-controller/sdk/userdata"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -95,7 +97,7 @@ func (ad *admissionData) mutateMachines(ctx context.Context, ar admissionv1.Admi
 	// Default and verify .Spec on CREATE only, its expensive and not required to do it on UPDATE
 	// as we disallow .Spec changes anyways.
 	if ar.Operation == admissionv1.Create {
-		if err := ad.defaultAndValidateMachineSpec(ctx, &machine.Spec); err != nil {
+		if err := ad.defaultAndValidateMachineSpec(ctx, machine.Namespace, &ar.UserInfo, &machine.Spec, field.NewPath("spec")); err != nil {
 			return nil, err
 		}
 
@@ -108,6 +110,10 @@ func (ad *admissionData) mutateMachines(ctx context.Context, ar admissionv1.Admi
 			return nil, err
 		}
 		common.SetOSLabel(&machine.Spec, string(providerConfig.OperatingSystem))
+
+		if err := ad.validateMachineUserdata(ctx, &machine, providerConfig); err != nil {
+			return nil, err
+		}
 	}
 
 	if machine.Labels == nil {
@@ -117,7 +123,7 @@ func (ad *admissionData) mutateMachines(ctx context.Context, ar admissionv1.Admi
 	return createAdmissionResponse(log, machineOriginal, &machine)
 }
 
-func (ad *admissionData) defaultAndValidateMachineSpec(ctx context.Context, spec *clusterv1alpha1.MachineSpec) error {
+func (ad *admissionData) defaultAndValidateMachineSpec(ctx context.Context, namespace string, userInfo *authenticationv1.UserInfo, spec *clusterv1alpha1.MachineSpec, fldPath *field.Path) error {
 	providerConfig, err := providerconfig.GetConfig(spec.ProviderSpec)
 	if err != nil {
 		return fmt.Errorf("failed to read machine.spec.providerSpec: %w", err)
@@ -138,7 +144,18 @@ func (ad *admissionData) defaultAndValidateMachineSpec(ctx context.Context, spec
 		}
 	}
 
-	configResolver := configvar.NewResolver(ctx, ad.workerClient)
+	credentialErrs, err := ad.validateCredentialReferences(ctx, namespace, userInfo, providerConfig, fldPath.Child("providerSpec", "value"))
+	if err != nil {
+		return err
+	}
+	if len(credentialErrs) > 0 {
+		return fmt.Errorf("invalid credential references: %w", credentialErrs.ToAggregate())
+	}
+
+	configResolver := configvar.NewResolver(ctx, ad.workerClient, append(
+		configvar.ForConfig(namespace, providerConfig, ad.allowCrossNamespaceRefs),
+		configvar.WithAccessor(fmt.Sprintf("admission in namespace %s by %s", namespace, userInfo.Username)),
+	)...)
 	prov, err := cloudprovider.ForProvider(providerConfig.CloudProvider, configResolver)
 	if err != nil {
 		return fmt.Errorf("failed to get cloud provider %q: %w", providerConfig.CloudProvider, err)
@@ -197,6 +214,14 @@ func (ad *admissionData) defaultAndValidateMachineSpec(ctx context.Context, spec
 		return fmt.Errorf("validation failed: %w", err)
 	}
 
+	policyErrs, err := ad.validateMachinePolicies(ctx, namespace, spec, fldPath.Child("providerSpec", "value"))
+	if err != nil {
+		return err
+	}
+	if len(policyErrs) > 0 {
+		return fmt.Errorf("machine policy validation failed: %w", policyErrs.ToAggregate())
+	}
+
 	return nil
 }
 
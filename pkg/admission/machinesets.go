@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func (ad *admissionData) mutateMachineSets(ctx context.Context, ar admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error) {
+	machineSet := clusterv1alpha1.MachineSet{}
+	if err := json.Unmarshal(ar.Object.Raw, &machineSet); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal: %w", err)
+	}
+
+	log := ad.log.With("machineset", ctrlruntimeclient.ObjectKeyFromObject(&machineSet))
+
+	if machineSet.DeletionTimestamp != nil {
+		log.Debug("Skipping admission for machine set under deletion")
+		return &admissionv1.AdmissionResponse{Allowed: true}, nil
+	}
+
+	if errs := validateMachineSet(machineSet); len(errs) > 0 {
+		return nil, fmt.Errorf("validation failed: %v", errs)
+	}
+
+	// Do not validate the spec and the selector if they haven't changed
+	machineSpecNeedsValidation := true
+	selectorNeedsValidation := true
+	if ar.Operation == admissionv1.Update {
+		var oldMachineSet clusterv1alpha1.MachineSet
+		if err := json.Unmarshal(ar.OldObject.Raw, &oldMachineSet); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal OldObject: %w", err)
+		}
+		if equal := apiequality.Semantic.DeepEqual(oldMachineSet.Spec.Template.Spec, machineSet.Spec.Template.Spec); equal {
+			machineSpecNeedsValidation = false
+		}
+		selectorNeedsValidation = selectorChanged(&machineSet.Spec.Selector, &oldMachineSet.Spec.Selector, machineSet.Spec.Template.Labels, oldMachineSet.Spec.Template.Labels)
+	}
+
+	if selectorNeedsValidation {
+		log.Debug("Validating machine set selector")
+		if err := ad.validateMachineSetSelectorOverlap(ctx, &machineSet); err != nil {
+			return nil, err
+		}
+	}
+
+	if machineSpecNeedsValidation {
+		log.Debug("Validating machine set template")
+		// The template is validated on a copy, MachineSets must not be mutated as those of
+		// MachineDeployments carry the already defaulted template of their MachineDeployment.
+		spec := machineSet.Spec.Template.Spec.DeepCopy()
+		if err := ad.defaultAndValidateMachineSpec(ctx, machineSet.Namespace, &ar.UserInfo, spec, field.NewPath("spec", "template", "spec")); err != nil {
+			return nil, err
+		}
+	}
+
+	return &admissionv1.AdmissionResponse{Allowed: true}, nil
+}
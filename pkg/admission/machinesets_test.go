@@ -0,0 +1,150 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newTestMachineSet(t *testing.T, name string, hash string, controller *clusterv1alpha1.MachineDeployment) *clusterv1alpha1.MachineSet {
+	t.Helper()
+
+	ms := &clusterv1alpha1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "kube-system"},
+		Spec: clusterv1alpha1.MachineSetSpec{
+			Selector: metav1.LabelSelector{MatchLabels: map[string]string{"foo": "bar", "hash": hash}},
+			Template: clusterv1alpha1.MachineTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"foo": "bar", "hash": hash}},
+				Spec:       mdBuild().build(t).Spec.Template.Spec,
+			},
+		},
+	}
+	if controller != nil {
+		ms.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(controller, clusterv1alpha1.SchemeGroupVersion.WithKind("MachineDeployment"))}
+	}
+	return ms
+}
+
+func TestMutateMachineSets(t *testing.T) {
+	ctx := context.Background()
+
+	md := mdBuild().build(t)
+	md.UID = "md-uid"
+	otherMD := mdBuild().withName("other").build(t)
+	otherMD.UID = "other-uid"
+
+	tests := []struct {
+		name              string
+		ms                *clusterv1alpha1.MachineSet
+		existing          []ctrlruntimeclient.Object
+		wantErrorContains string
+	}{
+		{
+			name:     "machineset_controlled_by_machinedeployment",
+			ms:       newTestMachineSet(t, "md-2", "2", md),
+			existing: []ctrlruntimeclient.Object{md, newTestMachineSet(t, "md-1", "1", md)},
+		},
+		{
+			name:              "machineset_overlapping_other_machinedeployment_rejected",
+			ms:                newTestMachineSet(t, "md-1", "1", md),
+			existing:          []ctrlruntimeclient.Object{md, otherMD},
+			wantErrorContains: "selector overlaps with the one of MachineDeployment other",
+		},
+		{
+			name:              "orphaned_machineset_overlapping_machineset_rejected",
+			ms:                newTestMachineSet(t, "standalone", "1", nil),
+			existing:          []ctrlruntimeclient.Object{newTestMachineSet(t, "md-1", "1", md)},
+			wantErrorContains: "selector overlaps with the one of MachineSet md-1",
+		},
+		{
+			name: "machineset_with_unresolvable_image_rejected",
+			ms: func() *clusterv1alpha1.MachineSet {
+				ms := newTestMachineSet(t, "standalone", "1", nil)
+				ms.Spec.Template.Spec = mdBuild().withPassValidation(false).build(t).Spec.Template.Spec
+				return ms
+			}(),
+			wantErrorContains: "failing validation as requested",
+		},
+		{
+			name: "machineset_with_least_utilized_delete_policy",
+			ms: func() *clusterv1alpha1.MachineSet {
+				ms := newTestMachineSet(t, "standalone", "1", nil)
+				ms.Spec.DeletePolicy = string(clusterv1alpha1.LeastUtilizedMachineSetDeletePolicy)
+				return ms
+			}(),
+		},
+		{
+			name: "machineset_with_invalid_delete_policy_rejected",
+			ms: func() *clusterv1alpha1.MachineSet {
+				ms := newTestMachineSet(t, "standalone", "1", nil)
+				ms.Spec.DeletePolicy = "Fastest"
+				return ms
+			}(),
+			wantErrorContains: "spec.deletePolicy: Unsupported value",
+		},
+		{
+			name: "machineset_with_provider_id_rejected",
+			ms: func() *clusterv1alpha1.MachineSet {
+				ms := newTestMachineSet(t, "standalone", "1", nil)
+				ms.Spec.Template.Spec.ProviderID = ptr.To("aws:///i-123")
+				return ms
+			}(),
+			wantErrorContains: "spec.template.spec.providerID: Forbidden",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ad := newTestAdmissionData(t, tc.existing...)
+
+			raw, err := json.Marshal(tc.ms)
+			if err != nil {
+				t.Fatalf("marshal machineset: %v", err)
+			}
+			resp, err := ad.mutateMachineSets(ctx, admissionv1.AdmissionRequest{
+				UID:       "test-uid",
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: raw},
+			})
+
+			if tc.wantErrorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErrorContains) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantErrorContains, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !resp.Allowed {
+				t.Fatal("expected machineset to be allowed")
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func validateMachineSet(ms clusterv1alpha1.MachineSet) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validateMachineSetSpec(&ms.Spec, field.NewPath("spec"))...)
+	return allErrs
+}
+
+func validateMachineSetSpec(spec *clusterv1alpha1.MachineSetSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, metav1validation.ValidateLabelSelector(&spec.Selector, metav1validation.LabelSelectorValidationOptions{}, fldPath.Child("selector"))...)
+	if len(spec.Selector.MatchLabels)+len(spec.Selector.MatchExpressions) == 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("selector"), spec.Selector, "empty selector is not valid for MachineSet."))
+	}
+	selector, err := metav1.LabelSelectorAsSelector(&spec.Selector)
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("selector"), spec.Selector, "invalid label selector."))
+	} else if !selector.Matches(labels.Set(spec.Template.Labels)) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("template", "metadata", "labels"), spec.Template.Labels, "`selector` does not match template `labels`"))
+	}
+	if spec.Replicas != nil && *spec.Replicas < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("replicas"), *spec.Replicas, "replicas can not be negative"))
+	}
+	if spec.MinReadySeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("minReadySeconds"), spec.MinReadySeconds, "minReadySeconds can not be negative"))
+	}
+	switch clusterv1alpha1.MachineSetDeletePolicy(spec.DeletePolicy) {
+	case "", clusterv1alpha1.RandomMachineSetDeletePolicy, clusterv1alpha1.NewestMachineSetDeletePolicy, clusterv1alpha1.OldestMachineSetDeletePolicy, clusterv1alpha1.LeastUtilizedMachineSetDeletePolicy:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("deletePolicy"), spec.DeletePolicy, []clusterv1alpha1.MachineSetDeletePolicy{
+			clusterv1alpha1.RandomMachineSetDeletePolicy,
+			clusterv1alpha1.NewestMachineSetDeletePolicy,
+			clusterv1alpha1.OldestMachineSetDeletePolicy,
+			clusterv1alpha1.LeastUtilizedMachineSetDeletePolicy,
+		}))
+	}
+	allErrs = append(allErrs, validateFailureDomains(spec.FailureDomains, spec.Template.Spec.ProviderSpec, fldPath.Child("failureDomains"))...)
+	allErrs = append(allErrs, validateMachineTemplateSpec(&spec.Template, fldPath.Child("template"))...)
+	return allErrs
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+	"k8c.io/machine-controller/sdk/providerconfig"
+	"k8c.io/machine-controller/sdk/providerconfig/configvar"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// useProviderCredentialsVerb is the verb users need on ProviderCredentials to reference them.
+const useProviderCredentialsVerb = "use"
+
+// validateCredentialReferences validates the secret and configmap references and the ProviderCredentials
+// of a providerConfig used in the namespace. If userInfo is set, the user needs the `use` verb on the
+// ProviderCredentials. Namespace scoping is not validated if the namespace is empty. fldPath is the path of
+// the providerSpec value.
+func (ad *admissionData) validateCredentialReferences(ctx context.Context, namespace string, userInfo *authenticationv1.UserInfo, providerConfig *providerconfig.Config, fldPath *field.Path) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	if namespace != "" && providerConfig.CloudProviderSpec.Raw != nil {
+		cloudProviderSpec := map[string]interface{}{}
+		if err := json.Unmarshal(providerConfig.CloudProviderSpec.Raw, &cloudProviderSpec); err != nil {
+			return field.ErrorList{field.Invalid(fldPath.Child("cloudProviderSpec"), field.OmitValueType{}, err.Error())}, nil
+		}
+		if !ad.allowCrossNamespaceRefs {
+			allErrs = append(allErrs, crossNamespaceReferences(namespace, cloudProviderSpec, fldPath.Child("cloudProviderSpec"))...)
+		}
+		allErrs = append(allErrs, externalKeyReferences(namespace, cloudProviderSpec, fldPath.Child("cloudProviderSpec"))...)
+	}
+
+	if providerConfig.CredentialsRef == nil {
+		return allErrs, nil
+	}
+
+	name := providerConfig.CredentialsRef.Name
+	namePath := fldPath.Child("credentialsRef", "name")
+
+	credentials := &clusterv1alpha1.ProviderCredentials{}
+	if err := ad.workerClient.Get(ctx, types.NamespacedName{Name: name}, credentials); err != nil {
+		if apierrors.IsNotFound(err) {
+			return append(allErrs, field.NotFound(namePath, name)), nil
+		}
+		return nil, fmt.Errorf("failed to get ProviderCredentials %s: %w", name, err)
+	}
+
+	if err := configvar.CheckProviderCredentials(ctx, ad.workerClient, credentials, providerConfig.CloudProvider, namespace); err != nil {
+		allErrs = append(allErrs, field.Forbidden(namePath, err.Error()))
+	}
+
+	if userInfo != nil {
+		allowed, err := ad.canUseProviderCredentials(ctx, userInfo, name)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			allErrs = append(allErrs, field.Forbidden(namePath, fmt.Sprintf("user %q may not use ProviderCredentials %s", userInfo.Username, name)))
+		}
+	}
+
+	return allErrs, nil
+}
+
+// canUseProviderCredentials checks with a SubjectAccessReview whether the user has the `use` verb on the
+// ProviderCredentials.
+func (ad *admissionData) canUseProviderCredentials(ctx context.Context, userInfo *authenticationv1.UserInfo, name string) (bool, error) {
+	return ad.isAllowed(ctx, userInfo, &authorizationv1.ResourceAttributes{
+		Verb:     useProviderCredentialsVerb,
+		Group:    clusterv1alpha1.GroupName,
+		Resource: "providercredentials",
+		Name:     name,
+	})
+}
+
+// isAllowed checks with a SubjectAccessReview whether the user may act on the resource.
+func (ad *admissionData) isAllowed(ctx context.Context, userInfo *authenticationv1.UserInfo, attributes *authorizationv1.ResourceAttributes) (bool, error) {
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:               userInfo.Username,
+			Groups:             userInfo.Groups,
+			UID:                userInfo.UID,
+			ResourceAttributes: attributes,
+		},
+	}
+	if userInfo.Extra != nil {
+		review.Spec.Extra = map[string]authorizationv1.ExtraValue{}
+		for key, value := range userInfo.Extra {
+			review.Spec.Extra[key] = authorizationv1.ExtraValue(value)
+		}
+	}
+
+	if err := ad.workerClient.Create(ctx, review); err != nil {
+		return false, fmt.Errorf("failed to create SubjectAccessReview: %w", err)
+	}
+	return review.Status.Allowed, nil
+}
+
+// crossNamespaceReferences returns an error for every secretKeyRef and configMapKeyRef in the value
+// which references another namespace than the given one.
+func crossNamespaceReferences(namespace string, value interface{}, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if key == "secretKeyRef" || key == "configMapKeyRef" {
+				if ref, ok := v[key].(map[string]interface{}); ok {
+					if refNamespace, ok := ref["namespace"].(string); ok && refNamespace != "" && refNamespace != namespace {
+						allErrs = append(allErrs, field.Forbidden(fldPath.Child(key, "namespace"), fmt.Sprintf("references to namespace %q are not allowed from namespace %q", refNamespace, namespace)))
+					}
+					continue
+				}
+			}
+			allErrs = append(allErrs, crossNamespaceReferences(namespace, v[key], fldPath.Child(key))...)
+		}
+	case []interface{}:
+		for i, item := range v {
+			allErrs = append(allErrs, crossNamespaceReferences(namespace, item, fldPath.Index(i))...)
+		}
+	}
+
+	return allErrs
+}
+
+// externalKeyReferences returns an error for every externalKeyRef in the value which references a
+// secret store that is not available to the namespace. Unlike references to other namespaces, these
+// are never allowed.
+func externalKeyReferences(namespace string, value interface{}, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if key == "externalKeyRef" {
+				if ref, ok := v[key].(map[string]interface{}); ok {
+					store, _ := ref["store"].(string)
+					path, _ := ref["path"].(string)
+					refKey, _ := ref["key"].(string)
+					if err := configvar.CheckExternalKeyRef(providerconfig.ExternalKeySelector{Store: store, Path: path, Key: refKey}, namespace); err != nil {
+						allErrs = append(allErrs, field.Forbidden(fldPath.Child(key, "store"), err.Error()))
+					}
+					continue
+				}
+			}
+			allErrs = append(allErrs, externalKeyReferences(namespace, v[key], fldPath.Child(key))...)
+		}
+	case []interface{}:
+		for i, item := range v {
+			allErrs = append(allErrs, externalKeyReferences(namespace, item, fldPath.Index(i))...)
+		}
+	}
+
+	return allErrs
+}
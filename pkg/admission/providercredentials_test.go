@@ -0,0 +1,201 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap/zaptest"
+
+	"k8c.io/machine-controller/pkg/secretstore"
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+	"k8c.io/machine-controller/sdk/providerconfig"
+	"k8c.io/machine-controller/sdk/providerconfig/configvar"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestValidateCredentialReferences(t *testing.T) {
+	store, err := secretstore.New(zaptest.NewLogger(t).Sugar(), &secretstore.Config{
+		Stores: []secretstore.StoreConfig{{Name: "team-a", Namespaces: []string{"team-a"}, File: &secretstore.FileStoreConfig{Directory: t.TempDir()}}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create secret store: %v", err)
+	}
+	configvar.SetSecretStore(store)
+	defer configvar.SetSecretStore(nil)
+
+	objects := []ctrlruntimeclient.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"team": "a"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}},
+		&clusterv1alpha1.ProviderCredentials{
+			ObjectMeta: metav1.ObjectMeta{Name: "hetzner"},
+			Spec: clusterv1alpha1.ProviderCredentialsSpec{
+				Type:              string(providerconfig.CloudProviderHetzner),
+				SecretRef:         corev1.SecretReference{Name: "hetzner", Namespace: "credentials"},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+			},
+		},
+	}
+
+	// Only the user "alice" may use ProviderCredentials.
+	interceptors := interceptor.Funcs{
+		Create: func(ctx context.Context, client ctrlruntimeclient.WithWatch, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.CreateOption) error {
+			if review, ok := obj.(*authorizationv1.SubjectAccessReview); ok {
+				attributes := review.Spec.ResourceAttributes
+				review.Status.Allowed = review.Spec.User == "alice" &&
+					attributes.Verb == "use" && attributes.Group == clusterv1alpha1.GroupName && attributes.Resource == "providercredentials"
+				return nil
+			}
+			return client.Create(ctx, obj, opts...)
+		},
+	}
+
+	alice := &authenticationv1.UserInfo{Username: "alice"}
+	bob := &authenticationv1.UserInfo{Username: "bob"}
+	credentialsRef := &corev1.LocalObjectReference{Name: "hetzner"}
+
+	tests := []struct {
+		name                    string
+		namespace               string
+		userInfo                *authenticationv1.UserInfo
+		cloudProvider           providerconfig.CloudProvider
+		cloudProviderSpec       string
+		credentialsRef          *corev1.LocalObjectReference
+		allowCrossNamespaceRefs bool
+		expectedPaths           []string
+	}{
+		{
+			name:              "reference to the own namespace",
+			namespace:         "team-a",
+			cloudProvider:     providerconfig.CloudProviderHetzner,
+			cloudProviderSpec: `{"token":{"secretKeyRef":{"namespace":"team-a","name":"hetzner","key":"token"}}}`,
+		},
+		{
+			name:              "reference to another namespace",
+			namespace:         "team-a",
+			cloudProvider:     providerconfig.CloudProviderHetzner,
+			cloudProviderSpec: `{"token":{"secretKeyRef":{"namespace":"kube-system","name":"hetzner","key":"token"}},"networks":[{"configMapKeyRef":{"namespace":"kube-system","name":"networks","key":"network"}}]}`,
+			expectedPaths: []string{
+				"spec.providerSpec.value.cloudProviderSpec.networks[0].configMapKeyRef.namespace",
+				"spec.providerSpec.value.cloudProviderSpec.token.secretKeyRef.namespace",
+			},
+		},
+		{
+			name:                    "allowed reference to another namespace",
+			namespace:               "team-a",
+			cloudProvider:           providerconfig.CloudProviderHetzner,
+			cloudProviderSpec:       `{"token":{"secretKeyRef":{"namespace":"kube-system","name":"hetzner","key":"token"}}}`,
+			allowCrossNamespaceRefs: true,
+		},
+		{
+			name:              "reference to a secret store of the namespace",
+			namespace:         "team-a",
+			cloudProvider:     providerconfig.CloudProviderHetzner,
+			cloudProviderSpec: `{"token":{"externalKeyRef":{"store":"team-a","path":"hetzner","key":"token"}}}`,
+		},
+		{
+			name:                    "reference to a secret store of another namespace",
+			namespace:               "team-b",
+			cloudProvider:           providerconfig.CloudProviderHetzner,
+			cloudProviderSpec:       `{"token":{"externalKeyRef":{"store":"team-a","path":"hetzner","key":"token"}}}`,
+			allowCrossNamespaceRefs: true,
+			expectedPaths:           []string{"spec.providerSpec.value.cloudProviderSpec.token.externalKeyRef.store"},
+		},
+		{
+			name:           "usable provider credentials",
+			namespace:      "team-a",
+			userInfo:       alice,
+			cloudProvider:  providerconfig.CloudProviderHetzner,
+			credentialsRef: credentialsRef,
+		},
+		{
+			name:           "provider credentials without permission",
+			namespace:      "team-a",
+			userInfo:       bob,
+			cloudProvider:  providerconfig.CloudProviderHetzner,
+			credentialsRef: credentialsRef,
+			expectedPaths:  []string{"spec.providerSpec.value.credentialsRef.name"},
+		},
+		{
+			name:           "provider credentials not selecting the namespace",
+			namespace:      "team-b",
+			userInfo:       alice,
+			cloudProvider:  providerconfig.CloudProviderHetzner,
+			credentialsRef: credentialsRef,
+			expectedPaths:  []string{"spec.providerSpec.value.credentialsRef.name"},
+		},
+		{
+			name:           "provider credentials of another type",
+			namespace:      "team-a",
+			userInfo:       alice,
+			cloudProvider:  providerconfig.CloudProviderAWS,
+			credentialsRef: credentialsRef,
+			expectedPaths:  []string{"spec.providerSpec.value.credentialsRef.name"},
+		},
+		{
+			name:           "missing provider credentials",
+			namespace:      "team-a",
+			userInfo:       alice,
+			cloudProvider:  providerconfig.CloudProviderHetzner,
+			credentialsRef: &corev1.LocalObjectReference{Name: "missing"},
+			expectedPaths:  []string{"spec.providerSpec.value.credentialsRef.name"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ad := newTestAdmissionData(t)
+			ad.allowCrossNamespaceRefs = test.allowCrossNamespaceRefs
+			ad.workerClient = clientfake.NewClientBuilder().
+				WithScheme(ad.workerClient.Scheme()).
+				WithObjects(objects...).
+				WithInterceptorFuncs(interceptors).
+				Build()
+
+			providerConfig := &providerconfig.Config{
+				CloudProvider:  test.cloudProvider,
+				CredentialsRef: test.credentialsRef,
+			}
+			if test.cloudProviderSpec != "" {
+				providerConfig.CloudProviderSpec = runtime.RawExtension{Raw: []byte(test.cloudProviderSpec)}
+			}
+
+			errs, err := ad.validateCredentialReferences(context.Background(), test.namespace, test.userInfo, providerConfig, field.NewPath("spec", "providerSpec", "value"))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(errs) != len(test.expectedPaths) {
+				t.Fatalf("expected %d errors, got %v", len(test.expectedPaths), errs)
+			}
+			for i, expectedPath := range test.expectedPaths {
+				if errs[i].Field != expectedPath {
+					t.Errorf("expected error for %s, got %v", expectedPath, errs[i])
+				}
+			}
+		})
+	}
+}
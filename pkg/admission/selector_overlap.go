@@ -0,0 +1,131 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"fmt"
+
+	controllerutil "k8c.io/machine-controller/pkg/controller/util"
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// validateMachineDeploymentSelectorOverlap rejects MachineDeployments whose selector overlaps with the
+// one of another MachineDeployment or of a MachineSet controlled by another object. Orphaned
+// MachineSets are not considered, as adopting them is intended.
+func (ad *admissionData) validateMachineDeploymentSelectorOverlap(ctx context.Context, md *clusterv1alpha1.MachineDeployment) error {
+	machineDeployments := &clusterv1alpha1.MachineDeploymentList{}
+	if err := ad.workerClient.List(ctx, machineDeployments, ctrlruntimeclient.InNamespace(md.Namespace)); err != nil {
+		return fmt.Errorf("failed to list MachineDeployments: %w", err)
+	}
+
+	for _, other := range machineDeployments.Items {
+		if other.Name == md.Name {
+			continue
+		}
+		if err := checkSelectorOverlap(&md.Spec.Selector, md.Spec.Template.Labels, "MachineDeployment", other.Name, &other.Spec.Selector, other.Spec.Template.Labels); err != nil {
+			return err
+		}
+	}
+
+	machineSets := &clusterv1alpha1.MachineSetList{}
+	if err := ad.workerClient.List(ctx, machineSets, ctrlruntimeclient.InNamespace(md.Namespace)); err != nil {
+		return fmt.Errorf("failed to list MachineSets: %w", err)
+	}
+
+	for _, other := range machineSets.Items {
+		controllerRef := metav1.GetControllerOf(&other)
+		if controllerRef == nil || isControlledBy(controllerRef, md.UID, "MachineDeployment", md.Name) {
+			continue
+		}
+		if err := checkSelectorOverlap(&md.Spec.Selector, md.Spec.Template.Labels, "MachineSet", other.Name, &other.Spec.Selector, other.Spec.Template.Labels); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateMachineSetSelectorOverlap rejects MachineSets whose selector overlaps with the one of another
+// MachineSet or MachineDeployment. The controlling MachineDeployment and the MachineSets it controls
+// are not considered.
+func (ad *admissionData) validateMachineSetSelectorOverlap(ctx context.Context, ms *clusterv1alpha1.MachineSet) error {
+	controllerRef := metav1.GetControllerOf(ms)
+
+	machineDeployments := &clusterv1alpha1.MachineDeploymentList{}
+	if err := ad.workerClient.List(ctx, machineDeployments, ctrlruntimeclient.InNamespace(ms.Namespace)); err != nil {
+		return fmt.Errorf("failed to list MachineDeployments: %w", err)
+	}
+
+	for _, other := range machineDeployments.Items {
+		if controllerRef != nil && isControlledBy(controllerRef, other.UID, "MachineDeployment", other.Name) {
+			continue
+		}
+		if err := checkSelectorOverlap(&ms.Spec.Selector, ms.Spec.Template.Labels, "MachineDeployment", other.Name, &other.Spec.Selector, other.Spec.Template.Labels); err != nil {
+			return err
+		}
+	}
+
+	machineSets := &clusterv1alpha1.MachineSetList{}
+	if err := ad.workerClient.List(ctx, machineSets, ctrlruntimeclient.InNamespace(ms.Namespace)); err != nil {
+		return fmt.Errorf("failed to list MachineSets: %w", err)
+	}
+
+	for _, other := range machineSets.Items {
+		if other.Name == ms.Name {
+			continue
+		}
+		if otherControllerRef := metav1.GetControllerOf(&other); controllerRef != nil && otherControllerRef != nil && otherControllerRef.UID == controllerRef.UID {
+			continue
+		}
+		if err := checkSelectorOverlap(&ms.Spec.Selector, ms.Spec.Template.Labels, "MachineSet", other.Name, &other.Spec.Selector, other.Spec.Template.Labels); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isControlledBy returns true if the controller reference points to the given object. Objects which
+// are being created have no UID yet, so they are compared by kind and name instead.
+func isControlledBy(controllerRef *metav1.OwnerReference, uid types.UID, kind, name string) bool {
+	if uid != "" {
+		return controllerRef.UID == uid
+	}
+	return controllerRef.Kind == kind && controllerRef.Name == name
+}
+
+func checkSelectorOverlap(selector *metav1.LabelSelector, templateLabels map[string]string, otherKind, otherName string, otherSelector *metav1.LabelSelector, otherTemplateLabels map[string]string) error {
+	overlap, err := controllerutil.SelectorsOverlap(selector, templateLabels, otherSelector, otherTemplateLabels)
+	if err != nil {
+		return fmt.Errorf("failed to compare selector with %s %s: %w", otherKind, otherName, err)
+	}
+	if overlap {
+		return fmt.Errorf("selector overlaps with the one of %s %s", otherKind, otherName)
+	}
+	return nil
+}
+
+// selectorChanged returns true if the selector or the template labels differ between the objects.
+func selectorChanged(selector, oldSelector *metav1.LabelSelector, templateLabels, oldTemplateLabels map[string]string) bool {
+	return !apiequality.Semantic.DeepEqual(selector, oldSelector) || !apiequality.Semantic.DeepEqual(templateLabels, oldTemplateLabels)
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// certificateReloadInterval is how often the certificate files are read again, in addition to
+// watching them for changes.
+const certificateReloadInterval = 10 * time.Second
+
+// certificates serves the certificate of the webhook and verifies client certificates with files
+// which are reloaded once they change, so rotations, e.g. by cert-manager, do not require a restart.
+type certificates struct {
+	log     *zap.SugaredLogger
+	serving *certwatcher.CertWatcher
+
+	// clientCAFile is the bundle client certificates are verified with. Client certificates are
+	// not requested if it is empty.
+	clientCAFile string
+
+	lock            sync.RWMutex
+	clientCAs       *x509.CertPool
+	clientCAContent []byte
+}
+
+func newCertificates(log *zap.SugaredLogger, certFile, keyFile, clientCAFile string) (*certificates, error) {
+	serving, err := certwatcher.New(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load serving certificate: %w", err)
+	}
+
+	c := &certificates{
+		log:          log,
+		serving:      serving.WithWatchInterval(certificateReloadInterval),
+		clientCAFile: clientCAFile,
+	}
+	serving.RegisterCallback(func(cert tls.Certificate) {
+		if cert.Leaf != nil {
+			c.log.Infow("Loaded serving certificate", "notAfter", cert.Leaf.NotAfter)
+		}
+	})
+
+	if clientCAFile != "" {
+		if err := c.loadClientCAs(); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// configure sets up the TLS configuration of the webhook server to use the certificates.
+// Client certificates are requested, but only verified if they are sent. The admission
+// endpoints reject requests without them, see requireClientCertificate.
+func (c *certificates) configure(cfg *tls.Config) {
+	cfg.GetCertificate = c.serving.GetCertificate
+
+	if c.clientCAFile == "" {
+		return
+	}
+	cfg.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		config := cfg.Clone()
+		config.GetConfigForClient = nil
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+
+		c.lock.RLock()
+		config.ClientCAs = c.clientCAs
+		c.lock.RUnlock()
+
+		return config, nil
+	}
+}
+
+// start watches the certificate files until the context is done.
+func (c *certificates) start(ctx context.Context) error {
+	if c.clientCAFile != "" {
+		go func() {
+			ticker := time.NewTicker(certificateReloadInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := c.loadClientCAs(); err != nil {
+						c.log.Errorw("Failed to reload client CA bundle", zap.Error(err))
+					}
+				}
+			}
+		}()
+	}
+
+	return c.serving.Start(ctx)
+}
+
+// loadClientCAs reads the client CA bundle, if it changed. The previous bundle is kept if the
+// file is invalid.
+func (c *certificates) loadClientCAs() error {
+	content, err := os.ReadFile(c.clientCAFile)
+	if err != nil {
+		return fmt.Errorf("failed to read client CA bundle: %w", err)
+	}
+
+	c.lock.RLock()
+	unchanged := bytes.Equal(content, c.clientCAContent)
+	c.lock.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(content) {
+		return errors.New("client CA bundle contains no PEM-encoded certificates")
+	}
+
+	c.lock.Lock()
+	c.clientCAs = pool
+	c.clientCAContent = content
+	c.lock.Unlock()
+
+	c.log.Info("Loaded client CA bundle")
+	return nil
+}
+
+// requireClientCertificate rejects requests without a client certificate verified with the
+// client CA bundle.
+func (c *certificates) requireClientCertificate(handler http.Handler) http.Handler {
+	if c.clientCAFile == "" {
+		return handler
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+			c.log.Infow("Rejected request without a verified client certificate", "path", r.URL.Path, "remoteAddr", r.RemoteAddr)
+			http.Error(w, "a client certificate is required", http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// tlsServer starts the certificates along with the webhook server.
+type tlsServer struct {
+	webhook.Server
+	certificates *certificates
+}
+
+func (s *tlsServer) Start(ctx context.Context) error {
+	go func() {
+		if err := s.certificates.start(ctx); err != nil {
+			s.certificates.log.Errorw("Failed to watch certificates", zap.Error(err))
+		}
+	}()
+
+	return s.Server.Start(ctx)
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type testCertificate struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+// newTestCertificate creates a certificate signed by the parent, or a self-signed CA if the
+// parent is nil.
+func newTestCertificate(t *testing.T, name string, parent *testCertificate) *testCertificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+
+	return &testCertificate{
+		cert: cert,
+		key:  key,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+func (c *testCertificate) write(t *testing.T, certFile, keyFile string) {
+	t.Helper()
+
+	if err := os.WriteFile(certFile, c.pem, 0o600); err != nil {
+		t.Fatalf("failed to write certificate: %v", err)
+	}
+	if keyFile == "" {
+		return
+	}
+	der, err := x509.MarshalECPrivateKey(c.key)
+	if err != nil {
+		t.Fatalf("failed to encode key: %v", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+}
+
+func (c *testCertificate) tlsCertificate() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.cert.Raw}, PrivateKey: c.key}
+}
+
+func TestCertificates(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	clientCAFile := filepath.Join(dir, "client-ca.crt")
+
+	servingCA := newTestCertificate(t, "serving-ca", nil)
+	newTestCertificate(t, "webhook", servingCA).write(t, certFile, keyFile)
+
+	clientCA := newTestCertificate(t, "client-ca", nil)
+	clientCA.write(t, clientCAFile, "")
+	apiserver := newTestCertificate(t, "kube-apiserver", clientCA)
+
+	certs, err := newCertificates(zap.NewNop().Sugar(), certFile, keyFile, clientCAFile)
+	if err != nil {
+		t.Fatalf("failed to load certificates: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/machines", certs.requireClientCertificate(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {})))
+	mux.Handle("/convert", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+
+	server := httptest.NewUnstartedServer(mux)
+	server.TLS = &tls.Config{}
+	certs.configure(server.TLS)
+	server.StartTLS()
+	defer server.Close()
+
+	request := func(path string, clientCert *testCertificate, rootCA *testCertificate) (int, error) {
+		roots := x509.NewCertPool()
+		roots.AddCert(rootCA.cert)
+		config := &tls.Config{RootCAs: roots}
+		if clientCert != nil {
+			config.Certificates = []tls.Certificate{clientCert.tlsCertificate()}
+		}
+
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: config, DisableKeepAlives: true}}
+		resp, err := client.Get(server.URL + path)
+		if err != nil {
+			return 0, err
+		}
+		resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+
+	if status, err := request("/machines", apiserver, servingCA); err != nil || status != http.StatusOK {
+		t.Errorf("expected a request with a client certificate to succeed, got %d: %v", status, err)
+	}
+	if status, err := request("/machines", nil, servingCA); err != nil || status != http.StatusUnauthorized {
+		t.Errorf("expected a request without a client certificate to be rejected, got %d: %v", status, err)
+	}
+	if status, err := request("/convert", nil, servingCA); err != nil || status != http.StatusOK {
+		t.Errorf("expected a request to /convert to succeed without a client certificate, got %d: %v", status, err)
+	}
+
+	// Rotate both the serving certificate and the client CA.
+	rotatedServingCA := newTestCertificate(t, "rotated-serving-ca", nil)
+	newTestCertificate(t, "webhook", rotatedServingCA).write(t, certFile, keyFile)
+	if err := certs.serving.ReadCertificate(); err != nil {
+		t.Fatalf("failed to reload serving certificate: %v", err)
+	}
+
+	rotatedClientCA := newTestCertificate(t, "rotated-client-ca", nil)
+	rotatedClientCA.write(t, clientCAFile, "")
+	if err := certs.loadClientCAs(); err != nil {
+		t.Fatalf("failed to reload client CA bundle: %v", err)
+	}
+
+	if _, err := request("/machines", newTestCertificate(t, "kube-apiserver", rotatedClientCA), servingCA); err == nil {
+		t.Error("expected the previous serving certificate to be replaced")
+	}
+	if status, err := request("/machines", newTestCertificate(t, "kube-apiserver", rotatedClientCA), rotatedServingCA); err != nil || status != http.StatusOK {
+		t.Errorf("expected a request with a client certificate of the rotated CA to succeed, got %d: %v", status, err)
+	}
+	// Clients do not send certificates which are not issued by one of the accepted CAs.
+	if status, err := request("/machines", apiserver, rotatedServingCA); err == nil && status != http.StatusUnauthorized {
+		t.Errorf("expected a client certificate of the previous CA to be rejected, got %d", status)
+	}
+
+	// An invalid bundle keeps the previous one.
+	if err := os.WriteFile(clientCAFile, []byte("invalid"), 0o600); err != nil {
+		t.Fatalf("failed to write client CA bundle: %v", err)
+	}
+	if err := certs.loadClientCAs(); err == nil {
+		t.Error("expected an invalid client CA bundle to be rejected")
+	}
+	if status, err := request("/machines", newTestCertificate(t, "kube-apiserver", rotatedClientCA), rotatedServingCA); err != nil || status != http.StatusOK {
+		t.Errorf("expected the previous client CA bundle to be kept, got %d: %v", status, err)
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8c.io/machine-controller/pkg/bootstrap"
+	controllerutil "k8c.io/machine-controller/pkg/controller/util"
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+	"k8c.io/machine-controller/sdk/providerconfig"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// validateMachineUserdata rejects machines whose userdata exceeds the limit of the cloud provider
+// and can neither be compressed nor staged, so they fail before the machine-controller creates
+// bootstrap tokens or instances for them. Only the userdata of the osm and template bootstrap
+// providers is checked, the http provider would send the machine to its endpoint. Machines whose
+// userdata is not available yet are checked by the machine-controller once it is.
+func (ad *admissionData) validateMachineUserdata(ctx context.Context, machine *clusterv1alpha1.Machine, providerConfig *providerconfig.Config) error {
+	machineDeploymentName, _, err := controllerutil.GetMachineDeploymentNameAndRevisionForMachine(ctx, machine, ad.workerClient)
+	if err != nil {
+		// Machines without a MachineDeployment are checked by the machine-controller.
+		return nil
+	}
+
+	machineDeployment := &clusterv1alpha1.MachineDeployment{}
+	if err := ad.workerClient.Get(ctx, types.NamespacedName{Namespace: machine.Namespace, Name: machineDeploymentName}, machineDeployment); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get MachineDeployment %s: %w", machineDeploymentName, err)
+	}
+
+	switch bootstrap.ProviderType(machineDeployment) {
+	case clusterv1alpha1.BootstrapProviderOSM, clusterv1alpha1.BootstrapProviderTemplate:
+	default:
+		return nil
+	}
+
+	prov, err := bootstrap.ForMachineDeployment(ad.workerClient, machineDeployment)
+	if err != nil {
+		return fmt.Errorf("failed to get bootstrap provider: %w", err)
+	}
+	userdata, err := prov.Userdata(ctx, ad.log, machine, machineDeployment)
+	if err != nil {
+		var notReady *bootstrap.NotReadyError
+		if errors.As(err, &notReady) {
+			return nil
+		}
+		return fmt.Errorf("failed to get userdata: %w", err)
+	}
+
+	if err := bootstrap.ValidateUserdata(providerConfig, userdata); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+	"k8c.io/machine-controller/sdk/providerconfig"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateMachineUserdata(t *testing.T) {
+	small := "{{ .Machine.Spec.Name }}"
+	large := small + strings.Repeat("a", 64*1024)
+
+	tests := []struct {
+		name              string
+		bootstrap         *clusterv1alpha1.Bootstrap
+		operatingSystem   providerconfig.OperatingSystem
+		ownedByMachineSet bool
+		expectErr         bool
+	}{
+		{
+			name:              "small userdata",
+			bootstrap:         &clusterv1alpha1.Bootstrap{Provider: clusterv1alpha1.BootstrapProviderTemplate, Template: small},
+			operatingSystem:   providerconfig.OperatingSystemFlatcar,
+			ownedByMachineSet: true,
+		},
+		{
+			name:              "oversized ignition userdata",
+			bootstrap:         &clusterv1alpha1.Bootstrap{Provider: clusterv1alpha1.BootstrapProviderTemplate, Template: large},
+			operatingSystem:   providerconfig.OperatingSystemFlatcar,
+			ownedByMachineSet: true,
+			expectErr:         true,
+		},
+		{
+			name:              "oversized cloud-init userdata is staged",
+			bootstrap:         &clusterv1alpha1.Bootstrap{Provider: clusterv1alpha1.BootstrapProviderTemplate, Template: large},
+			operatingSystem:   providerconfig.OperatingSystemUbuntu,
+			ownedByMachineSet: true,
+		},
+		{
+			name:              "osm userdata which is not available yet",
+			operatingSystem:   providerconfig.OperatingSystemFlatcar,
+			ownedByMachineSet: true,
+		},
+		{
+			name:            "machine without MachineDeployment",
+			bootstrap:       &clusterv1alpha1.Bootstrap{Provider: clusterv1alpha1.BootstrapProviderTemplate, Template: large},
+			operatingSystem: providerconfig.OperatingSystemFlatcar,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			machineDeployment := &clusterv1alpha1.MachineDeployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "md", Namespace: "kube-system"},
+				Spec:       clusterv1alpha1.MachineDeploymentSpec{Bootstrap: test.bootstrap},
+			}
+			machineSet := &clusterv1alpha1.MachineSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "md-1234",
+					Namespace:       "kube-system",
+					OwnerReferences: []metav1.OwnerReference{{Kind: "MachineDeployment", Name: "md"}},
+				},
+			}
+			machine := &clusterv1alpha1.Machine{
+				ObjectMeta: metav1.ObjectMeta{Name: "machine", Namespace: "kube-system"},
+				Spec:       clusterv1alpha1.MachineSpec{ObjectMeta: metav1.ObjectMeta{Name: "machine"}},
+			}
+			if test.ownedByMachineSet {
+				machine.OwnerReferences = []metav1.OwnerReference{{Kind: "MachineSet", Name: machineSet.Name}}
+			}
+
+			ad := newTestAdmissionData(t, machineDeployment, machineSet)
+			providerConfig := &providerconfig.Config{
+				CloudProvider:   providerconfig.CloudProviderHetzner,
+				OperatingSystem: test.operatingSystem,
+			}
+
+			err := ad.validateMachineUserdata(context.Background(), machine, providerConfig)
+			if (err != nil) != test.expectErr {
+				t.Errorf("expected error: %t, got %v", test.expectErr, err)
+			}
+		})
+	}
+}
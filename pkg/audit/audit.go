@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records the mutating calls made to cloud providers in a dedicated sink, separate
+// from the log, so it can be proven who created or deleted an instance and why.
+package audit
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Result is the outcome of a recorded call.
+type Result string
+
+const (
+	ResultSucceeded Result = "Succeeded"
+	ResultFailed    Result = "Failed"
+)
+
+// Record describes a single mutating call to a cloud provider. It never contains secrets.
+type Record struct {
+	Time time.Time `json:"time"`
+	// Operation is the call, e.g. `Create` or `openstack.AssignFloatingIP`.
+	Operation string `json:"operation"`
+
+	Subject `json:",inline"`
+
+	// Request summarizes the parameters of the call.
+	Request map[string]string `json:"request,omitempty"`
+
+	Result          Result  `json:"result"`
+	Error           string  `json:"error,omitempty"`
+	DurationSeconds float64 `json:"durationSeconds"`
+}
+
+// Subject identifies the Machine and the cloud the recorded calls are made for.
+type Subject struct {
+	Provider          string    `json:"provider"`
+	Region            string    `json:"region,omitempty"`
+	Machine           string    `json:"machine"`
+	MachineUID        types.UID `json:"machineUID"`
+	MachineSet        string    `json:"machineSet,omitempty"`
+	MachineDeployment string    `json:"machineDeployment,omitempty"`
+}
+
+// Sink stores audit records.
+type Sink interface {
+	Write(ctx context.Context, record Record) error
+}
+
+var (
+	// sink is the Sink records are written to. Calls are not recorded if it is nil.
+	sink Sink
+	log  = zap.NewNop().Sugar()
+)
+
+// SetSink sets the Sink all records are written to and the logger failures to write them
+// are reported with. It must be called before any call is recorded.
+func SetSink(logger *zap.SugaredLogger, s Sink) {
+	sink = s
+	log = logger.Named("audit")
+}
+
+// Enabled tells whether calls are recorded.
+func Enabled() bool {
+	return sink != nil
+}
+
+type subjectKey struct{}
+
+// WithSubject returns a context recording the calls made with it for the subject.
+func WithSubject(ctx context.Context, subject Subject) context.Context {
+	return context.WithValue(ctx, subjectKey{}, subject)
+}
+
+// SubjectFromContext returns the subject of the context.
+func SubjectFromContext(ctx context.Context) (Subject, bool) {
+	subject, ok := ctx.Value(subjectKey{}).(Subject)
+	return subject, ok
+}
+
+// Log records the call started at the given time with the subject of the context. Failures to
+// write the record are logged and counted in the machine_controller_audit_records_dropped_total
+// metric, they do not fail the call.
+func Log(ctx context.Context, operation string, request map[string]string, started time.Time, err error) {
+	if sink == nil {
+		return
+	}
+
+	subject, _ := SubjectFromContext(ctx)
+	record := Record{
+		Time:            started.UTC(),
+		Operation:       operation,
+		Subject:         subject,
+		Request:         request,
+		Result:          ResultSucceeded,
+		DurationSeconds: time.Since(started).Seconds(),
+	}
+	if err != nil {
+		record.Result = ResultFailed
+		record.Error = err.Error()
+	}
+
+	// The record is written even if the call was canceled.
+	if writeErr := sink.Write(context.WithoutCancel(ctx), record); writeErr != nil {
+		log.Errorw("Failed to write audit record", "operation", operation, "machine", subject.Machine, zap.Error(writeErr))
+	}
+}
+
+// Call makes and records the call.
+func Call(ctx context.Context, operation string, request map[string]string, call func() error) error {
+	started := time.Now()
+	err := call()
+	Log(ctx, operation, request, started, err)
+	return err
+}
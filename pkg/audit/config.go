@@ -0,0 +1,145 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Config configures the sinks audit records are written to. At least one sink must be set,
+// records are written to all of them.
+type Config struct {
+	File    *FileSinkConfig    `json:"file,omitempty"`
+	Webhook *WebhookSinkConfig `json:"webhook,omitempty"`
+}
+
+// FileSinkConfig configures a sink appending records as JSON lines to a file.
+type FileSinkConfig struct {
+	Path string `json:"path"`
+}
+
+// WebhookSinkConfig configures a sink posting every record as JSON object to a URL. Records are
+// queued and posted asynchronously, failed requests are retried.
+type WebhookSinkConfig struct {
+	URL string `json:"url"`
+
+	// TokenFile is the path of a file holding a bearer token sent with every request. It is
+	// read for every request, so the token can be rotated.
+	// +optional
+	TokenFile string `json:"tokenFile,omitempty"`
+
+	// Timeout of a request. Defaults to 10 seconds.
+	// +optional
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+	// QueueSize is the number of records queued to be posted. Records written while the queue
+	// is full are dropped. Defaults to 1000.
+	// +optional
+	QueueSize int `json:"queueSize,omitempty"`
+}
+
+// LoadConfig reads the configuration of the audit sinks from a YAML or JSON file.
+func LoadConfig(filename string) (*Config, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	config := &Config{}
+	if err := yaml.UnmarshalStrict(content, config); err != nil {
+		return nil, fmt.Errorf("failed to decode file: %w", err)
+	}
+
+	return config, nil
+}
+
+// NewSink creates the sinks of the configuration.
+func NewSink(config *Config) (Sink, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	var sinks multiSink
+	if config.File != nil {
+		fileSink, err := newFileSink(config.File)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, fileSink)
+	}
+	if config.Webhook != nil {
+		sinks = append(sinks, newWebhookSink(config.Webhook))
+	}
+
+	if len(sinks) == 1 {
+		return sinks[0], nil
+	}
+	return sinks, nil
+}
+
+// SetConfigFile creates the sinks configured in the file and records all mutating calls to
+// cloud providers made afterwards in them.
+func SetConfigFile(log *zap.SugaredLogger, filename string) error {
+	config, err := LoadConfig(filename)
+	if err != nil {
+		return err
+	}
+
+	s, err := NewSink(config)
+	if err != nil {
+		return err
+	}
+
+	SetSink(log, s)
+	return nil
+}
+
+func (c *Config) validate() error {
+	if c.File == nil && c.Webhook == nil {
+		return errors.New("at least one of file and webhook must be set")
+	}
+	if c.File != nil && c.File.Path == "" {
+		return errors.New("file.path must be set")
+	}
+	if c.Webhook != nil && c.Webhook.URL == "" {
+		return errors.New("webhook.url must be set")
+	}
+	if c.Webhook != nil && c.Webhook.QueueSize < 0 {
+		return errors.New("webhook.queueSize must not be negative")
+	}
+	return nil
+}
+
+// multiSink writes records to all of its sinks.
+type multiSink []Sink
+
+func (s multiSink) Write(ctx context.Context, record Record) error {
+	var errs []error
+	for _, sink := range s {
+		if err := sink.Write(ctx, record); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
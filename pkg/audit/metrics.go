@@ -0,0 +1,37 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	sinkFile    = "file"
+	sinkWebhook = "webhook"
+)
+
+var recordsDropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "machine_controller_audit_records_dropped_total",
+	Help: "The number of audit records which could not be written to a sink",
+}, []string{"sink"})
+
+func init() {
+	metrics.Registry.MustRegister(recordsDropped)
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+	"k8c.io/machine-controller/sdk/providerconfig"
+)
+
+var (
+	// summaryFields are the fields of the cloudProviderSpecs which are recorded. Secrets can not be
+	// told apart from other values by their name, e.g. the serviceAccount of GCE holds a private key,
+	// so only fields known to hold no secrets are included.
+	summaryFields = map[providerconfig.CloudProvider][]string{
+		providerconfig.CloudProviderAlibaba:             {"regionID", "zoneID", "instanceType", "vSwitchID", "diskType", "diskSize", "internetMaxBandwidthOut"},
+		providerconfig.CloudProviderAnexia:              {"locationID", "templateID", "template", "templateBuild", "cpus", "cpuPerformanceType", "memory", "diskSize", "vlanID"},
+		providerconfig.CloudProviderAWS:                 {"region", "availabilityZone", "vpcId", "subnetId", "instanceProfile", "instanceType", "ami", "diskSize", "diskType", "diskIops", "ebsVolumeEncrypted", "assignPublicIP", "isSpotInstance"},
+		providerconfig.CloudProviderAzure:               {"location", "resourceGroup", "vnetResourceGroup", "vmSize", "vnetName", "subnetName", "routeTableName", "availabilitySet", "securityGroupName", "imageID", "osDiskSize", "osDiskSKU", "dataDiskSize", "dataDiskSKU", "assignPublicIP", "publicIPSKU"},
+		providerconfig.CloudProviderDigitalocean:        {"region", "size", "backups", "private", "monitoring"},
+		providerconfig.CloudProviderEquinixMetal:        {"projectID", "billingCycle", "instanceType", "metro"},
+		providerconfig.CloudProviderGoogle:              {"zone", "machineType", "diskSize", "diskType", "network", "subnetwork", "preemptible", "provisioningModel", "assignPublicIPAddress", "multizone", "regional", "customImage", "minCPUPlatform"},
+		providerconfig.CloudProviderHetzner:             {"serverType", "datacenter", "image", "location", "placementGroupPrefix"},
+		providerconfig.CloudProviderKubeVirt:            {"clusterName"},
+		providerconfig.CloudProviderLinode:              {"region", "type", "backups", "private"},
+		providerconfig.CloudProviderNutanix:             {"clusterName", "projectName", "subnetName", "imageName", "cpus", "cpuCores", "cpuPassthrough", "memoryMB", "diskSize"},
+		providerconfig.CloudProviderOpenNebula:          {"cpu", "vcpu", "memory", "image", "datastore", "diskSize", "network"},
+		providerconfig.CloudProviderOpenstack:           {"region", "availabilityZone", "image", "flavor", "network", "subnet", "floatingIpPool", "rootDiskSizeGB", "rootDiskVolumeType", "serverGroup", "configDrive", "trustDevicePath"},
+		providerconfig.CloudProviderScaleway:            {"zone", "commercialType"},
+		providerconfig.CloudProviderVMwareCloudDirector: {"organization", "vdc", "vapp", "template", "catalog", "placementPolicy", "network", "ipAllocationMode", "cpus", "cpuCores", "memoryMB", "sizingPolicy", "diskSizeGB", "diskBusType", "diskIOPS", "storageProfile"},
+		providerconfig.CloudProviderVsphere:             {"templateVMName", "vmNetName", "datacenter", "cluster", "folder", "resourcePool", "datastoreCluster", "datastore", "cpus", "memoryMB", "diskSizeGB", "vmGroup"},
+		providerconfig.CloudProviderVultr:               {"region", "plan", "osId", "physicalMachine", "enableVPC"},
+	}
+
+	// regionFields are the fields the region of an instance is read from, in that order.
+	regionFields = []string{"region", "regionID", "location", "locationID", "zone", "availabilityZone", "datacenter"}
+)
+
+// SpecSummary summarizes the cloudProviderSpec of the machine for records. Only the fields of
+// summaryFields with plain values are included, values referenced from secrets or config maps
+// are left out. The region of the instance is returned if it is set.
+func SpecSummary(spec clusterv1alpha1.MachineSpec) (map[string]string, string) {
+	providerConfig, err := providerconfig.GetConfig(spec.ProviderSpec)
+	if err != nil || len(providerConfig.CloudProviderSpec.Raw) == 0 {
+		return nil, ""
+	}
+
+	var cloudProviderSpec map[string]interface{}
+	if err := json.Unmarshal(providerConfig.CloudProviderSpec.Raw, &cloudProviderSpec); err != nil {
+		return nil, ""
+	}
+
+	summary := map[string]string{}
+	for _, field := range summaryFields[providerConfig.CloudProvider] {
+		switch v := cloudProviderSpec[field].(type) {
+		case string:
+			if v != "" {
+				summary[field] = v
+			}
+		case bool, float64:
+			summary[field] = fmt.Sprint(v)
+		}
+	}
+
+	region := ""
+	for _, field := range regionFields {
+		if summary[field] != "" {
+			region = summary[field]
+			break
+		}
+	}
+
+	return summary, region
+}
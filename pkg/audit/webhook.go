@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"k8c.io/machine-controller/pkg/cloudprovider/util"
+)
+
+const (
+	defaultWebhookTimeout   = 10 * time.Second
+	defaultWebhookQueueSize = 1000
+	// webhookAttempts is the number of times a record is posted before it is dropped.
+	webhookAttempts = 5
+	// webhookRetryBackoff is the time waited before the first retry, it doubles for every
+	// further retry.
+	webhookRetryBackoff = time.Second
+)
+
+var errWebhookQueueFull = errors.New("webhook queue is full")
+
+// webhookSink posts every record as JSON object to a URL. Records are queued and posted
+// asynchronously, so slow or unavailable receivers do not delay the recorded calls.
+type webhookSink struct {
+	client       *http.Client
+	url          string
+	tokenFile    string
+	queue        chan Record
+	retryBackoff time.Duration
+}
+
+func newWebhookSink(config *WebhookSinkConfig) *webhookSink {
+	timeout := config.Timeout.Duration
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+	queueSize := config.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaultWebhookQueueSize
+	}
+
+	s := &webhookSink{
+		client: &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					RootCAs: util.CABundle,
+				},
+			},
+			Timeout: timeout,
+		},
+		url:          config.URL,
+		tokenFile:    config.TokenFile,
+		queue:        make(chan Record, queueSize),
+		retryBackoff: webhookRetryBackoff,
+	}
+	go s.run()
+
+	return s
+}
+
+// Write queues the record. Records are dropped if the queue is full or if they cannot be
+// posted after webhookAttempts attempts, both are counted in the
+// machine_controller_audit_records_dropped_total metric.
+func (s *webhookSink) Write(_ context.Context, record Record) error {
+	select {
+	case s.queue <- record:
+		return nil
+	default:
+		recordsDropped.WithLabelValues(sinkWebhook).Inc()
+		return errWebhookQueueFull
+	}
+}
+
+// run posts the queued records one after another, in the order they were written.
+func (s *webhookSink) run() {
+	for record := range s.queue {
+		if err := s.postWithRetries(record); err != nil {
+			recordsDropped.WithLabelValues(sinkWebhook).Inc()
+			log.Errorw("Failed to post audit record, dropping it", "operation", record.Operation, "machine", record.Machine, zap.Error(err))
+		}
+	}
+}
+
+func (s *webhookSink) postWithRetries(record Record) error {
+	backoff := s.retryBackoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = s.post(context.Background(), record); err == nil || attempt == webhookAttempts {
+			return err
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func (s *webhookSink) post(ctx context.Context, record Record) error {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode record: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.tokenFile != "" {
+		token, err := os.ReadFile(s.tokenFile)
+		if err != nil {
+			return fmt.Errorf("failed to read token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
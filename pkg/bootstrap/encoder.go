@@ -0,0 +1,288 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"go.uber.org/zap"
+
+	cloudprovidererrors "k8c.io/machine-controller/pkg/cloudprovider/errors"
+	"k8c.io/machine-controller/pkg/cloudprovider/util"
+	"k8c.io/machine-controller/sdk/apis/cluster/common"
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+	"k8c.io/machine-controller/sdk/providerconfig"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Compression describes when the userdata passed to a cloud provider is compressed.
+type Compression string
+
+const (
+	// CompressionNever is used for providers whose API only accepts text as userdata.
+	CompressionNever Compression = "never"
+	// CompressionOversized compresses userdata exceeding the size limit of the provider.
+	CompressionOversized Compression = "oversized"
+	// CompressionAlways compresses all userdata.
+	CompressionAlways Compression = "always"
+)
+
+// UserdataLimit describes the userdata accepted by a cloud provider.
+type UserdataLimit struct {
+	// MaxBytes is the maximum size of the userdata, zero means it is not limited.
+	MaxBytes int
+	// Base64 is set if the limit applies to the base64 encoded userdata.
+	Base64 bool
+	// Compression is applied to the userdata of operating systems bootstrapped by cloud-init.
+	Compression Compression
+}
+
+// userdataLimits are the documented userdata limits of the cloud providers.
+var userdataLimits = map[providerconfig.CloudProvider]UserdataLimit{
+	providerconfig.CloudProviderAWS:          {MaxBytes: 16 * 1024, Compression: CompressionAlways},
+	providerconfig.CloudProviderAzure:        {MaxBytes: 64*1024 - 1, Compression: CompressionOversized},
+	providerconfig.CloudProviderDigitalocean: {MaxBytes: 64 * 1024, Compression: CompressionNever},
+	providerconfig.CloudProviderGoogle:       {MaxBytes: 256 * 1024, Compression: CompressionNever},
+	providerconfig.CloudProviderHetzner:      {MaxBytes: 32 * 1024, Compression: CompressionNever},
+	providerconfig.CloudProviderOpenstack:    {MaxBytes: 64*1024 - 1, Base64: true, Compression: CompressionOversized},
+	// vSphere and VMware Cloud Director pass the userdata base64 encoded in guestinfo variables or
+	// OVF properties, which the VMware tools limit to 1 MiB (tools.setInfo.sizeLimit). vSphere
+	// writes the userdata of cloud-init to an ISO instead, so it is passed uncompressed.
+	providerconfig.CloudProviderVsphere:             {MaxBytes: 1024 * 1024, Base64: true, Compression: CompressionNever},
+	providerconfig.CloudProviderVMwareCloudDirector: {MaxBytes: 1024 * 1024, Base64: true, Compression: CompressionOversized},
+}
+
+// UserdataLimitForProvider returns the userdata limit of the cloud provider. Providers without
+// a known limit accept userdata of any size and do not support compression.
+func UserdataLimitForProvider(cloudProvider providerconfig.CloudProvider) UserdataLimit {
+	if limit, ok := userdataLimits[cloudProvider]; ok {
+		return limit
+	}
+	return UserdataLimit{Compression: CompressionNever}
+}
+
+func (l UserdataLimit) size(userdata string) int {
+	if l.Base64 {
+		return base64.StdEncoding.EncodedLen(len(userdata))
+	}
+	return len(userdata)
+}
+
+func (l UserdataLimit) fits(userdata string) bool {
+	return l.MaxBytes == 0 || l.size(userdata) <= l.MaxBytes
+}
+
+// supportsCloudInitFeatures returns whether the operating system is bootstrapped by cloud-init,
+// which understands compressed userdata and can run the script fetching staged userdata. Flatcar
+// is bootstrapped by Ignition.
+func supportsCloudInitFeatures(os providerconfig.OperatingSystem) bool {
+	return os != providerconfig.OperatingSystemFlatcar
+}
+
+// StagedUserdataSecretName returns the name of the secret in the cloud-init namespace holding the
+// staged userdata of the machine.
+func StagedUserdataSecretName(machine *clusterv1alpha1.Machine) string {
+	return fmt.Sprintf("%s-%s-userdata", machine.Namespace, machine.Name)
+}
+
+// EncodeUserdata prepares the userdata of the machine for its cloud provider. Userdata exceeding
+// the limit of the provider is compressed and, if it is still too large, staged in a secret that
+// is fetched on first boot. A terminal error is returned if neither is possible, so the machine
+// fails before an instance is created.
+func EncodeUserdata(ctx context.Context, log *zap.SugaredLogger, client ctrlruntimeclient.Client, machine *clusterv1alpha1.Machine, providerConfig *providerconfig.Config, userdata string) (string, error) {
+	limit := UserdataLimitForProvider(providerConfig.CloudProvider)
+	cloudInit := supportsCloudInitFeatures(providerConfig.OperatingSystem)
+
+	encoded, err := compressUserdata(limit, cloudInit, userdata)
+	if err != nil {
+		return "", err
+	}
+	if limit.fits(encoded) {
+		return encoded, nil
+	}
+
+	if !cloudInit {
+		return "", userdataTooLargeError(limit, providerConfig, userdata, "it can neither be compressed nor staged for operating systems using Ignition")
+	}
+
+	log.Infow("Staging oversized userdata in secret", "size", len(userdata), "limit", limit.MaxBytes)
+	script, err := stageUserdata(ctx, client, machine, userdata)
+	if err != nil {
+		return "", err
+	}
+
+	encoded, err = compressUserdata(limit, cloudInit, script)
+	if err != nil {
+		return "", err
+	}
+	if !limit.fits(encoded) {
+		return "", userdataTooLargeError(limit, providerConfig, userdata, "even the script fetching the staged userdata exceeds the limit")
+	}
+
+	return encoded, nil
+}
+
+// ValidateUserdata returns the terminal error EncodeUserdata fails with if the userdata of the
+// machine can neither be passed to the cloud provider nor be compressed or staged. It does not
+// stage any userdata.
+func ValidateUserdata(providerConfig *providerconfig.Config, userdata string) error {
+	limit := UserdataLimitForProvider(providerConfig.CloudProvider)
+	cloudInit := supportsCloudInitFeatures(providerConfig.OperatingSystem)
+
+	encoded, err := compressUserdata(limit, cloudInit, userdata)
+	if err != nil {
+		return err
+	}
+	if limit.fits(encoded) || cloudInit {
+		return nil
+	}
+
+	return userdataTooLargeError(limit, providerConfig, userdata, "it can neither be compressed nor staged for operating systems using Ignition")
+}
+
+func userdataTooLargeError(limit UserdataLimit, providerConfig *providerconfig.Config, userdata, cause string) error {
+	return cloudprovidererrors.TerminalError{
+		Reason: common.InvalidConfigurationMachineError,
+		Message: fmt.Sprintf("Userdata of %d bytes exceeds the limit of %d bytes of cloud provider %s and %s. Reduce the size of the userdata, e.g. by moving files into the image or downloading them on boot.",
+			len(userdata), limit.MaxBytes, providerConfig.CloudProvider, cause),
+	}
+}
+
+func compressUserdata(limit UserdataLimit, cloudInit bool, userdata string) (string, error) {
+	if !cloudInit {
+		return userdata, nil
+	}
+
+	switch limit.Compression {
+	case CompressionAlways:
+	case CompressionOversized:
+		if limit.fits(userdata) {
+			return userdata, nil
+		}
+	default:
+		return userdata, nil
+	}
+
+	var b bytes.Buffer
+	gz := gzip.NewWriter(&b)
+	if _, err := gz.Write([]byte(userdata)); err != nil {
+		return "", fmt.Errorf("failed to compress userdata: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return "", fmt.Errorf("failed to compress userdata: %w", err)
+	}
+
+	return b.String(), nil
+}
+
+// stagedUserdataScript fetches the staged userdata with the token of the cloud-init getter and
+// runs cloud-init again with it. The marker file prevents the script from running recursively.
+var stagedUserdataScript = template.Must(template.New("staged-userdata").Parse(`#!/bin/bash
+set -euo pipefail
+
+staged=/var/lib/machine-controller/staged-userdata
+if [ -f "$staged" ]; then
+  exit 0
+fi
+
+mkdir -p /var/lib/machine-controller
+cat > /var/lib/machine-controller/ca.crt <<'EOF'
+{{ .CABundle }}
+EOF
+
+curl --fail --silent --show-error --retry 10 --retry-delay 5 \
+  --cacert /var/lib/machine-controller/ca.crt \
+  --header "Authorization: Bearer {{ .Token }}" \
+  "{{ .APIServer }}/api/v1/namespaces/{{ .Namespace }}/secrets/{{ .SecretName }}" \
+  | sed -n 's/.*"cloud_init": *"\([^"]*\)".*/\1/p' | base64 --decode > "$staged.tmp"
+mv "$staged.tmp" "$staged"
+
+cloud-init clean
+cloud-init --file "$staged" init
+cloud-init --file "$staged" modules --mode config
+cloud-init --file "$staged" modules --mode final
+`))
+
+// stageUserdata stores the userdata in a secret of the cloud-init namespace and returns the script
+// fetching it.
+func stageUserdata(ctx context.Context, client ctrlruntimeclient.Client, machine *clusterv1alpha1.Machine, userdata string) (string, error) {
+	token, apiServer, err := util.ExtractTokenAndAPIServer(ctx, userdata, client)
+	if err != nil {
+		return "", fmt.Errorf("failed to get credentials for fetching staged userdata: %w", err)
+	}
+	caBundle, err := util.ExtractAPIServerCA(userdata)
+	if err != nil {
+		return "", fmt.Errorf("failed to get CA bundle for fetching staged userdata: %w", err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      StagedUserdataSecretName(machine),
+			Namespace: util.CloudInitNamespace,
+		},
+		Data: map[string][]byte{"cloud_init": []byte(userdata)},
+	}
+	if err := client.Create(ctx, secret); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return "", fmt.Errorf("failed to create secret for staged userdata: %w", err)
+		}
+		if err := client.Update(ctx, secret); err != nil {
+			return "", fmt.Errorf("failed to update secret for staged userdata: %w", err)
+		}
+	}
+
+	script := &strings.Builder{}
+	if err := stagedUserdataScript.Execute(script, map[string]string{
+		"CABundle":   strings.TrimSpace(string(caBundle)),
+		"Token":      token,
+		"APIServer":  strings.TrimSuffix(apiServer, "/"),
+		"Namespace":  util.CloudInitNamespace,
+		"SecretName": secret.Name,
+	}); err != nil {
+		return "", fmt.Errorf("failed to render script fetching staged userdata: %w", err)
+	}
+
+	return script.String(), nil
+}
+
+// DeleteStagedUserdata deletes the staged userdata of the machine, if any. The secret is looked up
+// with the client first, so a cached client does not send requests for machines without staged
+// userdata.
+func DeleteStagedUserdata(ctx context.Context, client ctrlruntimeclient.Client, machine *clusterv1alpha1.Machine) error {
+	secret := &corev1.Secret{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: util.CloudInitNamespace, Name: StagedUserdataSecretName(machine)}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get secret for staged userdata: %w", err)
+	}
+	if err := client.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete secret for staged userdata: %w", err)
+	}
+	return nil
+}
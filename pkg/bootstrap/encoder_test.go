@@ -0,0 +1,233 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+
+	cloudprovidererrors "k8c.io/machine-controller/pkg/cloudprovider/errors"
+	"k8c.io/machine-controller/pkg/cloudprovider/util"
+	"k8c.io/machine-controller/sdk/providerconfig"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+const testCloudConfig = `#cloud-config
+write_files:
+- path: "/etc/kubernetes/bootstrap-kubelet.conf"
+  permissions: "0600"
+  content: |
+    apiVersion: v1
+    kind: Config
+    clusters:
+    - name: c
+      cluster:
+        certificate-authority-data: dGVzdC1jYQ==
+        server: https://10.0.0.1:6443
+    contexts:
+    - name: c
+      context:
+        cluster: c
+        user: c
+    current-context: c
+    users:
+    - name: c
+      user:
+        token: abcdef.0123456789abcdef
+`
+
+func gunzip(t *testing.T, data string) string {
+	t.Helper()
+
+	reader, err := gzip.NewReader(bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatalf("failed to read compressed userdata: %v", err)
+	}
+	decompressed, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("failed to decompress userdata: %v", err)
+	}
+	return string(decompressed)
+}
+
+func TestEncodeUserdata(t *testing.T) {
+	small := testCloudConfig
+	// Compresses well, but exceeds all known limits uncompressed.
+	large := testCloudConfig + "# " + strings.Repeat("a", 300*1024) + "\n"
+
+	testCases := []struct {
+		name            string
+		cloudProvider   providerconfig.CloudProvider
+		operatingSystem providerconfig.OperatingSystem
+		userdata        string
+		expectTerminal  bool
+		expectGzip      bool
+		expectStaged    bool
+	}{
+		{
+			name:            "small userdata is passed as is",
+			cloudProvider:   providerconfig.CloudProviderOpenstack,
+			operatingSystem: providerconfig.OperatingSystemUbuntu,
+			userdata:        small,
+		},
+		{
+			name:            "aws always compresses cloud-init userdata",
+			cloudProvider:   providerconfig.CloudProviderAWS,
+			operatingSystem: providerconfig.OperatingSystemUbuntu,
+			userdata:        small,
+			expectGzip:      true,
+		},
+		{
+			name:            "aws does not compress ignition userdata",
+			cloudProvider:   providerconfig.CloudProviderAWS,
+			operatingSystem: providerconfig.OperatingSystemFlatcar,
+			userdata:        small,
+		},
+		{
+			name:            "oversized userdata is compressed",
+			cloudProvider:   providerconfig.CloudProviderAzure,
+			operatingSystem: providerconfig.OperatingSystemUbuntu,
+			userdata:        large,
+			expectGzip:      true,
+		},
+		{
+			name:            "oversized userdata is staged for providers without compression",
+			cloudProvider:   providerconfig.CloudProviderHetzner,
+			operatingSystem: providerconfig.OperatingSystemUbuntu,
+			userdata:        large,
+			expectStaged:    true,
+		},
+		{
+			name:            "oversized ignition userdata fails",
+			cloudProvider:   providerconfig.CloudProviderHetzner,
+			operatingSystem: providerconfig.OperatingSystemFlatcar,
+			userdata:        large,
+			expectTerminal:  true,
+		},
+		{
+			name:            "providers without limit accept any userdata",
+			cloudProvider:   providerconfig.CloudProviderVultr,
+			operatingSystem: providerconfig.OperatingSystemFlatcar,
+			userdata:        large,
+		},
+		{
+			name:            "vsphere accepts ignition userdata up to the guestinfo limit",
+			cloudProvider:   providerconfig.CloudProviderVsphere,
+			operatingSystem: providerconfig.OperatingSystemFlatcar,
+			userdata:        large,
+		},
+		{
+			name:            "vsphere rejects ignition userdata exceeding the guestinfo limit",
+			cloudProvider:   providerconfig.CloudProviderVsphere,
+			operatingSystem: providerconfig.OperatingSystemFlatcar,
+			userdata:        large + strings.Repeat(large, 3),
+			expectTerminal:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			getterSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "cloud-init-getter-token", Namespace: util.CloudInitNamespace},
+				Data:       map[string][]byte{"token": []byte("getter-token")},
+			}
+			client := fakectrlruntimeclient.NewClientBuilder().WithScheme(testScheme(t)).WithObjects(getterSecret).Build()
+			machine := testMachine()
+			providerConfig := &providerconfig.Config{CloudProvider: tc.cloudProvider, OperatingSystem: tc.operatingSystem}
+
+			if err := ValidateUserdata(providerConfig, tc.userdata); (err != nil) != tc.expectTerminal {
+				t.Errorf("expected validation error: %t, got %v", tc.expectTerminal, err)
+			}
+
+			encoded, err := EncodeUserdata(ctx, zap.NewNop().Sugar(), client, machine, providerConfig, tc.userdata)
+			if tc.expectTerminal {
+				if ok, _, _ := cloudprovidererrors.IsTerminalError(err); !ok {
+					t.Fatalf("expected terminal error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to encode userdata: %v", err)
+			}
+
+			if tc.expectGzip {
+				encoded = gunzip(t, encoded)
+			}
+
+			staged := &corev1.Secret{}
+			err = client.Get(ctx, types.NamespacedName{Namespace: util.CloudInitNamespace, Name: StagedUserdataSecretName(machine)}, staged)
+			if !tc.expectStaged {
+				if err == nil {
+					t.Error("expected userdata not to be staged")
+				}
+				if encoded != tc.userdata {
+					t.Errorf("expected the userdata to be passed on unchanged")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("failed to get staged userdata: %v", err)
+			}
+			if string(staged.Data["cloud_init"]) != tc.userdata {
+				t.Error("expected the staged secret to contain the userdata")
+			}
+			for _, expected := range []string{"Bearer getter-token", "https://10.0.0.1:6443/api/v1/namespaces/cloud-init-settings/secrets/" + staged.Name, "test-ca"} {
+				if !strings.Contains(encoded, expected) {
+					t.Errorf("expected the fetch script to contain %q, got:\n%s", expected, encoded)
+				}
+			}
+
+			if err := DeleteStagedUserdata(ctx, client, machine); err != nil {
+				t.Fatalf("failed to delete staged userdata: %v", err)
+			}
+			if err := client.Get(ctx, types.NamespacedName{Namespace: util.CloudInitNamespace, Name: staged.Name}, staged); err == nil {
+				t.Error("expected staged userdata to be deleted")
+			}
+		})
+	}
+}
+
+func TestDeleteStagedUserdataWithoutSecret(t *testing.T) {
+	deletes := 0
+	client := fakectrlruntimeclient.NewClientBuilder().WithScheme(testScheme(t)).WithInterceptorFuncs(interceptor.Funcs{
+		Delete: func(ctx context.Context, client ctrlruntimeclient.WithWatch, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.DeleteOption) error {
+			deletes++
+			return client.Delete(ctx, obj, opts...)
+		},
+	}).Build()
+
+	if err := DeleteStagedUserdata(context.Background(), client, testMachine()); err != nil {
+		t.Fatalf("failed to delete staged userdata: %v", err)
+	}
+	if deletes != 0 {
+		t.Errorf("expected no delete requests for machines without staged userdata, got %d", deletes)
+	}
+}
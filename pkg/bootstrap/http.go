@@ -0,0 +1,278 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	cloudprovidererrors "k8c.io/machine-controller/pkg/cloudprovider/errors"
+	"k8c.io/machine-controller/sdk/apis/cluster/common"
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultHTTPTimeout = 30 * time.Second
+
+	// maxHTTPUserdataSize limits the size of the responses of userdata endpoints.
+	maxHTTPUserdataSize = 4 << 20
+
+	// maxHTTPTransports limits the number of cached transports, as every CA bundle gets its own.
+	maxHTTPTransports = 32
+)
+
+// AllowedHTTPURLs are the URLs userdata may be requested from. A URL is allowed if it has the
+// scheme and host of an allowed URL and its path is below the path of it. If empty, the http
+// provider can not be used.
+var AllowedHTTPURLs []*url.URL
+
+// SetAllowedHTTPURLs parses a comma-separated list of https URLs and replaces AllowedHTTPURLs.
+func SetAllowedHTTPURLs(urls string) error {
+	var allowed []*url.URL
+	for _, raw := range strings.Split(urls, ",") {
+		if raw = strings.TrimSpace(raw); raw == "" {
+			continue
+		}
+		allowedURL, err := url.Parse(raw)
+		if err != nil {
+			return fmt.Errorf("invalid url %q: %w", raw, err)
+		}
+		if allowedURL.Scheme != "https" || allowedURL.Host == "" {
+			return fmt.Errorf("url %q must be an absolute https url", raw)
+		}
+		allowed = append(allowed, allowedURL)
+	}
+
+	AllowedHTTPURLs = allowed
+	return nil
+}
+
+func httpURLAllowed(endpoint *url.URL) bool {
+	for _, allowed := range AllowedHTTPURLs {
+		if !strings.EqualFold(endpoint.Host, allowed.Host) {
+			continue
+		}
+		prefix := strings.TrimSuffix(allowed.EscapedPath(), "/")
+		if path := endpoint.EscapedPath(); path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+type httpProvider struct {
+	client     ctrlruntimeclient.Client
+	httpClient *http.Client
+	spec       clusterv1alpha1.HTTPBootstrap
+}
+
+// httpTransports holds a transport per CA bundle, so the providers created on every reconcile
+// share their connections instead of leaking idle ones. Once maxHTTPTransports is reached, the
+// oldest transport is dropped.
+var httpTransports = struct {
+	lock       sync.Mutex
+	transports map[string]*http.Transport
+	order      []string
+}{transports: map[string]*http.Transport{}}
+
+// NewHTTPProvider returns the provider requesting the userdata from an HTTP endpoint.
+func NewHTTPProvider(client ctrlruntimeclient.Client, spec clusterv1alpha1.HTTPBootstrap) (Provider, error) {
+	if err := ValidateHTTP(spec); err != nil {
+		return nil, err
+	}
+
+	timeout := defaultHTTPTimeout
+	if spec.Timeout != nil {
+		timeout = spec.Timeout.Duration
+	}
+
+	return &httpProvider{
+		client:     client,
+		httpClient: &http.Client{Transport: httpTransport(spec.CABundle), Timeout: timeout},
+		spec:       spec,
+	}, nil
+}
+
+func httpTransport(caBundle string) *http.Transport {
+	httpTransports.lock.Lock()
+	defer httpTransports.lock.Unlock()
+
+	if transport, ok := httpTransports.transports[caBundle]; ok {
+		return transport
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if caBundle != "" {
+		pool := x509.NewCertPool()
+		pool.AppendCertsFromPEM([]byte(caBundle))
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+
+	if len(httpTransports.order) >= maxHTTPTransports {
+		// Providers still using the dropped transport keep working, only its idle connections are closed.
+		oldest := httpTransports.order[0]
+		httpTransports.order = httpTransports.order[1:]
+		httpTransports.transports[oldest].CloseIdleConnections()
+		delete(httpTransports.transports, oldest)
+	}
+	httpTransports.transports[caBundle] = transport
+	httpTransports.order = append(httpTransports.order, caBundle)
+
+	return transport
+}
+
+// ValidateHTTP validates the configuration of the http provider.
+func ValidateHTTP(spec clusterv1alpha1.HTTPBootstrap) error {
+	endpoint, err := url.Parse(spec.URL)
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+	if endpoint.Scheme != "https" || endpoint.Host == "" {
+		return errors.New("url must be an absolute https url")
+	}
+	if !httpURLAllowed(endpoint) {
+		return fmt.Errorf("url %q is not allowed by the machine-controller, see its -bootstrap-http-allowed-urls flag", spec.URL)
+	}
+	if spec.CABundle != "" && !x509.NewCertPool().AppendCertsFromPEM([]byte(spec.CABundle)) {
+		return errors.New("caBundle does not contain any PEM encoded certificate")
+	}
+	if spec.BearerTokenSecretRef != nil && (spec.BearerTokenSecretRef.Name == "" || spec.BearerTokenSecretRef.Key == "") {
+		return errors.New("bearerTokenSecretRef requires a name and a key")
+	}
+	if spec.Timeout != nil && spec.Timeout.Duration <= 0 {
+		return errors.New("timeout must be positive")
+	}
+	return nil
+}
+
+func (p *httpProvider) Userdata(ctx context.Context, log *zap.SugaredLogger, machine *clusterv1alpha1.Machine, _ *clusterv1alpha1.MachineDeployment) (string, error) {
+	redacted, err := redactMachine(machine)
+	if err != nil {
+		return "", err
+	}
+	body, err := json.Marshal(redacted)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal machine: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.spec.URL, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if p.spec.BearerTokenSecretRef != nil {
+		token, err := p.bearerToken(ctx, machine.Namespace)
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	log.Debugw("Requesting userdata", "url", p.spec.URL)
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to request userdata: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// Client errors other than timeouts and rate limits are not resolved by retrying.
+		if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+			return "", cloudprovidererrors.TerminalError{
+				Reason:  common.InvalidConfigurationMachineError,
+				Message: fmt.Sprintf("userdata endpoint returned status %d", resp.StatusCode),
+			}
+		}
+		return "", fmt.Errorf("userdata endpoint returned status %d", resp.StatusCode)
+	}
+
+	userdata, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPUserdataSize+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read userdata: %w", err)
+	}
+	if len(userdata) > maxHTTPUserdataSize {
+		return "", fmt.Errorf("userdata exceeds %d bytes", maxHTTPUserdataSize)
+	}
+	if len(userdata) == 0 {
+		return "", errors.New("userdata endpoint returned no userdata")
+	}
+
+	return string(userdata), nil
+}
+
+// redactMachine returns the machine sent to userdata endpoints. The cloudProviderSpec and the
+// last applied configuration may contain inline credentials, so they are removed together with
+// the managed fields and the status.
+func redactMachine(machine *clusterv1alpha1.Machine) (*clusterv1alpha1.Machine, error) {
+	redacted := &clusterv1alpha1.Machine{
+		TypeMeta:   machine.TypeMeta,
+		ObjectMeta: *machine.ObjectMeta.DeepCopy(),
+		Spec:       *machine.Spec.DeepCopy(),
+	}
+	redacted.ManagedFields = nil
+	delete(redacted.Annotations, corev1.LastAppliedConfigAnnotation)
+
+	if redacted.Spec.ProviderSpec.Value != nil {
+		providerConfig := map[string]interface{}{}
+		if err := json.Unmarshal(redacted.Spec.ProviderSpec.Value.Raw, &providerConfig); err != nil {
+			return nil, fmt.Errorf("failed to decode providerSpec: %w", err)
+		}
+		delete(providerConfig, "cloudProviderSpec")
+
+		raw, err := json.Marshal(providerConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode providerSpec: %w", err)
+		}
+		redacted.Spec.ProviderSpec.Value = &runtime.RawExtension{Raw: raw}
+	}
+
+	return redacted, nil
+}
+
+func (p *httpProvider) bearerToken(ctx context.Context, namespace string) (string, error) {
+	ref := p.spec.BearerTokenSecretRef
+
+	secret := &corev1.Secret{}
+	if err := p.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+		return "", fmt.Errorf("failed to get bearer token secret %q: %w", ref.Name, err)
+	}
+
+	token, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("bearer token secret %q has no key %q", ref.Name, ref.Key)
+	}
+	return string(token), nil
+}
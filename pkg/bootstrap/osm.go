@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"k8c.io/machine-controller/pkg/cloudprovider/util"
+	controllerutil "k8c.io/machine-controller/pkg/controller/util"
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+	"k8c.io/machine-controller/sdk/bootstrap"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const hostnamePlaceholder = "<MACHINE_NAME>"
+
+type osmProvider struct {
+	client ctrlruntimeclient.Client
+}
+
+// NewOSMProvider returns the provider reading the userdata from the bootstrap secrets, which
+// operating-system-manager creates per MachineDeployment in the cloud-init-settings namespace.
+func NewOSMProvider(client ctrlruntimeclient.Client) Provider {
+	return &osmProvider{client: client}
+}
+
+// OSMSecretName returns the name of the bootstrap secret of a MachineDeployment.
+func OSMSecretName(machineDeploymentName, namespace string) string {
+	return fmt.Sprintf(bootstrap.CloudConfigSecretNamePattern, machineDeploymentName, namespace, bootstrap.BootstrapCloudConfig)
+}
+
+func (p *osmProvider) Userdata(ctx context.Context, log *zap.SugaredLogger, machine *clusterv1alpha1.Machine, _ *clusterv1alpha1.MachineDeployment) (string, error) {
+	machineDeploymentName, machineDeploymentRevision, err := controllerutil.GetMachineDeploymentNameAndRevisionForMachine(ctx, machine, p.client)
+	if err != nil {
+		return "", fmt.Errorf("failed to find machine's MachineDployment: %w", err)
+	}
+
+	secretName := OSMSecretName(machineDeploymentName, machine.Namespace)
+	secret := &corev1.Secret{}
+	if err := p.client.Get(ctx, types.NamespacedName{Name: secretName, Namespace: util.CloudInitNamespace}, secret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("failed to get bootstrap secret %q: %w", secretName, err)
+		}
+
+		log.Debugw("Waiting for bootstrap secret", "secret", secretName)
+		return "", &NotReadyError{
+			Reason:  clusterv1alpha1.BootstrapSecretMissingReason,
+			Message: fmt.Sprintf("Waiting for bootstrap secret %s/%s", util.CloudInitNamespace, secretName),
+		}
+	}
+
+	if secretRevision := secret.Annotations[bootstrap.MachineDeploymentRevision]; secretRevision != machineDeploymentRevision {
+		log.Debugw("Waiting for bootstrap secret to be updated", "secret", secretName, "revision", secretRevision, "expected", machineDeploymentRevision)
+		return "", &NotReadyError{
+			Reason:  clusterv1alpha1.BootstrapSecretOutdatedReason,
+			Message: fmt.Sprintf("Waiting for bootstrap secret %s/%s to be updated to revision %s", util.CloudInitNamespace, secretName, machineDeploymentRevision),
+		}
+	}
+
+	return osmUserdata(machine.Spec.Name, *secret), nil
+}
+
+func osmUserdata(machineName string, bootstrapSecret corev1.Secret) string {
+	bootstrapConfig := string(bootstrapSecret.Data["cloud-config"])
+
+	// We have to inject the hostname i.e. machine name.
+	bootstrapConfig = strings.ReplaceAll(bootstrapConfig, hostnamePlaceholder, machineName)
+	// Data is HTML Encoded for ignition.
+	bootstrapConfig = strings.ReplaceAll(bootstrapConfig, url.QueryEscape(hostnamePlaceholder), url.QueryEscape(machineName))
+	return cleanupTemplateOutput(bootstrapConfig)
+}
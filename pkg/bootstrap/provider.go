@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"go.uber.org/zap"
+
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Provider provides the userdata passed to the instance of a machine on creation.
+type Provider interface {
+	// Userdata returns the userdata of the machine. The MachineDeployment is nil for machines
+	// which do not belong to one. A NotReadyError is returned while the userdata is not
+	// available yet.
+	Userdata(ctx context.Context, log *zap.SugaredLogger, machine *clusterv1alpha1.Machine, machineDeployment *clusterv1alpha1.MachineDeployment) (string, error)
+}
+
+// NotReadyError is returned by providers while the userdata of a machine is not available yet.
+type NotReadyError struct {
+	// Reason is a machine readable reason in CamelCase.
+	Reason  string
+	Message string
+}
+
+func (e *NotReadyError) Error() string {
+	return e.Message
+}
+
+// ProviderType returns the type of the provider of the MachineDeployment.
+func ProviderType(machineDeployment *clusterv1alpha1.MachineDeployment) clusterv1alpha1.BootstrapProviderType {
+	if machineDeployment == nil || machineDeployment.Spec.Bootstrap == nil || machineDeployment.Spec.Bootstrap.Provider == "" {
+		return clusterv1alpha1.BootstrapProviderOSM
+	}
+	return machineDeployment.Spec.Bootstrap.Provider
+}
+
+// ForMachineDeployment returns the provider configured for the machines of the MachineDeployment,
+// which defaults to operating-system-manager.
+func ForMachineDeployment(client ctrlruntimeclient.Client, machineDeployment *clusterv1alpha1.MachineDeployment) (Provider, error) {
+	switch providerType := ProviderType(machineDeployment); providerType {
+	case clusterv1alpha1.BootstrapProviderOSM:
+		return NewOSMProvider(client), nil
+	case clusterv1alpha1.BootstrapProviderTemplate:
+		return NewTemplateProvider(machineDeployment.Spec.Bootstrap.Template)
+	case clusterv1alpha1.BootstrapProviderHTTP:
+		if machineDeployment.Spec.Bootstrap.HTTP == nil {
+			return nil, fmt.Errorf("the %s bootstrap provider requires the http configuration", providerType)
+		}
+		return NewHTTPProvider(client, *machineDeployment.Spec.Bootstrap.HTTP)
+	default:
+		return nil, fmt.Errorf("unknown bootstrap provider %q", providerType)
+	}
+}
+
+var blankLinesRegexp = regexp.MustCompile(`(?m)^[ \t]+$`)
+
+// cleanupTemplateOutput postprocesses the output of the template processing. Those
+// may exist due to the working of template functions like those of the sprig package
+// or template condition.
+func cleanupTemplateOutput(output string) string {
+	// Valid YAML files are not allowed to have empty lines containing spaces or tabs.
+	// So far only cleanup.
+	return blankLinesRegexp.ReplaceAllString(output, "")
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+
+	cloudprovidererrors "k8c.io/machine-controller/pkg/cloudprovider/errors"
+	"k8c.io/machine-controller/pkg/cloudprovider/util"
+	controllerutil "k8c.io/machine-controller/pkg/controller/util"
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+	"k8c.io/machine-controller/sdk/bootstrap"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func testMachine() *clusterv1alpha1.Machine {
+	return &clusterv1alpha1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "machine",
+			Namespace:       metav1.NamespaceSystem,
+			OwnerReferences: []metav1.OwnerReference{{Kind: "MachineSet", Name: "md-1234"}},
+		},
+		Spec: clusterv1alpha1.MachineSpec{
+			ObjectMeta: metav1.ObjectMeta{Name: "node"},
+		},
+	}
+}
+
+func testScheme(t *testing.T) *runtime.Scheme {
+	s := runtime.NewScheme()
+	if err := scheme.AddToScheme(s); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	if err := clusterv1alpha1.AddToScheme(s); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	return s
+}
+
+func TestOSMProvider(t *testing.T) {
+	machineSet := &clusterv1alpha1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "md-1234",
+			Namespace:       metav1.NamespaceSystem,
+			Annotations:     map[string]string{controllerutil.RevisionAnnotation: "1"},
+			OwnerReferences: []metav1.OwnerReference{{Kind: "MachineDeployment", Name: "md"}},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        OSMSecretName("md", metav1.NamespaceSystem),
+			Namespace:   util.CloudInitNamespace,
+			Annotations: map[string]string{bootstrap.MachineDeploymentRevision: "1"},
+		},
+		Data: map[string][]byte{"cloud-config": []byte("hostname: <MACHINE_NAME>\n  \n")},
+	}
+
+	client := fakectrlruntimeclient.NewClientBuilder().WithScheme(testScheme(t)).WithObjects(machineSet).Build()
+	prov, err := ForMachineDeployment(client, nil)
+	if err != nil {
+		t.Fatalf("failed to get provider: %v", err)
+	}
+
+	_, err = prov.Userdata(context.Background(), zap.NewNop().Sugar(), testMachine(), nil)
+	var notReady *NotReadyError
+	if !errors.As(err, &notReady) || notReady.Reason != clusterv1alpha1.BootstrapSecretMissingReason {
+		t.Fatalf("expected the userdata not to be ready, got %v", err)
+	}
+
+	if err := client.Create(context.Background(), secret); err != nil {
+		t.Fatalf("failed to create secret: %v", err)
+	}
+	userdata, err := prov.Userdata(context.Background(), zap.NewNop().Sugar(), testMachine(), nil)
+	if err != nil {
+		t.Fatalf("failed to get userdata: %v", err)
+	}
+	if expected := "hostname: node\n\n"; userdata != expected {
+		t.Errorf("expected userdata %q, got %q", expected, userdata)
+	}
+}
+
+func TestTemplateProvider(t *testing.T) {
+	machineDeployment := &clusterv1alpha1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "md", Namespace: metav1.NamespaceSystem},
+		Spec: clusterv1alpha1.MachineDeploymentSpec{
+			Bootstrap: &clusterv1alpha1.Bootstrap{
+				Provider: clusterv1alpha1.BootstrapProviderTemplate,
+				Template: "#cloud-config\nhostname: {{ .Machine.Spec.Name }}\n# {{ .MachineDeployment.Name }}\n",
+			},
+		},
+	}
+
+	prov, err := ForMachineDeployment(nil, machineDeployment)
+	if err != nil {
+		t.Fatalf("failed to get provider: %v", err)
+	}
+
+	userdata, err := prov.Userdata(context.Background(), zap.NewNop().Sugar(), testMachine(), machineDeployment)
+	if err != nil {
+		t.Fatalf("failed to render userdata: %v", err)
+	}
+	if expected := "#cloud-config\nhostname: node\n# md\n"; userdata != expected {
+		t.Errorf("expected userdata %q, got %q", expected, userdata)
+	}
+
+	if _, err := ParseTemplate("{{ .Machine"); err == nil {
+		t.Error("expected invalid template to be rejected")
+	}
+
+	missingKey, err := NewTemplateProvider("{{ .Machine.Labels.role }}")
+	if err != nil {
+		t.Fatalf("failed to get provider: %v", err)
+	}
+	_, err = missingKey.Userdata(context.Background(), zap.NewNop().Sugar(), testMachine(), nil)
+	if ok, _, _ := cloudprovidererrors.IsTerminalError(err); !ok {
+		t.Errorf("expected render error to be terminal, got %v", err)
+	}
+}
+
+func TestHTTPProvider(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		machine := &clusterv1alpha1.Machine{}
+		if err := json.Unmarshal(body, machine); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// Inline credentials must not be sent to the endpoint.
+		if strings.Contains(string(body), "secret-key") || !strings.Contains(string(body), "ubuntu") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte("#cloud-config\nhostname: " + machine.Spec.Name + "\n"))
+	}))
+	defer server.Close()
+
+	if err := SetAllowedHTTPURLs(server.URL + "/userdata/"); err != nil {
+		t.Fatalf("failed to set allowed urls: %v", err)
+	}
+	defer func() { AllowedHTTPURLs = nil }()
+
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	tokenSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "userdata-token", Namespace: metav1.NamespaceSystem},
+		Data:       map[string][]byte{"token": []byte("secret-token")},
+	}
+	client := fakectrlruntimeclient.NewClientBuilder().WithScheme(testScheme(t)).WithObjects(tokenSecret).Build()
+
+	prov, err := NewHTTPProvider(client, clusterv1alpha1.HTTPBootstrap{
+		URL:      server.URL + "/userdata/ubuntu",
+		CABundle: string(caBundle),
+		BearerTokenSecretRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: tokenSecret.Name},
+			Key:                  "token",
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+
+	machine := testMachine()
+	machine.Annotations = map[string]string{corev1.LastAppliedConfigAnnotation: `{"secretAccessKey":"secret-key"}`}
+	machine.Spec.ProviderSpec.Value = &runtime.RawExtension{Raw: []byte(`{"operatingSystem":"ubuntu","cloudProviderSpec":{"secretAccessKey":"secret-key"}}`)}
+
+	userdata, err := prov.Userdata(context.Background(), zap.NewNop().Sugar(), machine, nil)
+	if err != nil {
+		t.Fatalf("failed to get userdata: %v", err)
+	}
+	if expected := "#cloud-config\nhostname: node\n"; userdata != expected {
+		t.Errorf("expected userdata %q, got %q", expected, userdata)
+	}
+
+	if string(machine.Spec.ProviderSpec.Value.Raw) != `{"operatingSystem":"ubuntu","cloudProviderSpec":{"secretAccessKey":"secret-key"}}` {
+		t.Error("expected the machine not to be modified")
+	}
+
+	other, err := NewHTTPProvider(client, clusterv1alpha1.HTTPBootstrap{URL: server.URL + "/userdata", CABundle: string(caBundle)})
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+	if other.(*httpProvider).httpClient.Transport != prov.(*httpProvider).httpClient.Transport {
+		t.Error("expected providers with the same CA bundle to share their transport")
+	}
+
+	// Requests without the bearer token are rejected by the endpoint, which is not resolved by retrying.
+	_, err = other.Userdata(context.Background(), zap.NewNop().Sugar(), machine, nil)
+	if ok, _, _ := cloudprovidererrors.IsTerminalError(err); !ok {
+		t.Errorf("expected unauthorized request to fail terminally, got %v", err)
+	}
+
+	for _, url := range []string{server.URL, server.URL + "/userdata-other", "https://userdata.example.com/userdata/"} {
+		if _, err := NewHTTPProvider(client, clusterv1alpha1.HTTPBootstrap{URL: url}); err == nil {
+			t.Errorf("expected url %q, which is not allowed, to be rejected", url)
+		}
+	}
+
+	if _, err := NewHTTPProvider(client, clusterv1alpha1.HTTPBootstrap{URL: "http://userdata.example.com"}); err == nil {
+		t.Error("expected plain http endpoint to be rejected")
+	}
+}
+
+func TestHTTPTransportLimit(t *testing.T) {
+	first := httpTransport("first")
+	for i := 0; i < maxHTTPTransports; i++ {
+		httpTransport(fmt.Sprintf("bundle-%d", i))
+	}
+
+	httpTransports.lock.Lock()
+	cached := len(httpTransports.transports)
+	httpTransports.lock.Unlock()
+	if cached > maxHTTPTransports {
+		t.Errorf("expected at most %d cached transports, got %d", maxHTTPTransports, cached)
+	}
+	if httpTransport("first") == first {
+		t.Error("expected the oldest transport to be dropped")
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"go.uber.org/zap"
+
+	cloudprovidererrors "k8c.io/machine-controller/pkg/cloudprovider/errors"
+	"k8c.io/machine-controller/sdk/apis/cluster/common"
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+)
+
+type templateProvider struct {
+	template *template.Template
+}
+
+// TemplateData is passed to the userdata templates of the template provider.
+type TemplateData struct {
+	Machine           *clusterv1alpha1.Machine
+	MachineDeployment *clusterv1alpha1.MachineDeployment
+}
+
+// NewTemplateProvider returns the provider rendering the userdata from a Go template.
+func NewTemplateProvider(userdataTemplate string) (Provider, error) {
+	tmpl, err := ParseTemplate(userdataTemplate)
+	if err != nil {
+		return nil, err
+	}
+	return &templateProvider{template: tmpl}, nil
+}
+
+// ParseTemplate parses a userdata template of the template provider.
+func ParseTemplate(userdataTemplate string) (*template.Template, error) {
+	if strings.TrimSpace(userdataTemplate) == "" {
+		return nil, fmt.Errorf("userdata template is empty")
+	}
+
+	tmpl, err := template.New("userdata").Option("missingkey=error").Parse(userdataTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse userdata template: %w", err)
+	}
+	return tmpl, nil
+}
+
+func (p *templateProvider) Userdata(_ context.Context, _ *zap.SugaredLogger, machine *clusterv1alpha1.Machine, machineDeployment *clusterv1alpha1.MachineDeployment) (string, error) {
+	var output strings.Builder
+	if err := p.template.Execute(&output, TemplateData{Machine: machine, MachineDeployment: machineDeployment}); err != nil {
+		// Rendering fails again for the same Machine, changed templates only apply to new Machines.
+		return "", cloudprovidererrors.TerminalError{
+			Reason:  common.InvalidConfigurationMachineError,
+			Message: fmt.Sprintf("failed to render userdata template: %v", err),
+		}
+	}
+	return cleanupTemplateOutput(output.String()), nil
+}
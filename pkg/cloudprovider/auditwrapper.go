@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudprovider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"k8c.io/machine-controller/pkg/audit"
+	"k8c.io/machine-controller/pkg/cloudprovider/instance"
+	cloudprovidertypes "k8c.io/machine-controller/pkg/cloudprovider/types"
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+	"k8c.io/machine-controller/sdk/providerconfig"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// auditingWrapper records the mutating calls of the wrapped cloudprovider with package audit.
+// All other calls are passed to the cachingValidationWrapper.
+type auditingWrapper struct {
+	*cachingValidationWrapper
+	provider providerconfig.CloudProvider
+}
+
+func newAuditingWrapper(provider providerconfig.CloudProvider, actualProvider cloudprovidertypes.Provider) *auditingWrapper {
+	return &auditingWrapper{
+		cachingValidationWrapper: &cachingValidationWrapper{actualProvider: actualProvider},
+		provider:                 provider,
+	}
+}
+
+// Create records the creation of the instance.
+func (w *auditingWrapper) Create(ctx context.Context, log *zap.SugaredLogger, machine *clusterv1alpha1.Machine, data *cloudprovidertypes.ProviderData, userdata string) (instance.Instance, error) {
+	ctx, request := w.withSubject(ctx, machine, data)
+	request["userdataBytes"] = strconv.Itoa(len(userdata))
+
+	started := time.Now()
+	i, err := w.cachingValidationWrapper.Create(ctx, log, machine, data, userdata)
+	if err == nil && i != nil {
+		request["instanceID"] = i.ID()
+	}
+	audit.Log(ctx, "Create", request, started, err)
+	return i, err
+}
+
+// Cleanup records the deletion of the instance.
+func (w *auditingWrapper) Cleanup(ctx context.Context, log *zap.SugaredLogger, m *clusterv1alpha1.Machine, mcd *cloudprovidertypes.ProviderData) (bool, error) {
+	ctx, request := w.withSubject(ctx, m, mcd)
+	if m.DeletionTimestamp != nil {
+		request["deletionTimestamp"] = m.DeletionTimestamp.UTC().Format(time.RFC3339)
+	}
+
+	started := time.Now()
+	completelyGone, err := w.cachingValidationWrapper.Cleanup(ctx, log, m, mcd)
+	request["completelyGone"] = strconv.FormatBool(completelyGone)
+	audit.Log(ctx, "Cleanup", request, started, err)
+	return completelyGone, err
+}
+
+// MigrateUID records the migration of the instance to the new UID.
+func (w *auditingWrapper) MigrateUID(ctx context.Context, log *zap.SugaredLogger, m *clusterv1alpha1.Machine, newUID types.UID) error {
+	ctx, request := w.withSubject(ctx, m, nil)
+	request["newUID"] = string(newUID)
+
+	return audit.Call(ctx, "MigrateUID", request, func() error {
+		return w.cachingValidationWrapper.MigrateUID(ctx, log, m, newUID)
+	})
+}
+
+// withSubject returns a context recording calls for the machine and the summary of its spec.
+func (w *auditingWrapper) withSubject(ctx context.Context, machine *clusterv1alpha1.Machine, data *cloudprovidertypes.ProviderData) (context.Context, map[string]string) {
+	if !audit.Enabled() {
+		return ctx, map[string]string{}
+	}
+
+	request, region := audit.SpecSummary(machine.Spec)
+	if request == nil {
+		request = map[string]string{}
+	}
+
+	subject := audit.Subject{
+		Provider:   string(w.provider),
+		Region:     region,
+		Machine:    fmt.Sprintf("%s/%s", machine.Namespace, machine.Name),
+		MachineUID: machine.UID,
+	}
+	if owner := metav1.GetControllerOf(machine); owner != nil && owner.Kind == "MachineSet" {
+		subject.MachineSet = owner.Name
+
+		// The MachineDeployment is looked up, if a client is available.
+		if data != nil && data.Client != nil {
+			machineSet := &clusterv1alpha1.MachineSet{}
+			if err := data.Client.Get(ctx, ctrlruntimeclient.ObjectKey{Namespace: machine.Namespace, Name: owner.Name}, machineSet); err == nil {
+				if owner := metav1.GetControllerOf(machineSet); owner != nil && owner.Kind == "MachineDeployment" {
+					subject.MachineDeployment = owner.Name
+				}
+			}
+		}
+	}
+
+	return audit.WithSubject(ctx, subject), request
+}
@@ -20,6 +20,7 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	gocache "github.com/patrickmn/go-cache"
@@ -29,11 +30,26 @@ import (
 
 type CloudproviderCache struct {
 	cache *gocache.Cache
+
+	// lock protects the index of the objects the cached results depend on.
+	lock       sync.Mutex
+	ids        map[string][]Reference
+	references map[Reference]map[string]struct{}
 }
 
 // New returns a new cloudproviderCache.
 func New() *CloudproviderCache {
-	return &CloudproviderCache{cache: gocache.New(5*time.Minute, 5*time.Minute)}
+	c := &CloudproviderCache{
+		cache:      gocache.New(5*time.Minute, 5*time.Minute),
+		ids:        map[string][]Reference{},
+		references: map[Reference]map[string]struct{}{},
+	}
+	c.cache.OnEvicted(func(id string, _ interface{}) {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		c.forget(id)
+	})
+	return c
 }
 
 // Get returns an error indicating the result of the validation and a boolean indicating if
@@ -60,17 +76,59 @@ func (c *CloudproviderCache) Get(machineSpec clusterv1alpha1.MachineSpec) (error
 	return errVal, true, nil
 }
 
-// Set sets the passed value for the given machineSpec.
+// Set sets the passed value for the given machineSpec. The value is dropped once one of the
+// Secrets, ConfigMaps or ProviderCredentials referenced by the machineSpec is invalidated.
 func (c *CloudproviderCache) Set(machineSpec clusterv1alpha1.MachineSpec, val error) error {
 	id, err := getID(machineSpec)
 	if err != nil {
 		return err
 	}
 
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.forget(id)
+	refs := References(machineSpec)
+	c.ids[id] = refs
+	for _, ref := range refs {
+		if c.references[ref] == nil {
+			c.references[ref] = map[string]struct{}{}
+		}
+		c.references[ref][id] = struct{}{}
+	}
+
 	c.cache.Set(id, val, gocache.DefaultExpiration)
 	return nil
 }
 
+// Invalidate drops the values of all machineSpecs referencing the object, e.g. after a
+// Secret holding credentials was rotated. It returns the number of dropped values.
+func (c *CloudproviderCache) Invalidate(ref Reference) int {
+	c.lock.Lock()
+	ids := make([]string, 0, len(c.references[ref]))
+	for id := range c.references[ref] {
+		ids = append(ids, id)
+	}
+	c.lock.Unlock()
+
+	// Delete calls the eviction callback, which takes the lock to update the index.
+	for _, id := range ids {
+		c.cache.Delete(id)
+	}
+	return len(ids)
+}
+
+// forget removes the id from the index. The lock must be held by the caller.
+func (c *CloudproviderCache) forget(id string) {
+	for _, ref := range c.ids[id] {
+		delete(c.references[ref], id)
+		if len(c.references[ref]) == 0 {
+			delete(c.references, ref)
+		}
+	}
+	delete(c.ids, id)
+}
+
 func getID(machineSpec clusterv1alpha1.MachineSpec) (string, error) {
 	b, err := json.Marshal(machineSpec.ProviderSpec)
 	if err != nil {
@@ -18,6 +18,7 @@ package cache
 
 import (
 	"errors"
+	"slices"
 	"testing"
 
 	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
@@ -119,3 +120,90 @@ func TestCloudproviderCache(t *testing.T) {
 		t.Errorf("Expected val for m3 to be %s but was %v", errMsg, val)
 	}
 }
+
+func TestCloudproviderCacheInvalidate(t *testing.T) {
+	cache := New()
+
+	secret := Reference{Kind: KindSecret, Namespace: "kube-system", Name: "credentials"}
+	credentials := Reference{Kind: KindProviderCredentials, Name: "team-a"}
+
+	withSecret := clusterv1alpha1.MachineSpec{}
+	withSecret.ProviderSpec.Value = &runtime.RawExtension{Raw: []byte(`{"cloudProviderSpec":{"token":{"secretKeyRef":{"namespace":"kube-system","name":"credentials","key":"token"}}}}`)}
+	withCredentials := clusterv1alpha1.MachineSpec{}
+	withCredentials.ProviderSpec.Value = &runtime.RawExtension{Raw: []byte(`{"credentialsRef":{"name":"team-a"},"cloudProviderSpec":{}}`)}
+
+	for _, spec := range []clusterv1alpha1.MachineSpec{withSecret, withCredentials} {
+		if err := cache.Set(spec, errors.New("invalid credentials")); err != nil {
+			t.Fatalf("Error setting cache value: %v", err)
+		}
+	}
+
+	if dropped := cache.Invalidate(Reference{Kind: KindConfigMap, Namespace: "kube-system", Name: "credentials"}); dropped != 0 {
+		t.Errorf("Expected no value to be dropped for an unreferenced object, got %d", dropped)
+	}
+
+	if dropped := cache.Invalidate(secret); dropped != 1 {
+		t.Errorf("Expected one value to be dropped for the secret, got %d", dropped)
+	}
+	if _, exists, _ := cache.Get(withSecret); exists {
+		t.Error("Expected the value referencing the secret to be dropped")
+	}
+	if _, exists, _ := cache.Get(withCredentials); !exists {
+		t.Error("Expected the value referencing the provider credentials to be kept")
+	}
+
+	// The index is cleaned up, invalidating again drops nothing.
+	if dropped := cache.Invalidate(secret); dropped != 0 {
+		t.Errorf("Expected no value to be dropped after the secret was invalidated, got %d", dropped)
+	}
+
+	if dropped := cache.Invalidate(credentials); dropped != 1 {
+		t.Errorf("Expected one value to be dropped for the provider credentials, got %d", dropped)
+	}
+	if _, exists, _ := cache.Get(withCredentials); exists {
+		t.Error("Expected the value referencing the provider credentials to be dropped")
+	}
+}
+
+func TestReferences(t *testing.T) {
+	testCases := []struct {
+		name     string
+		raw      string
+		expected []Reference
+	}{
+		{
+			name: "no references",
+			raw:  `{"cloudProviderSpec":{"token":"plain"}}`,
+		},
+		{
+			name: "nested secret and config map references",
+			raw:  `{"credentialsRef":{"name":"team-a"},"cloudProviderSpec":{"token":{"secretKeyRef":{"namespace":"ns","name":"s","key":"token"}},"networks":[{"name":{"configMapKeyRef":{"namespace":"ns","name":"c","key":"network"}}}]}}`,
+			expected: []Reference{
+				{Kind: KindProviderCredentials, Name: "team-a"},
+				{Kind: KindSecret, Namespace: "ns", Name: "s"},
+				{Kind: KindConfigMap, Namespace: "ns", Name: "c"},
+			},
+		},
+		{
+			name: "incomplete references are ignored",
+			raw:  `{"cloudProviderSpec":{"token":{"secretKeyRef":{"name":"s","key":"token"}}}}`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			spec := clusterv1alpha1.MachineSpec{}
+			spec.ProviderSpec.Value = &runtime.RawExtension{Raw: []byte(test.raw)}
+
+			refs := References(spec)
+			if len(refs) != len(test.expected) {
+				t.Fatalf("Expected references %v, got %v", test.expected, refs)
+			}
+			for _, expected := range test.expected {
+				if !slices.Contains(refs, expected) {
+					t.Errorf("Expected reference %v in %v", expected, refs)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"encoding/json"
+
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+)
+
+// The kinds of the objects the result of a validation can depend on.
+const (
+	KindSecret              = "Secret"
+	KindConfigMap           = "ConfigMap"
+	KindProviderCredentials = "ProviderCredentials"
+)
+
+// Reference identifies an object read while validating a providerSpec.
+// The namespace of ProviderCredentials is empty, they are cluster scoped.
+type Reference struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// References returns the Secrets, ConfigMaps and ProviderCredentials referenced by
+// the providerSpec of the machineSpec. References which cannot be resolved, because
+// their name, namespace or key is missing, are ignored.
+func References(machineSpec clusterv1alpha1.MachineSpec) []Reference {
+	if machineSpec.ProviderSpec.Value == nil || len(machineSpec.ProviderSpec.Value.Raw) == 0 {
+		return nil
+	}
+
+	var value map[string]interface{}
+	if err := json.Unmarshal(machineSpec.ProviderSpec.Value.Raw, &value); err != nil {
+		return nil
+	}
+
+	refs := map[Reference]struct{}{}
+	if credentialsRef, ok := value["credentialsRef"].(map[string]interface{}); ok {
+		if name, _ := credentialsRef["name"].(string); name != "" {
+			refs[Reference{Kind: KindProviderCredentials, Name: name}] = struct{}{}
+		}
+	}
+	collectKeyReferences(value, refs)
+
+	result := make([]Reference, 0, len(refs))
+	for ref := range refs {
+		result = append(result, ref)
+	}
+	return result
+}
+
+func collectKeyReferences(value interface{}, refs map[Reference]struct{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			kind := ""
+			switch key {
+			case "secretKeyRef":
+				kind = KindSecret
+			case "configMapKeyRef":
+				kind = KindConfigMap
+			}
+
+			if ref, ok := item.(map[string]interface{}); ok && kind != "" {
+				namespace, _ := ref["namespace"].(string)
+				name, _ := ref["name"].(string)
+				refKey, _ := ref["key"].(string)
+				if namespace != "" && name != "" && refKey != "" {
+					refs[Reference{Kind: kind, Namespace: namespace, Name: name}] = struct{}{}
+				}
+				continue
+			}
+			collectKeyReferences(item, refs)
+		}
+	case []interface{}:
+		for _, item := range v {
+			collectKeyReferences(item, refs)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudprovider
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	cloudprovidercache "k8c.io/machine-controller/pkg/cloudprovider/cache"
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrlruntimecache "sigs.k8s.io/controller-runtime/pkg/cache"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CredentialReferences returns the references to the changed Secret, ConfigMap or
+// ProviderCredentials and, for Secrets, to the ProviderCredentials using it.
+func CredentialReferences(ctx context.Context, client ctrlruntimeclient.Reader, kind string, obj metav1.Object) ([]cloudprovidercache.Reference, error) {
+	refs := []cloudprovidercache.Reference{{Kind: kind, Namespace: obj.GetNamespace(), Name: obj.GetName()}}
+	if kind != cloudprovidercache.KindSecret {
+		return refs, nil
+	}
+
+	credentialsList := &clusterv1alpha1.ProviderCredentialsList{}
+	if err := client.List(ctx, credentialsList); err != nil {
+		// Without the ProviderCredentials CRD no ProviderCredentials can reference the Secret.
+		if meta.IsNoMatchError(err) {
+			return refs, nil
+		}
+		return nil, fmt.Errorf("failed to list ProviderCredentials: %w", err)
+	}
+	for _, credentials := range credentialsList.Items {
+		if credentials.Spec.SecretRef.Namespace == obj.GetNamespace() && credentials.Spec.SecretRef.Name == obj.GetName() {
+			refs = append(refs, cloudprovidercache.Reference{Kind: cloudprovidercache.KindProviderCredentials, Name: credentials.Name})
+		}
+	}
+
+	return refs, nil
+}
+
+// WatchCredentials drops the cached validation results of providerSpecs referencing a Secret,
+// ConfigMap or ProviderCredentials once its resourceVersion changes, so rotated credentials
+// are validated again. Only the metadata of Secrets and ConfigMaps is watched. The informers
+// are started with the cache. ProviderCredentials are not watched if their CRD is not installed.
+func WatchCredentials(ctx context.Context, log *zap.SugaredLogger, informerCache ctrlruntimecache.Cache) error {
+	watched := map[string]ctrlruntimeclient.Object{
+		cloudprovidercache.KindSecret:              &metav1.PartialObjectMetadata{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: cloudprovidercache.KindSecret}},
+		cloudprovidercache.KindConfigMap:           &metav1.PartialObjectMetadata{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: cloudprovidercache.KindConfigMap}},
+		cloudprovidercache.KindProviderCredentials: &clusterv1alpha1.ProviderCredentials{},
+	}
+
+	for kind, obj := range watched {
+		informer, err := informerCache.GetInformer(ctx, obj)
+		if err != nil {
+			if kind == cloudprovidercache.KindProviderCredentials && meta.IsNoMatchError(err) {
+				log.Infow("ProviderCredentials CRD is not installed, not watching ProviderCredentials", zap.Error(err))
+				continue
+			}
+			return fmt.Errorf("failed to get informer for %ss: %w", kind, err)
+		}
+
+		invalidate := func(obj interface{}) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			object, ok := obj.(metav1.Object)
+			if !ok {
+				return
+			}
+
+			refs, err := CredentialReferences(ctx, informerCache, kind, object)
+			if err != nil {
+				log.Errorw("Failed to get references of changed object", "kind", kind, "object", ctrlruntimeclient.ObjectKey{Namespace: object.GetNamespace(), Name: object.GetName()}, zap.Error(err))
+				// The object itself is still invalidated.
+				refs = []cloudprovidercache.Reference{{Kind: kind, Namespace: object.GetNamespace(), Name: object.GetName()}}
+			}
+
+			for _, ref := range refs {
+				if dropped := cache.Invalidate(ref); dropped > 0 {
+					log.Debugw("Dropped cached validation results", "kind", ref.Kind, "namespace", ref.Namespace, "name", ref.Name, "results", dropped)
+				}
+			}
+		}
+
+		if _, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				oldObject, oldOK := oldObj.(metav1.Object)
+				newObject, newOK := newObj.(metav1.Object)
+				if oldOK && newOK && oldObject.GetResourceVersion() == newObject.GetResourceVersion() {
+					return
+				}
+				invalidate(newObj)
+			},
+			DeleteFunc: invalidate,
+		}); err != nil {
+			return fmt.Errorf("failed to add event handler for %ss: %w", kind, err)
+		}
+	}
+
+	return nil
+}
@@ -26,22 +26,36 @@ import (
 var (
 	// ErrInstanceNotFound tells that the requested instance was not found on the cloud provider.
 	ErrInstanceNotFound = errors.New("instance not found")
+
+	// ErrInvalidCredentials tells that the cloud provider rejected the credentials of the machine.
+	ErrInvalidCredentials = errors.New("invalid credentials")
 )
 
 func IsNotFound(err error) bool {
 	return errors.Is(err, ErrInstanceNotFound)
 }
 
+// IsInvalidCredentials tells whether the cloud provider rejected the credentials of the machine.
+func IsInvalidCredentials(err error) bool {
+	return errors.Is(err, ErrInvalidCredentials)
+}
+
 // TerminalError is a helper struct that holds errors of type "terminal".
 type TerminalError struct {
 	Reason  common.MachineStatusError
 	Message string
+	// Err optionally classifies the error, e.g. as ErrInvalidCredentials.
+	Err error
 }
 
 func (te TerminalError) Error() string {
 	return fmt.Sprintf("An error of type = %v, with message = %v occurred", te.Reason, te.Message)
 }
 
+func (te TerminalError) Unwrap() error {
+	return te.Err
+}
+
 // IsTerminalError is a helper function that helps to determine if a given error is terminal.
 func IsTerminalError(err error) (bool, common.MachineStatusError, string) {
 	var tError TerminalError
@@ -51,6 +51,9 @@ var (
 	// ErrProviderNotFound tells that the requested cloud provider was not found.
 	ErrProviderNotFound = errors.New("cloudprovider not found")
 
+	// ErrCapacityNotSupported tells that the cloud provider can not report the capacity of its instances.
+	ErrCapacityNotSupported = errors.New("cloudprovider does not support reporting machine capacity")
+
 	providers = map[providerconfig.CloudProvider]func(cvr providerconfig.ConfigVarResolver) cloudprovidertypes.Provider{
 		providerconfig.CloudProviderDigitalocean: func(cvr providerconfig.ConfigVarResolver) cloudprovidertypes.Provider {
 			return digitalocean.New(cvr)
@@ -133,11 +136,11 @@ var (
 
 // ForProvider returns a CloudProvider actuator for the requested provider.
 func ForProvider(p providerconfig.CloudProvider, cvr providerconfig.ConfigVarResolver) (cloudprovidertypes.Provider, error) {
-	if p, found := providers[p]; found {
-		return NewValidationCacheWrappingCloudProvider(p(cvr)), nil
+	if provider, found := providers[p]; found {
+		return newAuditingWrapper(p, provider(cvr)), nil
 	}
-	if p, found := communityProviders[p]; found {
-		return NewValidationCacheWrappingCloudProvider(p(cvr)), nil
+	if provider, found := communityProviders[p]; found {
+		return newAuditingWrapper(p, provider(cvr)), nil
 	}
 	return nil, ErrProviderNotFound
 }
@@ -537,6 +537,7 @@ func anexiaErrorToTerminalError(err error, msg string) error {
 		return cloudprovidererrors.TerminalError{
 			Reason:  common.InvalidConfigurationMachineError,
 			Message: "Request was rejected due to invalid credentials",
+			Err:     cloudprovidererrors.ErrInvalidCredentials,
 		}
 	}
 
@@ -545,6 +546,7 @@ func anexiaErrorToTerminalError(err error, msg string) error {
 		return cloudprovidererrors.TerminalError{
 			Reason:  common.InvalidConfigurationMachineError,
 			Message: "Request was rejected due to invalid credentials",
+			Err:     cloudprovidererrors.ErrInvalidCredentials,
 		}
 	}
 
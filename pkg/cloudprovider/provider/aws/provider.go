@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -38,9 +39,11 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
+	"k8c.io/machine-controller/pkg/audit"
 	cloudprovidererrors "k8c.io/machine-controller/pkg/cloudprovider/errors"
 	"k8c.io/machine-controller/pkg/cloudprovider/instance"
 	cloudprovidertypes "k8c.io/machine-controller/pkg/cloudprovider/types"
+	cloudproviderutil "k8c.io/machine-controller/pkg/cloudprovider/util"
 	"k8c.io/machine-controller/sdk/apis/cluster/common"
 	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
 	awstypes "k8c.io/machine-controller/sdk/cloudprovider/aws"
@@ -48,8 +51,10 @@ import (
 	"k8c.io/machine-controller/sdk/providerconfig"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
@@ -170,8 +175,18 @@ var (
 	// We lock so the first access updates/writes the data to the cache and afterwards everyone reads the cached data.
 	cacheLock = &sync.Mutex{}
 	cache     = gocache.New(5*time.Minute, 5*time.Minute)
+
+	// workloadIdentityConfigs holds the config per region and role, so all clients share the
+	// cached credentials of a role instead of assuming it again for every client.
+	workloadIdentityConfigsLock = &sync.Mutex{}
+	workloadIdentityConfigs     = map[workloadIdentityConfigKey]aws.Config{}
 )
 
+type workloadIdentityConfigKey struct {
+	region        string
+	assumeRoleARN string
+}
+
 type Config struct {
 	AccessKeyID     string
 	SecretAccessKey string
@@ -198,6 +213,7 @@ type Config struct {
 
 	AssumeRoleARN        string
 	AssumeRoleExternalID string
+	UseWorkloadIdentity  bool
 }
 
 type amiFilter struct {
@@ -313,6 +329,28 @@ func getCPUArchitecture(ctx context.Context, client *ec2.Client, instanceType ec
 	return "", errors.New("returned instance type data did not include supported architectures")
 }
 
+func getInstanceTypeInfo(ctx context.Context, client *ec2.Client, region string, instanceType ec2types.InstanceType) (*ec2types.InstanceTypeInfo, error) {
+	cacheKey := fmt.Sprintf("instance-type-%s-%s", region, instanceType)
+	if info, found := cache.Get(cacheKey); found {
+		return info.(*ec2types.InstanceTypeInfo), nil
+	}
+
+	instanceTypes, err := client.DescribeInstanceTypes(ctx, &ec2.DescribeInstanceTypesInput{
+		InstanceTypes: []ec2types.InstanceType{instanceType},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(instanceTypes.InstanceTypes) != 1 {
+		return nil, fmt.Errorf("unexpected length of instance type list: %d", len(instanceTypes.InstanceTypes))
+	}
+
+	info := &instanceTypes.InstanceTypes[0]
+	cache.SetDefault(cacheKey, info)
+	return info, nil
+}
+
 func getDefaultRootDevicePath(os providerconfig.OperatingSystem) (string, error) {
 	const (
 		rootDevicePathSDA  = "/dev/sda1"
@@ -352,13 +390,20 @@ func (p *provider) getConfig(provSpec clusterv1alpha1.ProviderSpec) (*Config, *p
 	}
 
 	c := Config{}
-	c.AccessKeyID, err = p.configVarResolver.GetStringValueOrEnv(rawConfig.AccessKeyID, "AWS_ACCESS_KEY_ID")
+	c.UseWorkloadIdentity, _, err = p.configVarResolver.GetBoolValue(rawConfig.UseWorkloadIdentity)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("failed to get the value of \"accessKeyId\" field, error = %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to get the value of \"useWorkloadIdentity\" field, error = %w", err)
 	}
-	c.SecretAccessKey, err = p.configVarResolver.GetStringValueOrEnv(rawConfig.SecretAccessKey, "AWS_SECRET_ACCESS_KEY")
-	if err != nil {
-		return nil, nil, nil, fmt.Errorf("failed to get the value of \"secretAccessKey\" field, error = %w", err)
+	// The access key is not used with workload identity federation, so it is not read at all.
+	if !c.UseWorkloadIdentity {
+		c.AccessKeyID, err = p.configVarResolver.GetStringValueOrEnv(rawConfig.AccessKeyID, "AWS_ACCESS_KEY_ID")
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to get the value of \"accessKeyId\" field, error = %w", err)
+		}
+		c.SecretAccessKey, err = p.configVarResolver.GetStringValueOrEnv(rawConfig.SecretAccessKey, "AWS_SECRET_ACCESS_KEY")
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to get the value of \"secretAccessKey\" field, error = %w", err)
+		}
 	}
 	c.Region, err = p.configVarResolver.GetStringValue(rawConfig.Region)
 	if err != nil {
@@ -468,7 +513,11 @@ func (p *provider) getConfig(provSpec clusterv1alpha1.ProviderSpec) (*Config, *p
 	return &c, pconfig, rawConfig, err
 }
 
-func getAwsConfig(ctx context.Context, id, secret, token, region, assumeRoleARN, assumeRoleExternalID string) (aws.Config, error) {
+func getAwsConfig(ctx context.Context, id, secret, token, region, assumeRoleARN, assumeRoleExternalID string, useWorkloadIdentity bool) (aws.Config, error) {
+	if useWorkloadIdentity {
+		return getWorkloadIdentityAwsConfig(ctx, region, assumeRoleARN)
+	}
+
 	cfg, err := awsconfig.LoadDefaultConfig(ctx,
 		awsconfig.WithRegion(region),
 		awsconfig.WithCredentialsProvider(awscredentials.NewStaticCredentialsProvider(id, secret, token)),
@@ -493,8 +542,46 @@ func getAwsConfig(ctx context.Context, id, secret, token, region, assumeRoleARN,
 	return cfg, nil
 }
 
-func getEC2client(ctx context.Context, id, secret, region, assumeRoleArn, assumeRoleExternalID string) (*ec2.Client, error) {
-	cfg, err := getAwsConfig(ctx, id, secret, "", region, assumeRoleArn, assumeRoleExternalID)
+// getWorkloadIdentityAwsConfig assumes the role with the projected ServiceAccount token. The
+// config is created once per region and role, its credentials are cached until they expire
+// and the token is read again for every refresh.
+func getWorkloadIdentityAwsConfig(ctx context.Context, region, assumeRoleARN string) (aws.Config, error) {
+	if cloudproviderutil.WorkloadIdentityTokenFile == "" {
+		return aws.Config{}, cloudproviderutil.ErrWorkloadIdentityNotConfigured
+	}
+
+	workloadIdentityConfigsLock.Lock()
+	defer workloadIdentityConfigsLock.Unlock()
+
+	key := workloadIdentityConfigKey{region: region, assumeRoleARN: assumeRoleARN}
+	if cfg, ok := workloadIdentityConfigs[key]; ok {
+		return cfg, nil
+	}
+
+	// AssumeRoleWithWebIdentity is not signed, the token is the only credential.
+	cfg, err := awsconfig.LoadDefaultConfig(ctx,
+		awsconfig.WithRegion(region),
+		awsconfig.WithCredentialsProvider(aws.AnonymousCredentials{}),
+		awsconfig.WithRetryMaxAttempts(maxRetries),
+	)
+	if err != nil {
+		return aws.Config{}, err
+	}
+
+	creds := stscreds.NewWebIdentityRoleProvider(sts.NewFromConfig(cfg), assumeRoleARN,
+		stscreds.IdentityTokenFile(cloudproviderutil.WorkloadIdentityTokenFile),
+		func(o *stscreds.WebIdentityRoleOptions) {
+			o.RoleSessionName = "machine-controller"
+		},
+	)
+	cfg.Credentials = aws.NewCredentialsCache(creds)
+	workloadIdentityConfigs[key] = cfg
+
+	return cfg, nil
+}
+
+func getEC2client(ctx context.Context, id, secret, region, assumeRoleArn, assumeRoleExternalID string, useWorkloadIdentity bool) (*ec2.Client, error) {
+	cfg, err := getAwsConfig(ctx, id, secret, "", region, assumeRoleArn, assumeRoleExternalID, useWorkloadIdentity)
 	if err != nil {
 		return nil, awsErrorToTerminalError(err, "failed to get aws configuration")
 	}
@@ -523,99 +610,141 @@ func (p *provider) AddDefaults(_ *zap.SugaredLogger, spec clusterv1alpha1.Machin
 	return spec, err
 }
 
-func (p *provider) Validate(ctx context.Context, _ *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec) error {
+func (p *provider) Validate(ctx context.Context, log *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec) error {
+	allErrs, err := p.ValidateFields(ctx, log, spec, field.NewPath("spec", "providerSpec", "value"))
+	if err != nil {
+		return err
+	}
+	return allErrs.ToAggregate()
+}
+
+// ValidateFields validates the spec and reports all errors at once.
+func (p *provider) ValidateFields(ctx context.Context, _ *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec, fldPath *field.Path) (field.ErrorList, error) {
 	config, pc, _, err := p.getConfig(spec.ProviderSpec)
 	if err != nil {
-		return fmt.Errorf("failed to parse config: %w", err)
+		return field.ErrorList{field.Invalid(fldPath, field.OmitValueType{}, fmt.Sprintf("failed to parse config: %v", err))}, nil
 	}
 
+	allErrs := field.ErrorList{}
+	specPath := fldPath.Child("cloudProviderSpec")
+
 	if _, osSupported := amiFilters[pc.OperatingSystem]; !osSupported {
-		return fmt.Errorf("unsupported os %s", pc.OperatingSystem)
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("operatingSystem"), pc.OperatingSystem, "unsupported os"))
 	}
 
 	if _, ok := volumeTypes[config.DiskType]; !ok {
-		return fmt.Errorf("invalid volume type %s specified. Supported: %s", config.DiskType, volumeTypes)
+		allErrs = append(allErrs, field.Invalid(specPath.Child("diskType"), config.DiskType, fmt.Sprintf("invalid volume type. Supported: %s", volumeTypes)))
 	}
 
 	if config.InstanceType == "" {
-		return fmt.Errorf("instanceType must be specified")
+		allErrs = append(allErrs, field.Required(specPath.Child("instanceType"), "instanceType must be specified"))
 	}
 
 	// Not the best test as the minimum disk size depends on the AMI
 	// but the best we can do here
 	if config.DiskSize == 0 {
-		return fmt.Errorf("diskSize must be specified and > 0")
+		allErrs = append(allErrs, field.Required(specPath.Child("diskSize"), "diskSize must be specified and > 0"))
+	}
+
+	if len(config.SecurityGroupIDs) == 0 {
+		allErrs = append(allErrs, field.Required(specPath.Child("securityGroupIDs"), "no security groups were specified"))
+	}
+
+	if config.InstanceProfile == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("instanceProfile"), "no instance profile specified"))
 	}
 
-	ec2Client, err := getEC2client(ctx, config.AccessKeyID, config.SecretAccessKey, config.Region, config.AssumeRoleARN, config.AssumeRoleExternalID)
+	if config.UseWorkloadIdentity {
+		if config.AssumeRoleARN == "" {
+			allErrs = append(allErrs, field.Required(specPath.Child("assumeRoleARN"), "the role to assume must be specified to use workload identity"))
+		}
+		if config.AssumeRoleExternalID != "" {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("assumeRoleExternalID"), "external ids are not supported with workload identity"))
+		}
+		if cloudproviderutil.WorkloadIdentityTokenFile == "" {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("useWorkloadIdentity"), cloudproviderutil.ErrWorkloadIdentityNotConfigured.Error()))
+		}
+		if len(allErrs) > 0 {
+			return allErrs, nil
+		}
+	}
+
+	if config.IsSpotInstance != nil && *config.IsSpotInstance {
+		if config.SpotMaxPrice == nil {
+			allErrs = append(allErrs, field.Required(specPath.Child("spotInstanceConfig", "maxPrice"), "max price cannot be empty for spot instances"))
+		}
+	}
+
+	ec2Client, err := getEC2client(ctx, config.AccessKeyID, config.SecretAccessKey, config.Region, config.AssumeRoleARN, config.AssumeRoleExternalID, config.UseWorkloadIdentity)
 	if err != nil {
-		return fmt.Errorf("failed to create ec2 client: %w", err)
+		return nil, fmt.Errorf("failed to create ec2 client: %w", err)
 	}
 	if config.AMI != "" {
 		_, err := ec2Client.DescribeImages(ctx, &ec2.DescribeImagesInput{
 			ImageIds: []string{config.AMI},
 		})
 		if err != nil {
-			return fmt.Errorf("failed to validate ami: %w", err)
+			allErrs = append(allErrs, field.Invalid(specPath.Child("ami"), config.AMI, fmt.Sprintf("failed to validate ami: %v", err)))
 		}
 	}
 
-	vpc, err := getVpc(ctx, ec2Client, config.VpcID)
-	if err != nil {
-		return fmt.Errorf("invalid vpc %q specified: %w", config.VpcID, err)
-	}
-
-	switch f := pc.Network.GetIPFamily(); f {
-	case net.IPFamilyUnspecified, net.IPFamilyIPv4:
-		// noop
-	case net.IPFamilyIPv6, net.IPFamilyIPv4IPv6, net.IPFamilyIPv6IPv4:
-		if len(vpc.Ipv6CidrBlockAssociationSet) == 0 {
-			return fmt.Errorf("vpc %s does not have IPv6 CIDR block", ptr.Deref(vpc.VpcId, ""))
+	if vpc, err := getVpc(ctx, ec2Client, config.VpcID); err != nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("vpcId"), config.VpcID, fmt.Sprintf("invalid vpc: %v", err)))
+	} else {
+		switch f := pc.Network.GetIPFamily(); f {
+		case net.IPFamilyUnspecified, net.IPFamilyIPv4:
+			// noop
+		case net.IPFamilyIPv6, net.IPFamilyIPv4IPv6, net.IPFamilyIPv6IPv4:
+			if len(vpc.Ipv6CidrBlockAssociationSet) == 0 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("network", "ipFamily"), f, fmt.Sprintf("vpc %s does not have IPv6 CIDR block", ptr.Deref(vpc.VpcId, ""))))
+			}
+		default:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("network", "ipFamily"), f, fmt.Sprintf(net.ErrUnknownNetworkFamily, f)))
 		}
-	default:
-		return fmt.Errorf(net.ErrUnknownNetworkFamily, f)
-	}
 
-	dnsHostnames, err := areVpcDNSHostnamesEnabled(ctx, ec2Client, config.VpcID)
-	if err != nil {
-		return fmt.Errorf("failed to retrieve VPC attributes: %w", err)
+		dnsHostnames, err := areVpcDNSHostnamesEnabled(ctx, ec2Client, config.VpcID)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("vpcId"), config.VpcID, fmt.Sprintf("failed to retrieve VPC attributes: %v", err)))
+		} else if !dnsHostnames {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("vpcId"), config.VpcID, "vpc does not have the enableDnsHostname attribute enabled, new machines in this VPC would be incompatible with Kubernetes"))
+		}
 	}
 
-	if !dnsHostnames {
-		return fmt.Errorf("vpc %s does not have the enableDnsHostname attribute enabled, new machines in this VPC would be incompatible with Kubernetes", config.VpcID)
+	if config.SubnetID != "" {
+		subnets, err := ec2Client.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{SubnetIds: []string{config.SubnetID}})
+		switch {
+		case err != nil:
+			allErrs = append(allErrs, field.Invalid(specPath.Child("subnetId"), config.SubnetID, fmt.Sprintf("invalid subnet: %v", err)))
+		case len(subnets.Subnets) != 1 || ptr.Deref(subnets.Subnets[0].VpcId, "") != config.VpcID:
+			allErrs = append(allErrs, field.Invalid(specPath.Child("subnetId"), config.SubnetID, fmt.Sprintf("subnet does not belong to vpc %s", config.VpcID)))
+		}
 	}
 
 	_, err = ec2Client.DescribeAvailabilityZones(ctx, &ec2.DescribeAvailabilityZonesInput{ZoneNames: []string{config.AvailabilityZone}})
 	if err != nil {
-		return fmt.Errorf("invalid zone %q specified: %w", config.AvailabilityZone, err)
+		allErrs = append(allErrs, field.Invalid(specPath.Child("availabilityZone"), config.AvailabilityZone, fmt.Sprintf("invalid zone: %v", err)))
 	}
 
 	_, err = ec2Client.DescribeRegions(ctx, &ec2.DescribeRegionsInput{RegionNames: []string{config.Region}})
 	if err != nil {
-		return fmt.Errorf("invalid region %q specified: %w", config.Region, err)
-	}
-
-	if len(config.SecurityGroupIDs) == 0 {
-		return errors.New("no security groups were specified")
-	}
-	_, err = ec2Client.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{
-		GroupIds: config.SecurityGroupIDs,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to validate security group id's: %w", err)
+		allErrs = append(allErrs, field.Invalid(specPath.Child("region"), config.Region, fmt.Sprintf("invalid region: %v", err)))
 	}
 
-	if config.InstanceProfile == "" {
-		return errors.New("no instance profile specified")
+	if len(config.SecurityGroupIDs) > 0 {
+		_, err = ec2Client.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{
+			GroupIds: config.SecurityGroupIDs,
+		})
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("securityGroupIDs"), config.SecurityGroupIDs, fmt.Sprintf("failed to validate security group id's: %v", err)))
+		}
 	}
 
-	if config.IsSpotInstance != nil && *config.IsSpotInstance {
-		if config.SpotMaxPrice == nil {
-			return errors.New("failed to validate max price for the spot instance: max price cannot be empty when spot instance ")
-		}
+	// Failed API calls of canceled requests are no validation errors.
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	return nil
+	return allErrs, nil
 }
 
 func getVpc(ctx context.Context, client *ec2.Client, id string) (*ec2types.Vpc, error) {
@@ -662,7 +791,7 @@ func (p *provider) Create(ctx context.Context, log *zap.SugaredLogger, machine *
 		}
 	}
 
-	ec2Client, err := getEC2client(ctx, config.AccessKeyID, config.SecretAccessKey, config.Region, config.AssumeRoleARN, config.AssumeRoleExternalID)
+	ec2Client, err := getEC2client(ctx, config.AccessKeyID, config.SecretAccessKey, config.Region, config.AssumeRoleARN, config.AssumeRoleExternalID, config.UseWorkloadIdentity)
 	if err != nil {
 		return nil, err
 	}
@@ -692,14 +821,6 @@ func (p *provider) Create(ctx context.Context, log *zap.SugaredLogger, machine *
 		}
 	}
 
-	if pc.OperatingSystem != providerconfig.OperatingSystemFlatcar {
-		// Gzip the userdata in case we don't use Flatcar
-		userdata, err = gzipString(userdata)
-		if err != nil {
-			return nil, fmt.Errorf("failed to gzip the userdata")
-		}
-	}
-
 	tags := []ec2types.Tag{
 		{
 			Key:   aws.String(nameTag),
@@ -796,7 +917,23 @@ func (p *provider) Create(ctx context.Context, log *zap.SugaredLogger, machine *
 		instanceRequest.NetworkInterfaces[0].Ipv6AddressCount = aws.Int32(1)
 	}
 
-	runOut, err := ec2Client.RunInstances(ctx, instanceRequest)
+	// The root volume and the network interface are created along with the instance.
+	runRequest := map[string]string{
+		"instanceType":     string(config.InstanceType),
+		"availabilityZone": config.AvailabilityZone,
+		"volumeSize":       strconv.Itoa(int(config.DiskSize)),
+		"volumeType":       string(config.DiskType),
+		"volumeEncrypted":  strconv.FormatBool(config.EBSVolumeEncrypted),
+		"subnetID":         config.SubnetID,
+		"securityGroupIDs": strings.Join(config.SecurityGroupIDs, ","),
+		"assignPublicIP":   strconv.FormatBool(assignPublicIP),
+		"ipv6":             strconv.FormatBool(pc.Network.GetIPFamily().HasIPv6()),
+	}
+	var runOut *ec2.RunInstancesOutput
+	err = audit.Call(ctx, "aws.RunInstances", runRequest, func() error {
+		runOut, err = ec2Client.RunInstances(ctx, instanceRequest)
+		return err
+	})
 	if err != nil {
 		return nil, awsErrorToTerminalError(err, "failed create instance at aws")
 	}
@@ -827,17 +964,20 @@ func (p *provider) Cleanup(ctx context.Context, log *zap.SugaredLogger, machine
 		}
 	}
 
-	ec2Client, err := getEC2client(ctx, config.AccessKeyID, config.SecretAccessKey, config.Region, config.AssumeRoleARN, config.AssumeRoleExternalID)
+	ec2Client, err := getEC2client(ctx, config.AccessKeyID, config.SecretAccessKey, config.Region, config.AssumeRoleARN, config.AssumeRoleExternalID, config.UseWorkloadIdentity)
 	if err != nil {
 		return false, err
 	}
 
 	if config.IsSpotInstance != nil && *config.IsSpotInstance &&
 		config.SpotPersistentRequest != nil && *config.SpotPersistentRequest {
-		cOut, err := ec2Client.CancelSpotInstanceRequests(ctx, &ec2.CancelSpotInstanceRequestsInput{
-			SpotInstanceRequestIds: []string{*ec2instance.instance.SpotInstanceRequestId},
+		var cOut *ec2.CancelSpotInstanceRequestsOutput
+		err := audit.Call(ctx, "aws.CancelSpotInstanceRequests", map[string]string{"spotInstanceRequestID": *ec2instance.instance.SpotInstanceRequestId}, func() error {
+			cOut, err = ec2Client.CancelSpotInstanceRequests(ctx, &ec2.CancelSpotInstanceRequestsInput{
+				SpotInstanceRequestIds: []string{*ec2instance.instance.SpotInstanceRequestId},
+			})
+			return err
 		})
-
 		if err != nil {
 			return false, awsErrorToTerminalError(err, "failed to cancel spot instance request")
 		}
@@ -847,8 +987,13 @@ func (p *provider) Cleanup(ctx context.Context, log *zap.SugaredLogger, machine
 		}
 	}
 
-	tOut, err := ec2Client.TerminateInstances(ctx, &ec2.TerminateInstancesInput{
-		InstanceIds: []string{ec2instance.ID()},
+	// The root volume and the network interface are deleted along with the instance.
+	var tOut *ec2.TerminateInstancesOutput
+	err = audit.Call(ctx, "aws.TerminateInstances", map[string]string{"instanceID": ec2instance.ID()}, func() error {
+		tOut, err = ec2Client.TerminateInstances(ctx, &ec2.TerminateInstancesInput{
+			InstanceIds: []string{ec2instance.ID()},
+		})
+		return err
 	})
 	if err != nil {
 		return false, awsErrorToTerminalError(err, "failed to terminate instance")
@@ -874,7 +1019,7 @@ func (p *provider) get(ctx context.Context, machine *clusterv1alpha1.Machine) (*
 		}
 	}
 
-	ec2Client, err := getEC2client(ctx, config.AccessKeyID, config.SecretAccessKey, config.Region, config.AssumeRoleARN, config.AssumeRoleExternalID)
+	ec2Client, err := getEC2client(ctx, config.AccessKeyID, config.SecretAccessKey, config.Region, config.AssumeRoleARN, config.AssumeRoleExternalID, config.UseWorkloadIdentity)
 	if err != nil {
 		return nil, err
 	}
@@ -922,6 +1067,44 @@ func (p *provider) MachineMetricsLabels(machine *clusterv1alpha1.Machine) (map[s
 	return labels, err
 }
 
+// MachineCapacity returns the vCPUs, memory and GPUs of the machine's instance type and its disk size.
+func (p *provider) MachineCapacity(ctx context.Context, _ *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec) (*cloudprovidertypes.MachineCapacity, error) {
+	config, _, _, err := p.getConfig(spec.ProviderSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	ec2Client, err := getEC2client(ctx, config.AccessKeyID, config.SecretAccessKey, config.Region, config.AssumeRoleARN, config.AssumeRoleExternalID, config.UseWorkloadIdentity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ec2 client: %w", err)
+	}
+
+	info, err := getInstanceTypeInfo(ctx, ec2Client, config.Region, config.InstanceType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get instance type %s: %w", config.InstanceType, err)
+	}
+
+	capacity := &cloudprovidertypes.MachineCapacity{
+		EphemeralStorage: resource.NewQuantity(int64(config.DiskSize)*1024*1024*1024, resource.BinarySI),
+	}
+	if info.VCpuInfo != nil && info.VCpuInfo.DefaultVCpus != nil {
+		capacity.CPU = resource.NewQuantity(int64(*info.VCpuInfo.DefaultVCpus), resource.DecimalSI)
+	}
+	if info.MemoryInfo != nil && info.MemoryInfo.SizeInMiB != nil {
+		capacity.Memory = resource.NewQuantity(*info.MemoryInfo.SizeInMiB*1024*1024, resource.BinarySI)
+	}
+	if info.GpuInfo != nil {
+		for _, gpu := range info.GpuInfo.Gpus {
+			capacity.GPUCount += int64(ptr.Deref(gpu.Count, 0))
+			if capacity.GPUType == "" {
+				capacity.GPUType = ptr.Deref(gpu.Name, "")
+			}
+		}
+	}
+
+	return capacity, nil
+}
+
 func (p *provider) MigrateUID(ctx context.Context, _ *zap.SugaredLogger, machine *clusterv1alpha1.Machine, newUID types.UID) error {
 	machineInstance, err := p.get(ctx, machine)
 	if err != nil {
@@ -939,14 +1122,17 @@ func (p *provider) MigrateUID(ctx context.Context, _ *zap.SugaredLogger, machine
 		}
 	}
 
-	ec2Client, err := getEC2client(ctx, config.AccessKeyID, config.SecretAccessKey, config.Region, config.AssumeRoleARN, config.AssumeRoleExternalID)
+	ec2Client, err := getEC2client(ctx, config.AccessKeyID, config.SecretAccessKey, config.Region, config.AssumeRoleARN, config.AssumeRoleExternalID, config.UseWorkloadIdentity)
 	if err != nil {
 		return fmt.Errorf("failed to get EC2 client: %w", err)
 	}
 
-	_, err = ec2Client.CreateTags(ctx, &ec2.CreateTagsInput{
-		Resources: []string{machineInstance.ID()},
-		Tags:      []ec2types.Tag{{Key: aws.String(machineUIDTag), Value: aws.String(string(newUID))}}})
+	err = audit.Call(ctx, "aws.CreateTags", map[string]string{"instanceID": machineInstance.ID(), "machineUID": string(newUID)}, func() error {
+		_, err := ec2Client.CreateTags(ctx, &ec2.CreateTagsInput{
+			Resources: []string{machineInstance.ID()},
+			Tags:      []ec2types.Tag{{Key: aws.String(machineUIDTag), Value: aws.String(string(newUID))}}})
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("failed to update instance with new machineUIDTag: %w", err)
 	}
@@ -1053,6 +1239,7 @@ func awsErrorToTerminalError(err error, msg string) error {
 			return cloudprovidererrors.TerminalError{
 				Reason:  common.InvalidConfigurationMachineError,
 				Message: "A request has been rejected due to invalid credentials which were taken from the MachineSpec",
+				Err:     cloudprovidererrors.ErrInvalidCredentials,
 			}
 		case "OptInRequired":
 			// User has to accept the terms of the AMI
@@ -1102,6 +1289,7 @@ func (p *provider) SetMetricsForMachines(machines clusterv1alpha1.MachineList) e
 		region               string
 		assumeRoleARN        string
 		assumeRoleExternalID string
+		useWorkloadIdentity  bool
 	}
 
 	var machineErrors []error
@@ -1114,18 +1302,19 @@ func (p *provider) SetMetricsForMachines(machines clusterv1alpha1.MachineList) e
 		}
 
 		// Very simple and very stupid
-		machineEc2Credentials[fmt.Sprintf("%s/%s/%s/%s/%s", config.AccessKeyID, config.SecretAccessKey, config.Region, config.AssumeRoleARN, config.AssumeRoleExternalID)] = ec2Credentials{
+		machineEc2Credentials[fmt.Sprintf("%s/%s/%s/%s/%s/%t", config.AccessKeyID, config.SecretAccessKey, config.Region, config.AssumeRoleARN, config.AssumeRoleExternalID, config.UseWorkloadIdentity)] = ec2Credentials{
 			accessKeyID:          config.AccessKeyID,
 			secretAccessKey:      config.SecretAccessKey,
 			region:               config.Region,
 			assumeRoleARN:        config.AssumeRoleARN,
 			assumeRoleExternalID: config.AssumeRoleExternalID,
+			useWorkloadIdentity:  config.UseWorkloadIdentity,
 		}
 	}
 
 	allReservations := []ec2types.Reservation{}
 	for _, cred := range machineEc2Credentials {
-		ec2Client, err := getEC2client(ctx, cred.accessKeyID, cred.secretAccessKey, cred.region, cred.assumeRoleARN, cred.assumeRoleExternalID)
+		ec2Client, err := getEC2client(ctx, cred.accessKeyID, cred.secretAccessKey, cred.region, cred.assumeRoleARN, cred.assumeRoleExternalID, cred.useWorkloadIdentity)
 		if err != nil {
 			machineErrors = append(machineErrors, fmt.Errorf("failed to get EC2 client: %w", err))
 			continue
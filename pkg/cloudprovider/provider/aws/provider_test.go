@@ -0,0 +1,52 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"testing"
+
+	cloudproviderutil "k8c.io/machine-controller/pkg/cloudprovider/util"
+)
+
+func TestGetWorkloadIdentityAwsConfig(t *testing.T) {
+	defer func(tokenFile string) { cloudproviderutil.WorkloadIdentityTokenFile = tokenFile }(cloudproviderutil.WorkloadIdentityTokenFile)
+	cloudproviderutil.WorkloadIdentityTokenFile = "/var/run/secrets/tokens/token"
+
+	ctx := context.Background()
+	roleARN := "arn:aws:iam::123456789012:role/machine-controller"
+
+	first, err := getWorkloadIdentityAwsConfig(ctx, "eu-central-1", roleARN)
+	if err != nil {
+		t.Fatalf("failed to get config: %v", err)
+	}
+	second, err := getWorkloadIdentityAwsConfig(ctx, "eu-central-1", roleARN)
+	if err != nil {
+		t.Fatalf("failed to get config: %v", err)
+	}
+	if first.Credentials != second.Credentials {
+		t.Error("expected the credentials of a region and role to be shared")
+	}
+
+	other, err := getWorkloadIdentityAwsConfig(ctx, "eu-west-1", roleARN)
+	if err != nil {
+		t.Fatalf("failed to get config: %v", err)
+	}
+	if other.Credentials == first.Credentials || other.Region != "eu-west-1" {
+		t.Error("expected a config with separate credentials for another region")
+	}
+}
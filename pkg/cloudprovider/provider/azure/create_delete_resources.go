@@ -22,10 +22,10 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/profiles/latest/compute/mgmt/compute"
 	"github.com/Azure/azure-sdk-for-go/profiles/latest/network/mgmt/network"
-	"github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/Azure/go-autorest/autorest/to"
 	"go.uber.org/zap"
 
+	"k8c.io/machine-controller/pkg/audit"
 	"k8c.io/machine-controller/sdk/net"
 
 	"k8s.io/apimachinery/pkg/types"
@@ -56,14 +56,17 @@ func deleteInterfacesByMachineUID(ctx context.Context, c *config, machineUID typ
 
 	for _, iface := range allInterfaces {
 		if iface.Tags != nil && iface.Tags[machineUIDTag] != nil && *iface.Tags[machineUIDTag] == string(machineUID) {
-			future, err := ifClient.Delete(ctx, c.ResourceGroup, *iface.Name)
+			err := audit.Call(ctx, "azure.DeleteNetworkInterface", map[string]string{"resourceGroup": c.ResourceGroup, "name": *iface.Name}, func() error {
+				future, err := ifClient.Delete(ctx, c.ResourceGroup, *iface.Name)
+				if err != nil {
+					return err
+				}
+
+				return future.WaitForCompletionRef(ctx, ifClient.Client)
+			})
 			if err != nil {
 				return err
 			}
-
-			if err = future.WaitForCompletionRef(ctx, ifClient.Client); err != nil {
-				return err
-			}
 		}
 	}
 
@@ -95,14 +98,17 @@ func deleteIPAddressesByMachineUID(ctx context.Context, c *config, machineUID ty
 
 	for _, ip := range allIPs {
 		if ip.Tags != nil && ip.Tags[machineUIDTag] != nil && *ip.Tags[machineUIDTag] == string(machineUID) {
-			future, err := ipClient.Delete(ctx, c.ResourceGroup, *ip.Name)
+			err := audit.Call(ctx, "azure.DeletePublicIPAddress", map[string]string{"resourceGroup": c.ResourceGroup, "name": *ip.Name}, func() error {
+				future, err := ipClient.Delete(ctx, c.ResourceGroup, *ip.Name)
+				if err != nil {
+					return err
+				}
+
+				return future.WaitForCompletionRef(ctx, ipClient.Client)
+			})
 			if err != nil {
 				return err
 			}
-
-			if err = future.WaitForCompletionRef(ctx, ipClient.Client); err != nil {
-				return err
-			}
 		}
 	}
 
@@ -132,14 +138,17 @@ func deleteVMsByMachineUID(ctx context.Context, c *config, machineUID types.UID)
 
 	for _, vm := range allServers {
 		if vm.Tags != nil && vm.Tags[machineUIDTag] != nil && *vm.Tags[machineUIDTag] == string(machineUID) {
-			future, err := vmClient.Delete(ctx, c.ResourceGroup, *vm.Name, nil)
+			err := audit.Call(ctx, "azure.DeleteVirtualMachine", map[string]string{"resourceGroup": c.ResourceGroup, "name": *vm.Name}, func() error {
+				future, err := vmClient.Delete(ctx, c.ResourceGroup, *vm.Name, nil)
+				if err != nil {
+					return err
+				}
+
+				return future.WaitForCompletionRef(ctx, vmClient.Client)
+			})
 			if err != nil {
 				return err
 			}
-
-			if err = future.WaitForCompletionRef(ctx, vmClient.Client); err != nil {
-				return err
-			}
 		}
 	}
 
@@ -158,13 +167,19 @@ func deleteDisksByMachineUID(ctx context.Context, c *config, machineUID types.UI
 	}
 
 	for _, disk := range matchingDisks {
-		future, err := disksClient.Delete(ctx, c.ResourceGroup, *disk.Name)
-		if err != nil {
-			return fmt.Errorf("failed to delete disk %s: %w", *disk.Name, err)
-		}
+		err := audit.Call(ctx, "azure.DeleteDisk", map[string]string{"resourceGroup": c.ResourceGroup, "name": *disk.Name}, func() error {
+			future, err := disksClient.Delete(ctx, c.ResourceGroup, *disk.Name)
+			if err != nil {
+				return fmt.Errorf("failed to delete disk %s: %w", *disk.Name, err)
+			}
 
-		if err = future.WaitForCompletionRef(ctx, disksClient.Client); err != nil {
-			return fmt.Errorf("failed to wait for deletion of disk %s: %w", *disk.Name, err)
+			if err = future.WaitForCompletionRef(ctx, disksClient.Client); err != nil {
+				return fmt.Errorf("failed to wait for deletion of disk %s: %w", *disk.Name, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
 		}
 	}
 
@@ -215,18 +230,25 @@ func createOrUpdatePublicIPAddress(ctx context.Context, log *zap.SugaredLogger,
 		},
 	}
 
-	future, err := ipClient.CreateOrUpdate(ctx, c.ResourceGroup, ipName, ipParams)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create public IP address: %w", err)
-	}
+	request := map[string]string{"resourceGroup": c.ResourceGroup, "name": ipName, "ipVersion": string(ipVersion), "sku": string(sku)}
+	err = audit.Call(ctx, "azure.CreatePublicIPAddress", request, func() error {
+		future, err := ipClient.CreateOrUpdate(ctx, c.ResourceGroup, ipName, ipParams)
+		if err != nil {
+			return fmt.Errorf("failed to create public IP address: %w", err)
+		}
 
-	err = future.WaitForCompletionRef(ctx, ipClient.Client)
-	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve public IP address creation result: %w", err)
-	}
+		err = future.WaitForCompletionRef(ctx, ipClient.Client)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve public IP address creation result: %w", err)
+		}
 
-	if _, err = future.Result(*ipClient); err != nil {
-		return nil, fmt.Errorf("failed to create public IP address: %w", err)
+		if _, err = future.Result(*ipClient); err != nil {
+			return fmt.Errorf("failed to create public IP address: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	log.Infow("Fetching info for IP address", "name", ipName)
@@ -365,7 +387,7 @@ func createOrUpdateNetworkInterface(ctx context.Context, log *zap.SugaredLogger,
 	ifSpec.EnableAcceleratedNetworking = enableAcceleratedNetworking
 
 	if config.SecurityGroupName != "" {
-		authorizer, err := auth.NewClientCredentialsConfig(config.ClientID, config.ClientSecret, config.TenantID).Authorizer()
+		authorizer, err := getAuthorizer(config)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create authorizer for security groups: %w", err)
 		}
@@ -378,19 +400,25 @@ func createOrUpdateNetworkInterface(ctx context.Context, log *zap.SugaredLogger,
 		ifSpec.NetworkSecurityGroup = &secGroup
 	}
 	log.Infow("Creating/Updating public network interface", "interface", ifName)
-	future, err := ifClient.CreateOrUpdate(ctx, config.ResourceGroup, ifName, ifSpec)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create interface: %w", err)
-	}
+	err = audit.Call(ctx, "azure.CreateNetworkInterface", map[string]string{"resourceGroup": config.ResourceGroup, "name": ifName}, func() error {
+		future, err := ifClient.CreateOrUpdate(ctx, config.ResourceGroup, ifName, ifSpec)
+		if err != nil {
+			return fmt.Errorf("failed to create interface: %w", err)
+		}
 
-	err = future.WaitForCompletionRef(ctx, ifClient.Client)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get interface creation response: %w", err)
-	}
+		err = future.WaitForCompletionRef(ctx, ifClient.Client)
+		if err != nil {
+			return fmt.Errorf("failed to get interface creation response: %w", err)
+		}
 
-	_, err = future.Result(*ifClient)
+		_, err = future.Result(*ifClient)
+		if err != nil {
+			return fmt.Errorf("failed to get interface creation result: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get interface creation result: %w", err)
+		return nil, err
 	}
 
 	log.Infow("Fetching info about network interface", "interface", ifName)
@@ -21,13 +21,43 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/profiles/latest/compute/mgmt/compute"
 	"github.com/Azure/azure-sdk-for-go/profiles/latest/network/mgmt/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
+
+	cloudproviderutil "k8c.io/machine-controller/pkg/cloudprovider/util"
 )
 
+// getAuthorizer returns an authorizer for the client secret or, with workload identity, for the
+// federated ServiceAccount token of the machine-controller.
+func getAuthorizer(c *config) (autorest.Authorizer, error) {
+	if !c.UseWorkloadIdentity {
+		return auth.NewClientCredentialsConfig(c.ClientID, c.ClientSecret, c.TenantID).Authorizer()
+	}
+
+	if cloudproviderutil.WorkloadIdentityTokenFile == "" {
+		return nil, cloudproviderutil.ErrWorkloadIdentityNotConfigured
+	}
+
+	oauthConfig, err := adal.NewOAuthConfig(azure.PublicCloud.ActiveDirectoryEndpoint, c.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The token is read again whenever the access token is refreshed.
+	spToken, err := adal.NewServicePrincipalTokenFromFederatedTokenCallback(*oauthConfig, c.ClientID, cloudproviderutil.WorkloadIdentityToken, azure.PublicCloud.ResourceManagerEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return autorest.NewBearerAuthorizer(spToken), nil
+}
+
 func getIPClient(c *config) (*network.PublicIPAddressesClient, error) {
 	var err error
 	ipClient := network.NewPublicIPAddressesClient(c.SubscriptionID)
-	ipClient.Authorizer, err = auth.NewClientCredentialsConfig(c.ClientID, c.ClientSecret, c.TenantID).Authorizer()
+	ipClient.Authorizer, err = getAuthorizer(c)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create authorizer: %w", err)
 	}
@@ -38,7 +68,7 @@ func getIPClient(c *config) (*network.PublicIPAddressesClient, error) {
 func getIPConfigClient(c *config) (*network.InterfaceIPConfigurationsClient, error) {
 	var err error
 	ipConfigClient := network.NewInterfaceIPConfigurationsClient(c.SubscriptionID)
-	ipConfigClient.Authorizer, err = auth.NewClientCredentialsConfig(c.ClientID, c.ClientSecret, c.TenantID).Authorizer()
+	ipConfigClient.Authorizer, err = getAuthorizer(c)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create authorizer: %w", err)
 	}
@@ -49,7 +79,7 @@ func getIPConfigClient(c *config) (*network.InterfaceIPConfigurationsClient, err
 func getSubnetsClient(c *config) (*network.SubnetsClient, error) {
 	var err error
 	subnetClient := network.NewSubnetsClient(c.SubscriptionID)
-	subnetClient.Authorizer, err = auth.NewClientCredentialsConfig(c.ClientID, c.ClientSecret, c.TenantID).Authorizer()
+	subnetClient.Authorizer, err = getAuthorizer(c)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create authorizer: %w", err)
 	}
@@ -60,7 +90,7 @@ func getSubnetsClient(c *config) (*network.SubnetsClient, error) {
 func getVirtualNetworksClient(c *config) (*network.VirtualNetworksClient, error) {
 	var err error
 	virtualNetworksClient := network.NewVirtualNetworksClient(c.SubscriptionID)
-	virtualNetworksClient.Authorizer, err = auth.NewClientCredentialsConfig(c.ClientID, c.ClientSecret, c.TenantID).Authorizer()
+	virtualNetworksClient.Authorizer, err = getAuthorizer(c)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create authorizer: %w", err)
 	}
@@ -70,7 +100,7 @@ func getVirtualNetworksClient(c *config) (*network.VirtualNetworksClient, error)
 func getVMClient(c *config) (*compute.VirtualMachinesClient, error) {
 	var err error
 	vmClient := compute.NewVirtualMachinesClient(c.SubscriptionID)
-	vmClient.Authorizer, err = auth.NewClientCredentialsConfig(c.ClientID, c.ClientSecret, c.TenantID).Authorizer()
+	vmClient.Authorizer, err = getAuthorizer(c)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create authorizer: %w", err)
 	}
@@ -81,7 +111,7 @@ func getVMClient(c *config) (*compute.VirtualMachinesClient, error) {
 func getSKUClient(c *config) (*compute.ResourceSkusClient, error) {
 	var err error
 	skuClient := compute.NewResourceSkusClient(c.SubscriptionID)
-	skuClient.Authorizer, err = auth.NewClientCredentialsConfig(c.ClientID, c.ClientSecret, c.TenantID).Authorizer()
+	skuClient.Authorizer, err = getAuthorizer(c)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create authorizer: %w", err)
 	}
@@ -92,7 +122,7 @@ func getSKUClient(c *config) (*compute.ResourceSkusClient, error) {
 func getInterfacesClient(c *config) (*network.InterfacesClient, error) {
 	var err error
 	ifClient := network.NewInterfacesClient(c.SubscriptionID)
-	ifClient.Authorizer, err = auth.NewClientCredentialsConfig(c.ClientID, c.ClientSecret, c.TenantID).Authorizer()
+	ifClient.Authorizer, err = getAuthorizer(c)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create authorizer: %w", err)
 	}
@@ -103,7 +133,7 @@ func getInterfacesClient(c *config) (*network.InterfacesClient, error) {
 func getDisksClient(c *config) (*compute.DisksClient, error) {
 	var err error
 	disksClient := compute.NewDisksClient(c.SubscriptionID)
-	disksClient.Authorizer, err = auth.NewClientCredentialsConfig(c.ClientID, c.ClientSecret, c.TenantID).Authorizer()
+	disksClient.Authorizer, err = getAuthorizer(c)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create authorizer: %w", err)
 	}
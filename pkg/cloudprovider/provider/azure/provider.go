@@ -38,6 +38,7 @@ import (
 	cloudprovidererrors "k8c.io/machine-controller/pkg/cloudprovider/errors"
 	"k8c.io/machine-controller/pkg/cloudprovider/instance"
 	cloudprovidertypes "k8c.io/machine-controller/pkg/cloudprovider/types"
+	cloudproviderutil "k8c.io/machine-controller/pkg/cloudprovider/util"
 	kuberneteshelper "k8c.io/machine-controller/pkg/kubernetes"
 	"k8c.io/machine-controller/sdk/apis/cluster/common"
 	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
@@ -47,6 +48,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 )
 
@@ -82,6 +84,8 @@ type config struct {
 	ClientID       string
 	ClientSecret   string
 
+	UseWorkloadIdentity bool
+
 	Location              string
 	ResourceGroup         string
 	VNetResourceGroup     string
@@ -254,9 +258,17 @@ func (p *provider) getConfig(provSpec clusterv1alpha1.ProviderSpec) (*config, *p
 		return nil, nil, fmt.Errorf("failed to get the value of \"clientID\" field, error = %w", err)
 	}
 
-	c.ClientSecret, err = p.configVarResolver.GetStringValueOrEnv(rawCfg.ClientSecret, envClientSecret)
+	c.UseWorkloadIdentity, _, err = p.configVarResolver.GetBoolValue(rawCfg.UseWorkloadIdentity)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get the value of \"clientSecret\" field, error = %w", err)
+		return nil, nil, fmt.Errorf("failed to get the value of \"useWorkloadIdentity\" field, error = %w", err)
+	}
+
+	// The client secret is not used with workload identity federation, so it is not read at all.
+	if !c.UseWorkloadIdentity {
+		c.ClientSecret, err = p.configVarResolver.GetStringValueOrEnv(rawCfg.ClientSecret, envClientSecret)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get the value of \"clientSecret\" field, error = %w", err)
+		}
 	}
 
 	c.ResourceGroup, err = p.configVarResolver.GetStringValue(rawCfg.ResourceGroup)
@@ -982,94 +994,96 @@ func (p *provider) get(ctx context.Context, log *zap.SugaredLogger, machine *clu
 	return &azureVM{vm: vm, ipAddresses: ipAddresses, status: status}, nil
 }
 
-func validateDiskSKUs(_ context.Context, c *config, sku compute.ResourceSku) error {
-	if c.OSDiskSKU != nil || c.DataDiskSKU != nil {
-		if c.OSDiskSKU != nil {
-			if _, ok := osDiskSKUs[*c.OSDiskSKU]; !ok {
-				return fmt.Errorf("invalid OS disk SKU '%s'", *c.OSDiskSKU)
-			}
+func validateDiskSKUs(_ context.Context, c *config, sku compute.ResourceSku, specPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
 
-			if err := supportsDiskSKU(sku, *c.OSDiskSKU, c.Zones); err != nil {
-				return err
-			}
+	if c.OSDiskSKU != nil {
+		if _, ok := osDiskSKUs[*c.OSDiskSKU]; !ok {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("osDiskSKU"), *c.OSDiskSKU, "invalid OS disk SKU"))
+		} else if err := supportsDiskSKU(sku, *c.OSDiskSKU, c.Zones); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("osDiskSKU"), *c.OSDiskSKU, err.Error()))
 		}
+	}
 
-		if c.DataDiskSKU != nil {
-			if _, ok := dataDiskSKUs[*c.DataDiskSKU]; !ok {
-				return fmt.Errorf("invalid data disk SKU '%s'", *c.DataDiskSKU)
-			}
+	if c.DataDiskSKU != nil {
+		switch _, ok := dataDiskSKUs[*c.DataDiskSKU]; {
+		case !ok:
+			allErrs = append(allErrs, field.Invalid(specPath.Child("dataDiskSKU"), *c.DataDiskSKU, "invalid data disk SKU"))
 
-			// Ultra SSDs do not support availability sets, see for reference:
-			// https://docs.microsoft.com/en-us/azure/virtual-machines/disks-enable-ultra-ssd#ga-scope-and-limitations
-			if *c.DataDiskSKU == compute.StorageAccountTypesUltraSSDLRS && ((c.AssignAvailabilitySet != nil && *c.AssignAvailabilitySet) || c.AvailabilitySet != "") {
-				return fmt.Errorf("data disk SKU '%s' does not support availability sets", *c.DataDiskSKU)
-			}
+		// Ultra SSDs do not support availability sets, see for reference:
+		// https://docs.microsoft.com/en-us/azure/virtual-machines/disks-enable-ultra-ssd#ga-scope-and-limitations
+		case *c.DataDiskSKU == compute.StorageAccountTypesUltraSSDLRS && ((c.AssignAvailabilitySet != nil && *c.AssignAvailabilitySet) || c.AvailabilitySet != ""):
+			allErrs = append(allErrs, field.Invalid(specPath.Child("dataDiskSKU"), *c.DataDiskSKU, "data disk SKU does not support availability sets"))
 
+		default:
 			if err := supportsDiskSKU(sku, *c.DataDiskSKU, c.Zones); err != nil {
-				return err
+				allErrs = append(allErrs, field.Invalid(specPath.Child("dataDiskSKU"), *c.DataDiskSKU, err.Error()))
 			}
 		}
 	}
 
-	return nil
+	return allErrs
 }
 
-func validateSKUCapabilities(_ context.Context, c *config, sku compute.ResourceSku) error {
+func validateSKUCapabilities(_ context.Context, c *config, sku compute.ResourceSku, specPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
 	if c.EnableAcceleratedNetworking != nil && *c.EnableAcceleratedNetworking {
 		if !SKUHasCapability(sku, capabilityAcceleratedNetworking) {
-			return fmt.Errorf("VM size %q does not support accelerated networking", c.VMSize)
+			allErrs = append(allErrs, field.Invalid(specPath.Child("enableAcceleratedNetworking"), true, fmt.Sprintf("VM size %q does not support accelerated networking", c.VMSize)))
 		}
 	}
-	return nil
+	return allErrs
 }
 
 func (p *provider) Validate(ctx context.Context, log *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec) error {
-	c, providerConfig, err := p.getConfig(spec.ProviderSpec)
+	allErrs, err := p.ValidateFields(ctx, log, spec, field.NewPath("spec", "providerSpec", "value"))
 	if err != nil {
-		return fmt.Errorf("failed to parse config: %w", err)
-	}
-
-	if c.SubscriptionID == "" {
-		return errors.New("subscriptionID is missing")
-	}
-
-	if c.TenantID == "" {
-		return errors.New("tenantID is missing")
-	}
-
-	if c.ClientID == "" {
-		return errors.New("clientID is missing")
-	}
-
-	if c.ClientSecret == "" {
-		return errors.New("clientSecret is missing")
-	}
-
-	if c.ResourceGroup == "" {
-		return errors.New("resourceGroup is missing")
-	}
-
-	if c.VMSize == "" {
-		return errors.New("vmSize is missing")
+		return err
 	}
+	return allErrs.ToAggregate()
+}
 
-	if c.VNetName == "" {
-		return errors.New("vnetName is missing")
+// ValidateFields validates the spec and reports all errors at once.
+func (p *provider) ValidateFields(ctx context.Context, log *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec, fldPath *field.Path) (field.ErrorList, error) {
+	c, providerConfig, err := p.getConfig(spec.ProviderSpec)
+	if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, field.OmitValueType{}, fmt.Sprintf("failed to parse config: %v", err))}, nil
+	}
+
+	allErrs := field.ErrorList{}
+	specPath := fldPath.Child("cloudProviderSpec")
+
+	for _, required := range []struct{ name, value string }{
+		{"subscriptionID", c.SubscriptionID},
+		{"tenantID", c.TenantID},
+		{"clientID", c.ClientID},
+		{"resourceGroup", c.ResourceGroup},
+		{"vmSize", c.VMSize},
+		{"vnetName", c.VNetName},
+		{"subnetName", c.SubnetName},
+	} {
+		if required.value == "" {
+			allErrs = append(allErrs, field.Required(specPath.Child(required.name), fmt.Sprintf("%s is missing", required.name)))
+		}
 	}
 
-	if c.SubnetName == "" {
-		return errors.New("subnetName is missing")
+	if c.UseWorkloadIdentity {
+		if cloudproviderutil.WorkloadIdentityTokenFile == "" {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("useWorkloadIdentity"), cloudproviderutil.ErrWorkloadIdentityNotConfigured.Error()))
+		}
+	} else if c.ClientSecret == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("clientSecret"), "clientSecret is missing"))
 	}
 
 	switch f := providerConfig.Network.GetIPFamily(); f {
 	case net.IPFamilyUnspecified, net.IPFamilyIPv4:
 		//noop
 	case net.IPFamilyIPv6:
-		return fmt.Errorf(net.ErrIPv6OnlyUnsupported)
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("network", "ipFamily"), f, net.ErrIPv6OnlyUnsupported))
 	case net.IPFamilyIPv4IPv6, net.IPFamilyIPv6IPv4:
 		// validate
 	default:
-		return fmt.Errorf(net.ErrUnknownNetworkFamily, f)
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("network", "ipFamily"), f, fmt.Sprintf(net.ErrUnknownNetworkFamily, f)))
 	}
 
 	if c.PublicIPSKU != nil {
@@ -1081,47 +1095,50 @@ func (p *provider) Validate(ctx context.Context, log *zap.SugaredLogger, spec cl
 		}
 
 		if !valid {
-			return fmt.Errorf("unknown public IP address SKU: %s", *c.PublicIPSKU)
+			allErrs = append(allErrs, field.Invalid(specPath.Child("publicIPSKU"), *c.PublicIPSKU, "unknown public IP address SKU"))
+		} else if providerConfig.Network.GetIPFamily().IsDualstack() && *c.PublicIPSKU == network.PublicIPAddressSkuNameBasic {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("publicIPSKU"), *c.PublicIPSKU, "cannot be used with dualstack"))
 		}
+	}
 
-		if providerConfig.Network.GetIPFamily().IsDualstack() && *c.PublicIPSKU == network.PublicIPAddressSkuNameBasic {
-			return fmt.Errorf("cannot use %s public IP address SKU with dualstack", network.PublicIPAddressSkuNameBasic)
-		}
+	if _, err := getOSImageReference(c, providerConfig.OperatingSystem); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("operatingSystem"), providerConfig.OperatingSystem, err.Error()))
 	}
 
-	vmClient, err := getVMClient(c)
-	if err != nil {
-		return fmt.Errorf("failed to (create) vm client: %w", err)
+	// Azure can not be queried without credentials and the referenced resources.
+	if len(allErrs) > 0 {
+		return allErrs, nil
 	}
 
-	_, err = vmClient.List(ctx, c.ResourceGroup, "")
+	vmClient, err := getVMClient(c)
 	if err != nil {
-		return fmt.Errorf("failed to list virtual machines: %w", err)
+		return nil, fmt.Errorf("failed to (create) vm client: %w", err)
 	}
 
-	if _, err := getVirtualNetwork(ctx, c); err != nil {
-		return fmt.Errorf("failed to get virtual network: %w", err)
+	if _, err := vmClient.List(ctx, c.ResourceGroup, ""); err != nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("resourceGroup"), c.ResourceGroup, fmt.Sprintf("failed to list virtual machines: %v", err)))
 	}
 
-	if _, err := getSubnet(ctx, c); err != nil {
-		return fmt.Errorf("failed to get subnet: %w", err)
+	if _, err := getVirtualNetwork(ctx, c); err != nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("vnetName"), c.VNetName, fmt.Sprintf("failed to get virtual network: %v", err)))
+	} else if _, err := getSubnet(ctx, c); err != nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("subnetName"), c.SubnetName, fmt.Sprintf("failed to get subnet: %v", err)))
 	}
 
 	sku, err := getSKU(ctx, log, c)
 	if err != nil {
-		return fmt.Errorf("failed to get VM SKU: %w", err)
+		allErrs = append(allErrs, field.Invalid(specPath.Child("vmSize"), c.VMSize, fmt.Sprintf("failed to get VM SKU: %v", err)))
+	} else {
+		allErrs = append(allErrs, validateDiskSKUs(ctx, c, sku, specPath)...)
+		allErrs = append(allErrs, validateSKUCapabilities(ctx, c, sku, specPath)...)
 	}
 
-	if err := validateDiskSKUs(ctx, c, sku); err != nil {
-		return fmt.Errorf("failed to validate disk SKUs: %w", err)
-	}
-
-	if err := validateSKUCapabilities(ctx, c, sku); err != nil {
-		return fmt.Errorf("failed to validate SKU capabilities: %w", err)
+	// Failed API calls of canceled requests are no validation errors.
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	_, err = getOSImageReference(c, providerConfig.OperatingSystem)
-	return err
+	return allErrs, nil
 }
 
 func ifaceName(machine *clusterv1alpha1.Machine) string {
@@ -544,6 +544,7 @@ func doStatusAndErrToTerminalError(status int, err error) error {
 		return cloudprovidererrors.TerminalError{
 			Reason:  common.InvalidConfigurationMachineError,
 			Message: "A request has been rejected due to invalid credentials which were taken from the MachineSpec",
+			Err:     cloudprovidererrors.ErrInvalidCredentials,
 		}
 	default:
 		return err
@@ -30,6 +30,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 type provider struct{}
@@ -90,6 +91,14 @@ func (p *provider) Validate(_ context.Context, log *zap.SugaredLogger, machinesp
 	return fmt.Errorf("failing validation as requested")
 }
 
+// ValidateFields reports a field error if the FakeCloudProviderSpec requests to fail the validation.
+func (p *provider) ValidateFields(ctx context.Context, log *zap.SugaredLogger, machinespec clusterv1alpha1.MachineSpec, fldPath *field.Path) (field.ErrorList, error) {
+	if err := p.Validate(ctx, log, machinespec); err != nil {
+		return field.ErrorList{field.Invalid(fldPath.Child("cloudProviderSpec", "passValidation"), false, err.Error())}, nil
+	}
+	return nil, nil
+}
+
 func (p *provider) Get(_ context.Context, _ *zap.SugaredLogger, _ *clusterv1alpha1.Machine, _ *cloudprovidertypes.ProviderData) (instance.Instance, error) {
 	return CloudProviderInstance{}, nil
 }
@@ -30,8 +30,10 @@ import (
 
 	"golang.org/x/oauth2"
 	googleoauth "golang.org/x/oauth2/google"
+	"golang.org/x/oauth2/google/externalaccount"
 	"google.golang.org/api/compute/v1"
 
+	cloudproviderutil "k8c.io/machine-controller/pkg/cloudprovider/util"
 	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
 	gcetypes "k8c.io/machine-controller/sdk/cloudprovider/gce"
 	"k8c.io/machine-controller/sdk/providerconfig"
@@ -114,6 +116,9 @@ type config struct {
 	minCPUPlatform               string
 	guestOSFeatures              []string
 	clientConfig                 *clientConfig
+	useWorkloadIdentity          bool
+	workloadIdentityProvider     string
+	serviceAccountEmail          string
 }
 
 type clientConfig struct {
@@ -138,19 +143,41 @@ func newConfig(resolver providerconfig.ConfigVarResolver, spec clusterv1alpha1.P
 		guestOSFeatures: cpSpec.GuestOSFeatures,
 	}
 
-	cfg.serviceAccount, err = resolver.GetStringValueOrEnv(cpSpec.ServiceAccount, envGoogleServiceAccount)
-	if err != nil {
-		return nil, fmt.Errorf("cannot retrieve service account: %w", err)
-	}
-
 	cfg.projectID, err = resolver.GetStringValue(cpSpec.ProjectID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve project id: %w", err)
 	}
 
-	err = cfg.postprocessServiceAccount()
+	cfg.useWorkloadIdentity, _, err = resolver.GetBoolValue(cpSpec.UseWorkloadIdentity)
 	if err != nil {
-		return nil, fmt.Errorf("cannot prepare JWT: %w", err)
+		return nil, fmt.Errorf("failed to retrieve use workload identity: %w", err)
+	}
+
+	if cfg.useWorkloadIdentity {
+		cfg.workloadIdentityProvider, err = resolver.GetStringValue(cpSpec.WorkloadIdentityProvider)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve workload identity provider: %w", err)
+		}
+
+		cfg.serviceAccountEmail, err = resolver.GetStringValue(cpSpec.ServiceAccountEmail)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve service account email: %w", err)
+		}
+
+		err = cfg.postprocessWorkloadIdentity()
+		if err != nil {
+			return nil, fmt.Errorf("cannot prepare workload identity: %w", err)
+		}
+	} else {
+		cfg.serviceAccount, err = resolver.GetStringValueOrEnv(cpSpec.ServiceAccount, envGoogleServiceAccount)
+		if err != nil {
+			return nil, fmt.Errorf("cannot retrieve service account: %w", err)
+		}
+
+		err = cfg.postprocessServiceAccount()
+		if err != nil {
+			return nil, fmt.Errorf("cannot prepare JWT: %w", err)
+		}
 	}
 
 	cfg.zone, err = resolver.GetStringValue(cpSpec.Zone)
@@ -284,6 +311,49 @@ func (cfg *config) postprocessServiceAccount() error {
 	return nil
 }
 
+// workloadIdentityTokenSupplier supplies the projected ServiceAccount token of the machine-controller.
+type workloadIdentityTokenSupplier struct{}
+
+func (workloadIdentityTokenSupplier) SubjectToken(_ context.Context, _ externalaccount.SupplierOptions) (string, error) {
+	return cloudproviderutil.WorkloadIdentityToken()
+}
+
+// postprocessWorkloadIdentity creates a token source which exchanges the ServiceAccount token of the
+// machine-controller for an access token of the impersonated service account. Access tokens are
+// cached until they expire and the ServiceAccount token is read again for every exchange.
+func (cfg *config) postprocessWorkloadIdentity() error {
+	if cloudproviderutil.WorkloadIdentityTokenFile == "" {
+		return cloudproviderutil.ErrWorkloadIdentityNotConfigured
+	}
+	if cfg.workloadIdentityProvider == "" {
+		return errors.New("workloadIdentityProvider must be set to use workload identity")
+	}
+	if cfg.serviceAccountEmail == "" {
+		return errors.New("serviceAccountEmail must be set to use workload identity")
+	}
+	if cfg.projectID == "" {
+		return errors.New("projectID must be set to use workload identity")
+	}
+
+	tokenSource, err := externalaccount.NewTokenSource(context.TODO(), externalaccount.Config{
+		Audience:                       cfg.workloadIdentityProvider,
+		SubjectTokenType:               "urn:ietf:params:oauth:token-type:jwt",
+		ServiceAccountImpersonationURL: fmt.Sprintf("https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/%s:generateAccessToken", cfg.serviceAccountEmail),
+		Scopes:                         []string{compute.ComputeScope},
+		SubjectTokenSupplier:           workloadIdentityTokenSupplier{},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create token source: %w", err)
+	}
+
+	cfg.clientConfig = &clientConfig{
+		ClientEmail: cfg.serviceAccountEmail,
+		TokenSource: tokenSource,
+	}
+
+	return nil
+}
+
 // machineTypeDescriptor creates the descriptor out of zone and machine type
 // for the machine type of an instance.
 func (cfg *config) machineTypeDescriptor() string {
@@ -33,6 +33,7 @@ import (
 	compute "google.golang.org/api/compute/v1"
 	"google.golang.org/api/googleapi"
 
+	"k8c.io/machine-controller/pkg/audit"
 	cloudprovidererrors "k8c.io/machine-controller/pkg/cloudprovider/errors"
 	"k8c.io/machine-controller/pkg/cloudprovider/instance"
 	cloudprovidertypes "k8c.io/machine-controller/pkg/cloudprovider/types"
@@ -42,6 +43,8 @@ import (
 	"k8c.io/machine-controller/sdk/providerconfig"
 
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 )
 
 // Terminal error messages.
@@ -101,44 +104,60 @@ func (p *Provider) AddDefaults(_ *zap.SugaredLogger, spec clusterv1alpha1.Machin
 }
 
 // Validate checks the given machine's specification.
-func (p *Provider) Validate(_ context.Context, _ *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec) error {
+func (p *Provider) Validate(ctx context.Context, log *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec) error {
+	allErrs, err := p.ValidateFields(ctx, log, spec, field.NewPath("spec", "providerSpec", "value"))
+	if err != nil {
+		return err
+	}
+	if len(allErrs) > 0 {
+		return newError(common.InvalidConfigurationMachineError, "%v", allErrs.ToAggregate())
+	}
+	return nil
+}
+
+// ValidateFields checks the given machine's specification and reports all errors at once.
+func (p *Provider) ValidateFields(_ context.Context, _ *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec, fldPath *field.Path) (field.ErrorList, error) {
 	// Read configuration.
 	cfg, err := newConfig(p.resolver, spec.ProviderSpec)
 	if err != nil {
-		return newError(common.InvalidConfigurationMachineError, errMachineSpec, err)
+		return field.ErrorList{field.Invalid(fldPath, field.OmitValueType{}, fmt.Sprintf(errMachineSpec, err))}, nil
 	}
+
+	allErrs := field.ErrorList{}
+	specPath := fldPath.Child("cloudProviderSpec")
+
 	// Check configured values.
-	if cfg.serviceAccount == "" {
-		return newError(common.InvalidConfigurationMachineError, errInvalidServiceAccount)
+	if !cfg.useWorkloadIdentity && cfg.serviceAccount == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("serviceAccount"), errInvalidServiceAccount))
 	}
 	if cfg.zone == "" {
-		return newError(common.InvalidConfigurationMachineError, errInvalidZone)
+		allErrs = append(allErrs, field.Required(specPath.Child("zone"), errInvalidZone))
 	}
 
-	switch cfg.providerConfig.Network.GetIPFamily() {
+	switch f := cfg.providerConfig.Network.GetIPFamily(); f {
 	case net.IPFamilyUnspecified, net.IPFamilyIPv4:
 		// noop
 	case net.IPFamilyIPv6:
-		return newError(common.InvalidConfigurationMachineError, net.ErrIPv6OnlyUnsupported)
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("network", "ipFamily"), f, net.ErrIPv6OnlyUnsupported))
 	case net.IPFamilyIPv4IPv6, net.IPFamilyIPv6IPv4:
 	default:
-		return newError(common.InvalidConfigurationMachineError, net.ErrUnknownNetworkFamily, cfg.providerConfig.Network.GetIPFamily())
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("network", "ipFamily"), f, fmt.Sprintf(net.ErrUnknownNetworkFamily, f)))
 	}
 
 	if cfg.machineType == "" {
-		return newError(common.InvalidConfigurationMachineError, errInvalidMachineType)
+		allErrs = append(allErrs, field.Required(specPath.Child("machineType"), errInvalidMachineType))
 	}
 	if cfg.diskSize < 1 {
-		return newError(common.InvalidConfigurationMachineError, errInvalidDiskSize)
+		allErrs = append(allErrs, field.Invalid(specPath.Child("diskSize"), cfg.diskSize, errInvalidDiskSize))
 	}
 	if !diskTypes[cfg.diskType] {
-		return newError(common.InvalidConfigurationMachineError, errInvalidDiskType)
+		allErrs = append(allErrs, field.Invalid(specPath.Child("diskType"), cfg.diskType, errInvalidDiskType))
 	}
-	_, err = cfg.sourceImageDescriptor()
-	if err != nil {
-		return newError(common.InvalidConfigurationMachineError, errOperatingSystem, cfg.providerConfig.OperatingSystem, err)
+	if _, err := cfg.sourceImageDescriptor(); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("operatingSystem"), cfg.providerConfig.OperatingSystem, fmt.Sprintf(errOperatingSystem, cfg.providerConfig.OperatingSystem, err)))
 	}
-	return nil
+
+	return allErrs, nil
 }
 
 // Get retrieves a node instance that is associated with the given machine.
@@ -263,11 +282,27 @@ func (p *Provider) Create(ctx context.Context, log *zap.SugaredLogger, machine *
 		inst.MinCpuPlatform = cfg.minCPUPlatform
 	}
 
-	op, err := svc.Instances.Insert(cfg.projectID, cfg.zone, inst).Do()
-	if err != nil {
-		return nil, newError(common.InvalidConfigurationMachineError, errInsertInstance, err)
-	}
-	err = svc.waitZoneOperation(ctx, cfg, op.Name)
+	// The boot disk and the network interface are created along with the instance.
+	insertRequest := map[string]string{
+		"project":           cfg.projectID,
+		"zone":              cfg.zone,
+		"name":              machine.Spec.Name,
+		"machineType":       cfg.machineType,
+		"diskSize":          strconv.FormatInt(cfg.diskSize, 10),
+		"diskType":          cfg.diskType,
+		"network":           cfg.network,
+		"subnetwork":        cfg.subnetwork,
+		"assignPublicIP":    strconv.FormatBool(cfg.assignPublicIPAddress),
+		"userdataBytes":     strconv.Itoa(len(userdata)),
+		"provisioningModel": ptr.Deref(cfg.provisioningModel, ""),
+	}
+	err = audit.Call(ctx, "gce.InsertInstance", insertRequest, func() error {
+		op, err := svc.Instances.Insert(cfg.projectID, cfg.zone, inst).Do()
+		if err != nil {
+			return err
+		}
+		return svc.waitZoneOperation(ctx, cfg, op.Name)
+	})
 	if err != nil {
 		return nil, newError(common.InvalidConfigurationMachineError, errInsertInstance, err)
 	}
@@ -288,17 +323,23 @@ func (p *Provider) Cleanup(ctx context.Context, _ *zap.SugaredLogger, machine *c
 		return false, newError(common.InvalidConfigurationMachineError, errConnect, err)
 	}
 	// Delete instance.
-	op, err := svc.Instances.Delete(cfg.projectID, cfg.zone, machine.Spec.Name).Do()
-	if err != nil {
-		var gerr *googleapi.Error
-		if errors.As(err, &gerr) {
-			if gerr.Code == http.StatusNotFound {
-				return true, nil
+	var gone bool
+	deleteRequest := map[string]string{"project": cfg.projectID, "zone": cfg.zone, "name": machine.Spec.Name}
+	err = audit.Call(ctx, "gce.DeleteInstance", deleteRequest, func() error {
+		op, err := svc.Instances.Delete(cfg.projectID, cfg.zone, machine.Spec.Name).Do()
+		if err != nil {
+			var gerr *googleapi.Error
+			if errors.As(err, &gerr) && gerr.Code == http.StatusNotFound {
+				gone = true
+				return nil
 			}
+			return err
 		}
-		return false, newError(common.InvalidConfigurationMachineError, errDeleteInstance, err)
+		return svc.waitZoneOperation(ctx, cfg, op.Name)
+	})
+	if gone {
+		return true, nil
 	}
-	err = svc.waitZoneOperation(ctx, cfg, op.Name)
 	if err != nil {
 		return false, newError(common.InvalidConfigurationMachineError, errDeleteInstance, err)
 	}
@@ -357,11 +398,14 @@ func (p *Provider) MigrateUID(ctx context.Context, _ *zap.SugaredLogger, machine
 		Labels:           labels,
 		LabelFingerprint: ci.LabelFingerprint,
 	}
-	op, err := svc.Instances.SetLabels(cfg.projectID, cfg.zone, inst.Name(), req).Do()
-	if err != nil {
-		return newError(common.InvalidConfigurationMachineError, errSetLabels, err)
-	}
-	err = svc.waitZoneOperation(ctx, cfg, op.Name)
+	setLabelsRequest := map[string]string{"project": cfg.projectID, "zone": cfg.zone, "name": inst.Name(), "machineUID": string(newUID)}
+	err = audit.Call(ctx, "gce.SetLabels", setLabelsRequest, func() error {
+		op, err := svc.Instances.SetLabels(cfg.projectID, cfg.zone, inst.Name(), req).Do()
+		if err != nil {
+			return err
+		}
+		return svc.waitZoneOperation(ctx, cfg, op.Name)
+	})
 	if err != nil {
 		return newError(common.InvalidConfigurationMachineError, errSetLabels, err)
 	}
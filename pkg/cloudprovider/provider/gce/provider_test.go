@@ -30,6 +30,7 @@ import (
 	"k8c.io/machine-controller/sdk/providerconfig/configvar"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	fake2 "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -177,3 +178,35 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateFields(t *testing.T) {
+	t.Setenv(envGoogleServiceAccount, testServiceAccount())
+
+	raw, err := json.Marshal(testMap(testProviderSpec()).
+		with("cloudProviderSpec.zone", "").
+		with("cloudProviderSpec.machineType", "").
+		with("cloudProviderSpec.diskType", "pd-unknown"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := New(configvar.NewResolver(context.Background(), fake2.NewClientBuilder().Build()))
+	spec := clusterv1alpha1.MachineSpec{ProviderSpec: clusterv1alpha1.ProviderSpec{Value: &runtime.RawExtension{Raw: raw}}}
+	allErrs, err := p.ValidateFields(context.Background(), zap.NewNop().Sugar(), spec, field.NewPath("spec", "providerSpec", "value"))
+	if err != nil {
+		t.Fatalf("failed to validate: %v", err)
+	}
+
+	var fields []string
+	for _, fieldErr := range allErrs {
+		fields = append(fields, fieldErr.Field)
+	}
+	expected := []string{
+		"spec.providerSpec.value.cloudProviderSpec.zone",
+		"spec.providerSpec.value.cloudProviderSpec.machineType",
+		"spec.providerSpec.value.cloudProviderSpec.diskType",
+	}
+	if strings.Join(fields, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected errors for %v, got %v", expected, allErrs)
+	}
+}
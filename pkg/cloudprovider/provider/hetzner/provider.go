@@ -38,8 +38,10 @@ import (
 	"k8c.io/machine-controller/sdk/providerconfig"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 const (
@@ -191,76 +193,96 @@ func (p *provider) getServerPlacementGroup(ctx context.Context, client *hcloud.C
 	return createdPg.PlacementGroup, nil
 }
 
-func (p *provider) Validate(ctx context.Context, _ *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec) error {
+func (p *provider) Validate(ctx context.Context, log *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec) error {
+	allErrs, err := p.ValidateFields(ctx, log, spec, field.NewPath("spec", "providerSpec", "value"))
+	if err != nil {
+		return err
+	}
+	return allErrs.ToAggregate()
+}
+
+// ValidateFields validates the spec and reports all errors at once.
+func (p *provider) ValidateFields(ctx context.Context, _ *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec, fldPath *field.Path) (field.ErrorList, error) {
 	c, pc, err := p.getConfig(spec.ProviderSpec)
 	if err != nil {
-		return fmt.Errorf("failed to parse config: %w", err)
+		return field.ErrorList{field.Invalid(fldPath, field.OmitValueType{}, fmt.Sprintf("failed to parse config: %v", err))}, nil
 	}
 
+	allErrs := field.ErrorList{}
+	specPath := fldPath.Child("cloudProviderSpec")
+
 	if c.Token == "" {
-		return errors.New("token is missing")
+		return append(allErrs, field.Required(specPath.Child("token"), "token is missing")), nil
 	}
 
 	client := getClient(c.Token)
 
 	if c.Location != "" && c.Datacenter != "" {
-		return fmt.Errorf("location and datacenter must not be set at the same time")
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("datacenter"), "location and datacenter must not be set at the same time"))
 	}
 
 	if c.Location != "" {
 		if _, _, err = client.Location.Get(ctx, c.Location); err != nil {
-			return fmt.Errorf("failed to get location: %w", err)
+			allErrs = append(allErrs, field.Invalid(specPath.Child("location"), c.Location, fmt.Sprintf("failed to get location: %v", err)))
 		}
 	}
 
 	if c.Datacenter != "" {
 		if _, _, err = client.Datacenter.Get(ctx, c.Datacenter); err != nil {
-			return fmt.Errorf("failed to get datacenter: %w", err)
+			allErrs = append(allErrs, field.Invalid(specPath.Child("datacenter"), c.Datacenter, fmt.Sprintf("failed to get datacenter: %v", err)))
 		}
 	}
 
 	serverType, _, err := client.ServerType.Get(ctx, c.ServerType)
-	if err != nil {
-		return fmt.Errorf("failed to get server type: %w", err)
-	}
-
-	if serverType == nil {
-		return fmt.Errorf("server type %q not found", c.ServerType)
+	switch {
+	case err != nil:
+		allErrs = append(allErrs, field.Invalid(specPath.Child("serverType"), c.ServerType, fmt.Sprintf("failed to get server type: %v", err)))
+	case serverType == nil:
+		allErrs = append(allErrs, field.NotFound(specPath.Child("serverType"), c.ServerType))
 	}
 
 	image := c.Image
+	imagePath := specPath.Child("image")
 	if image == "" {
-		image, err = getNameForOS(pc.OperatingSystem)
-		if err != nil {
-			return fmt.Errorf("invalid/not supported operating system specified %q: %w", pc.OperatingSystem, err)
+		imagePath = fldPath.Child("operatingSystem")
+		if image, err = getNameForOS(pc.OperatingSystem); err != nil {
+			allErrs = append(allErrs, field.Invalid(imagePath, pc.OperatingSystem, fmt.Sprintf("invalid/not supported operating system: %v", err)))
 		}
 	}
 
-	if _, _, err = client.Image.GetForArchitecture(ctx, image, serverType.Architecture); err != nil {
-		return fmt.Errorf("failed to get image: %w", err)
+	// The architecture of the image depends on the server type.
+	if image != "" && serverType != nil {
+		if _, _, err = client.Image.GetForArchitecture(ctx, image, serverType.Architecture); err != nil {
+			allErrs = append(allErrs, field.Invalid(imagePath, image, fmt.Sprintf("failed to get image: %v", err)))
+		}
 	}
 
-	for _, network := range c.Networks {
+	for i, network := range c.Networks {
 		if _, _, err = client.Network.Get(ctx, network); err != nil {
-			return fmt.Errorf("failed to get network %q: %w", network, err)
+			allErrs = append(allErrs, field.Invalid(specPath.Child("networks").Index(i), network, fmt.Sprintf("failed to get network: %v", err)))
 		}
 	}
 
-	for _, firewall := range c.Firewalls {
+	for i, firewall := range c.Firewalls {
 		f, _, err := client.Firewall.Get(ctx, firewall)
-		if err != nil {
-			return fmt.Errorf("failed to get firewall %q: %w", firewall, err)
-		}
-		if f == nil {
-			return fmt.Errorf("firewall %q does not exist", firewall)
+		switch {
+		case err != nil:
+			allErrs = append(allErrs, field.Invalid(specPath.Child("firewalls").Index(i), firewall, fmt.Sprintf("failed to get firewall: %v", err)))
+		case f == nil:
+			allErrs = append(allErrs, field.NotFound(specPath.Child("firewalls").Index(i), firewall))
 		}
 	}
 
 	if !c.AssignIPv4 && !c.AssignIPv6 && len(c.Networks) < 1 {
-		return errors.New("server should have either a public ipv4, ipv6 or dedicated network")
+		allErrs = append(allErrs, field.Required(specPath.Child("networks"), "server should have either a public ipv4, ipv6 or dedicated network"))
 	}
 
-	return nil
+	// Failed API calls of canceled requests are no validation errors.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return allErrs, nil
 }
 
 func (p *provider) Create(ctx context.Context, log *zap.SugaredLogger, machine *clusterv1alpha1.Machine, _ *cloudprovidertypes.ProviderData, userdata string) (instance.Instance, error) {
@@ -539,6 +561,29 @@ func (p *provider) MachineMetricsLabels(machine *clusterv1alpha1.Machine) (map[s
 	return labels, err
 }
 
+// MachineCapacity returns the cores, memory and disk size of the machine's server type.
+func (p *provider) MachineCapacity(ctx context.Context, _ *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec) (*cloudprovidertypes.MachineCapacity, error) {
+	c, _, err := p.getConfig(spec.ProviderSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	client := getClient(c.Token)
+	serverType, _, err := client.ServerType.Get(ctx, c.ServerType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server type: %w", err)
+	}
+	if serverType == nil {
+		return nil, fmt.Errorf("server type %q not found", c.ServerType)
+	}
+
+	return &cloudprovidertypes.MachineCapacity{
+		CPU:              resource.NewQuantity(int64(serverType.Cores), resource.DecimalSI),
+		Memory:           resource.NewQuantity(int64(serverType.Memory*1024)*1024*1024, resource.BinarySI),
+		EphemeralStorage: resource.NewQuantity(int64(serverType.Disk)*1024*1024*1024, resource.BinarySI),
+	}, nil
+}
+
 type hetznerServer struct {
 	server *hcloud.Server
 }
@@ -603,6 +648,7 @@ func hzErrorToTerminalError(err error, msg string) error {
 			return cloudprovidererrors.TerminalError{
 				Reason:  common.InvalidConfigurationMachineError,
 				Message: "A request has been rejected due to invalid credentials which were taken from the MachineSpec",
+				Err:     cloudprovidererrors.ErrInvalidCredentials,
 			}
 		}
 
@@ -23,12 +23,14 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"go.uber.org/zap"
 	kubevirtcorev1 "kubevirt.io/api/core/v1"
+	kubevirtinstancetypev1beta1 "kubevirt.io/api/instancetype/v1beta1"
 	cdicorev1beta1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 
 	cloudprovidererrors "k8c.io/machine-controller/pkg/cloudprovider/errors"
@@ -46,6 +48,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -60,6 +63,9 @@ func init() {
 	if err := cdicorev1beta1.AddToScheme(scheme.Scheme); err != nil {
 		panic(fmt.Sprintf("failed to add cdiv1beta1 to scheme: %v", err))
 	}
+	if err := kubevirtinstancetypev1beta1.AddToScheme(scheme.Scheme); err != nil {
+		panic(fmt.Sprintf("failed to add instancetypev1beta1 to scheme: %v", err))
+	}
 }
 
 type imageSource string
@@ -639,53 +645,76 @@ func (p *provider) MigrateUID(_ context.Context, _ *zap.SugaredLogger, _ *cluste
 	return nil
 }
 
-func (p *provider) Validate(ctx context.Context, _ *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec) error {
+func (p *provider) Validate(ctx context.Context, log *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec) error {
+	allErrs, err := p.ValidateFields(ctx, log, spec, field.NewPath("spec", "providerSpec", "value"))
+	if err != nil {
+		return err
+	}
+	return allErrs.ToAggregate()
+}
+
+// ValidateFields validates the spec and reports all errors at once.
+func (p *provider) ValidateFields(ctx context.Context, _ *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec, fldPath *field.Path) (field.ErrorList, error) {
 	c, pc, err := p.getConfig(spec.ProviderSpec)
 	if err != nil {
-		return fmt.Errorf("failed to parse config: %w", err)
+		return field.ErrorList{field.Invalid(fldPath, field.OmitValueType{}, fmt.Sprintf("failed to parse config: %v", err))}, nil
 	}
+
+	allErrs := field.ErrorList{}
+	vmPath := fldPath.Child("cloudProviderSpec", "virtualMachine")
+
 	// If instancetype is specified, skip CPU and Memory validation.
 	// Values will come from instancetype.
 	if c.Instancetype == nil {
-		if c.Resources == nil {
-			return errors.New("no resource requests set for the virtual machine")
-		}
-
-		if c.VCPUs == nil && c.Resources.Cpu().IsZero() {
-			return errors.New("no CPUs configured. Either vCPUs or CPUs have to be set")
-		}
-
-		if c.VCPUs != nil && !c.Resources.Cpu().IsZero() {
-			return errors.New("vCPUs and CPUs cannot be configured at the same time")
+		templatePath := vmPath.Child("template")
+		switch {
+		case c.Resources == nil:
+			allErrs = append(allErrs, field.Required(templatePath.Child("memory"), "no resource requests set for the virtual machine"))
+		case c.VCPUs == nil && c.Resources.Cpu().IsZero():
+			allErrs = append(allErrs, field.Required(templatePath.Child("cpus"), "no CPUs configured. Either vCPUs or CPUs have to be set"))
+		case c.VCPUs != nil && !c.Resources.Cpu().IsZero():
+			allErrs = append(allErrs, field.Forbidden(templatePath.Child("vcpus"), "vCPUs and CPUs cannot be configured at the same time"))
 		}
 	}
 
-	sigClient, err := ctrlruntimeclient.New(c.RestConfig, ctrlruntimeclient.Options{})
-	if err != nil {
-		return fmt.Errorf("failed to get kubevirt client: %w", err)
-	}
 	if _, ok := kubevirttypes.SupportedOS[pc.OperatingSystem]; !ok {
-		return fmt.Errorf("invalid/not supported operating system specified %q: %w", pc.OperatingSystem, providerconfig.ErrOSNotSupported)
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("operatingSystem"), pc.OperatingSystem, sortedSupportedOS()))
 	}
 	if c.DNSPolicy == corev1.DNSNone {
 		if c.DNSConfig == nil || len(c.DNSConfig.Nameservers) == 0 {
-			return errors.New("dns config must be specified when dns policy is None")
+			allErrs = append(allErrs, field.Required(vmPath.Child("dnsConfig", "nameservers"), "dns config must be specified when dns policy is None"))
 		}
 	}
-	// Check if we can reach the API of the target cluster.
-	vmi := &kubevirtcorev1.VirtualMachineInstance{}
-	if err := sigClient.Get(ctx, types.NamespacedName{Namespace: c.Namespace, Name: "not-expected-to-exist"}, vmi); err != nil && !apierrors.IsNotFound(err) {
-		return fmt.Errorf("failed to request VirtualMachineInstances: %w", err)
-	}
 
 	if c.EvictionStrategy != "" {
 		if c.EvictionStrategy != kubevirtcorev1.EvictionStrategyExternal &&
 			c.EvictionStrategy != kubevirtcorev1.EvictionStrategyLiveMigrate {
-			return fmt.Errorf("unsupported vm eviction strategy: %s", c.EvictionStrategy)
+			allErrs = append(allErrs, field.NotSupported(vmPath.Child("evictionStrategy"), c.EvictionStrategy,
+				[]kubevirtcorev1.EvictionStrategy{kubevirtcorev1.EvictionStrategyExternal, kubevirtcorev1.EvictionStrategyLiveMigrate}))
 		}
 	}
 
-	return nil
+	sigClient, err := ctrlruntimeclient.New(c.RestConfig, ctrlruntimeclient.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubevirt client: %w", err)
+	}
+	// Check if we can reach the API of the target cluster.
+	vmi := &kubevirtcorev1.VirtualMachineInstance{}
+	if err := sigClient.Get(ctx, types.NamespacedName{Namespace: c.Namespace, Name: "not-expected-to-exist"}, vmi); err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to request VirtualMachineInstances: %w", err)
+	}
+
+	return allErrs, nil
+}
+
+// sortedSupportedOS returns the operating systems supported by KubeVirt.
+func sortedSupportedOS() []providerconfig.OperatingSystem {
+	supported := make([]providerconfig.OperatingSystem, 0, len(kubevirttypes.SupportedOS))
+	for operatingSystem := range kubevirttypes.SupportedOS {
+		supported = append(supported, operatingSystem)
+	}
+	slices.Sort(supported)
+	return supported
 }
 
 func (p *provider) AddDefaults(_ *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec) (clusterv1alpha1.MachineSpec, error) {
@@ -943,6 +972,61 @@ func parseResources(cpus, memory string, vpcus kubevirttypes.VCPUs) (*corev1.Res
 	}, nil, nil
 }
 
+// MachineCapacity returns the CPUs, memory and GPUs of the virtual machine, taken either from its
+// instancetype or from its template, and the size of its primary disk.
+func (p *provider) MachineCapacity(ctx context.Context, _ *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec) (*cloudprovidertypes.MachineCapacity, error) {
+	c, _, err := p.getConfig(spec.ProviderSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	capacity := &cloudprovidertypes.MachineCapacity{
+		EphemeralStorage: ptr.To(c.PVCSize),
+	}
+
+	if c.Instancetype == nil {
+		if cpu, ok := (*c.Resources)[corev1.ResourceCPU]; ok {
+			capacity.CPU = ptr.To(cpu)
+		}
+		if c.VCPUs != nil {
+			capacity.CPU = resource.NewQuantity(int64(c.VCPUs.Cores), resource.DecimalSI)
+		}
+		if memory, ok := (*c.Resources)[corev1.ResourceMemory]; ok {
+			capacity.Memory = ptr.To(memory)
+		}
+		return capacity, nil
+	}
+
+	sigClient, err := ctrlruntimeclient.New(c.RestConfig, ctrlruntimeclient.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubevirt client: %w", err)
+	}
+
+	var instancetypeSpec kubevirtinstancetypev1beta1.VirtualMachineInstancetypeSpec
+	if strings.EqualFold(c.Instancetype.Kind, "VirtualMachineInstancetype") {
+		instancetype := &kubevirtinstancetypev1beta1.VirtualMachineInstancetype{}
+		if err := sigClient.Get(ctx, types.NamespacedName{Namespace: c.Namespace, Name: c.Instancetype.Name}, instancetype); err != nil {
+			return nil, fmt.Errorf("failed to get instancetype %q: %w", c.Instancetype.Name, err)
+		}
+		instancetypeSpec = instancetype.Spec
+	} else {
+		instancetype := &kubevirtinstancetypev1beta1.VirtualMachineClusterInstancetype{}
+		if err := sigClient.Get(ctx, types.NamespacedName{Name: c.Instancetype.Name}, instancetype); err != nil {
+			return nil, fmt.Errorf("failed to get cluster instancetype %q: %w", c.Instancetype.Name, err)
+		}
+		instancetypeSpec = instancetype.Spec
+	}
+
+	capacity.CPU = resource.NewQuantity(int64(instancetypeSpec.CPU.Guest), resource.DecimalSI)
+	capacity.Memory = ptr.To(instancetypeSpec.Memory.Guest)
+	capacity.GPUCount = int64(len(instancetypeSpec.GPUs))
+	if len(instancetypeSpec.GPUs) > 0 {
+		capacity.GPUType = instancetypeSpec.GPUs[0].DeviceName
+	}
+
+	return capacity, nil
+}
+
 func (p *provider) SetMetricsForMachines(_ clusterv1alpha1.MachineList) error {
 	return nil
 }
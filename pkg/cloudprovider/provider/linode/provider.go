@@ -439,6 +439,7 @@ func linodeStatusAndErrToTerminalError(err error) error {
 		return cloudprovidererrors.TerminalError{
 			Reason:  common.InvalidConfigurationMachineError,
 			Message: "A request has been rejected due to invalid credentials which were taken from the MachineSpec",
+			Err:     cloudprovidererrors.ErrInvalidCredentials,
 		}
 	default:
 		return err
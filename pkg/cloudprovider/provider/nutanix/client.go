@@ -512,6 +512,7 @@ func wrapNutanixError(initialErr error) error {
 			return cloudprovidererrors.TerminalError{
 				Reason:  common.InvalidConfigurationMachineError,
 				Message: initialErr.Error(),
+				Err:     cloudprovidererrors.ErrInvalidCredentials,
 			}
 		}
 
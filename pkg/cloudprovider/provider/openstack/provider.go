@@ -36,6 +36,7 @@ import (
 	"github.com/gophercloud/gophercloud/pagination"
 	"go.uber.org/zap"
 
+	"k8c.io/machine-controller/pkg/audit"
 	cloudprovidererrors "k8c.io/machine-controller/pkg/cloudprovider/errors"
 	"k8c.io/machine-controller/pkg/cloudprovider/instance"
 	cloudprovidertypes "k8c.io/machine-controller/pkg/cloudprovider/types"
@@ -50,6 +51,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apimachinery/pkg/util/wait"
 )
 
@@ -90,6 +92,9 @@ type Config struct {
 	ProjectName                 string
 	ProjectID                   string
 	TokenID                     string
+	UseWorkloadIdentity         bool
+	IdentityProvider            string
+	Protocol                    string
 	Region                      string
 	ComputeAPIVersion           string
 
@@ -119,6 +124,8 @@ const (
 	machineUIDMetaKey = "machine-uid"
 	securityGroupName = "kubernetes-v1"
 	ovhAuthURL        = "auth.cloud.ovh.net"
+
+	defaultFederationProtocol = "openid"
 )
 
 // Protects floating ip assignment.
@@ -148,6 +155,13 @@ func (p *provider) getProjectIDOrTenantID(rawConfig *openstacktypes.RawConfig) (
 
 func (p *provider) getConfigAuth(c *Config, rawConfig *openstacktypes.RawConfig) error {
 	var err error
+	c.UseWorkloadIdentity, _, err = p.configVarResolver.GetBoolValue(rawConfig.UseWorkloadIdentity)
+	if err != nil {
+		return fmt.Errorf("failed to get the value of \"useWorkloadIdentity\" field, error = %w", err)
+	}
+	if c.UseWorkloadIdentity {
+		return p.getConfigWorkloadIdentity(c, rawConfig)
+	}
 	c.ApplicationCredentialID, err = p.configVarResolver.GetStringValueOrEnv(rawConfig.ApplicationCredentialID, "OS_APPLICATION_CREDENTIAL_ID")
 	if err != nil {
 		return fmt.Errorf("failed to get the value of \"applicationCredentialID\" field, error = %w", err)
@@ -178,6 +192,30 @@ func (p *provider) getConfigAuth(c *Config, rawConfig *openstacktypes.RawConfig)
 	return nil
 }
 
+func (p *provider) getConfigWorkloadIdentity(c *Config, rawConfig *openstacktypes.RawConfig) error {
+	var err error
+	c.IdentityProvider, err = p.configVarResolver.GetStringValueOrEnv(rawConfig.IdentityProvider, "OS_IDENTITY_PROVIDER")
+	if err != nil {
+		return fmt.Errorf("failed to get the value of \"identityProvider\" field, error = %w", err)
+	}
+	c.Protocol, err = p.configVarResolver.GetStringValueOrEnv(rawConfig.Protocol, "OS_PROTOCOL")
+	if err != nil {
+		return fmt.Errorf("failed to get the value of \"protocol\" field, error = %w", err)
+	}
+	if c.Protocol == "" {
+		c.Protocol = defaultFederationProtocol
+	}
+	c.ProjectName, err = p.getProjectNameOrTenantName(rawConfig)
+	if err != nil {
+		return fmt.Errorf("failed to get the value of \"projectName\" field or fallback to \"tenantName\" field, error = %w", err)
+	}
+	c.ProjectID, err = p.getProjectIDOrTenantID(rawConfig)
+	if err != nil {
+		return fmt.Errorf("failed to get the value of \"projectID\" or fallback to\"tenantID\" field, error = %w", err)
+	}
+	return nil
+}
+
 func (p *provider) resolveNetworks(cfg *Config) ([]string, error) {
 	if len(cfg.Networks) > 0 {
 		networks := make([]string, 0, len(cfg.Networks)+1)
@@ -380,10 +418,56 @@ func getClient(c *Config) (*gophercloud.ProviderClient, error) {
 		}.New()
 	}
 
+	if c.UseWorkloadIdentity {
+		opts, err = getWorkloadIdentityAuthOptions(pc, c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	err = goopenstack.Authenticate(pc, opts)
 	return pc, err
 }
 
+// getWorkloadIdentityAuthOptions exchanges the projected ServiceAccount token of the machine-controller
+// for an unscoped Keystone token with OpenID Connect federation and returns the options to scope it
+// to the project. As a client is created for every operation, the tokens do not need to be refreshed.
+func getWorkloadIdentityAuthOptions(pc *gophercloud.ProviderClient, c *Config) (gophercloud.AuthOptions, error) {
+	token, err := cloudproviderutil.WorkloadIdentityToken()
+	if err != nil {
+		return gophercloud.AuthOptions{}, err
+	}
+
+	identityClient, err := goopenstack.NewIdentityV3(pc, gophercloud.EndpointOpts{})
+	if err != nil {
+		return gophercloud.AuthOptions{}, err
+	}
+
+	resp, err := identityClient.Post(identityClient.ServiceURL("OS-FEDERATION", "identity_providers", c.IdentityProvider, "protocols", c.Protocol, "auth"), nil, nil, &gophercloud.RequestOpts{
+		MoreHeaders: map[string]string{"Authorization": "Bearer " + token},
+		OkCodes:     []int{201},
+	})
+	if err != nil {
+		return gophercloud.AuthOptions{}, fmt.Errorf("failed to exchange workload identity token: %w", err)
+	}
+
+	unscopedToken := resp.Header.Get("X-Subject-Token")
+	if unscopedToken == "" {
+		return gophercloud.AuthOptions{}, errors.New("failed to exchange workload identity token: response has no token")
+	}
+
+	scope := &gophercloud.AuthScope{ProjectID: c.ProjectID}
+	if c.ProjectID == "" {
+		scope = &gophercloud.AuthScope{ProjectName: c.ProjectName, DomainName: c.DomainName}
+	}
+
+	return gophercloud.AuthOptions{
+		IdentityEndpoint: c.IdentityEndpoint,
+		TokenID:          unscopedToken,
+		Scope:            scope,
+	}, nil
+}
+
 func (p *provider) AddDefaults(log *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec) (clusterv1alpha1.MachineSpec, error) {
 	c, _, rawConfig, err := p.getConfig(spec.ProviderSpec)
 	if err != nil {
@@ -482,126 +566,162 @@ func (p *provider) AddDefaults(log *zap.SugaredLogger, spec clusterv1alpha1.Mach
 	return spec, nil
 }
 
-func (p *provider) Validate(_ context.Context, _ *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec) error {
+func (p *provider) Validate(ctx context.Context, log *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec) error {
+	allErrs, err := p.ValidateFields(ctx, log, spec, field.NewPath("spec", "providerSpec", "value"))
+	if err != nil {
+		return err
+	}
+	return allErrs.ToAggregate()
+}
+
+// ValidateFields validates the spec and reports all errors at once.
+func (p *provider) ValidateFields(ctx context.Context, _ *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec, fldPath *field.Path) (field.ErrorList, error) {
 	c, _, _, err := p.getConfig(spec.ProviderSpec)
 	if err != nil {
-		return fmt.Errorf("failed to parse config: %w", err)
+		return field.ErrorList{field.Invalid(fldPath, field.OmitValueType{}, fmt.Sprintf("failed to parse config: %v", err))}, nil
 	}
 
-	if c.ApplicationCredentialID == "" {
+	allErrs := field.ErrorList{}
+	specPath := fldPath.Child("cloudProviderSpec")
+
+	if c.UseWorkloadIdentity {
+		if cloudproviderutil.WorkloadIdentityTokenFile == "" {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("useWorkloadIdentity"), cloudproviderutil.ErrWorkloadIdentityNotConfigured.Error()))
+		}
+
+		if c.IdentityProvider == "" {
+			allErrs = append(allErrs, field.Required(specPath.Child("identityProvider"), "identityProvider must be configured to use workload identity"))
+		}
+
+		if c.ProjectID == "" && c.ProjectName == "" {
+			allErrs = append(allErrs, field.Required(specPath.Child("projectID"), "either projectID / tenantID or projectName / tenantName must be configured"))
+		}
+		if c.ProjectID == "" && c.DomainName == "" {
+			allErrs = append(allErrs, field.Required(specPath.Child("domainName"), "domainName must be configured"))
+		}
+	} else if c.ApplicationCredentialID == "" {
 		if c.Username == "" {
-			return errors.New("username must be configured")
+			allErrs = append(allErrs, field.Required(specPath.Child("username"), "username must be configured"))
 		}
 
 		if c.Password == "" {
-			return errors.New("password must be configured")
+			allErrs = append(allErrs, field.Required(specPath.Child("password"), "password must be configured"))
 		}
 
 		if c.ProjectID == "" && c.ProjectName == "" {
-			return errors.New("either projectID / tenantID or projectName / tenantName must be configured")
+			allErrs = append(allErrs, field.Required(specPath.Child("projectID"), "either projectID / tenantID or projectName / tenantName must be configured"))
 		}
 		if c.DomainName == "" {
-			return errors.New("domainName must be configured")
+			allErrs = append(allErrs, field.Required(specPath.Child("domainName"), "domainName must be configured"))
 		}
 	} else {
 		if c.ApplicationCredentialSecret == "" {
-			return errors.New("applicationCredentialSecret must be configured in conjunction with applicationCredentialID")
+			allErrs = append(allErrs, field.Required(specPath.Child("applicationCredentialSecret"), "applicationCredentialSecret must be configured in conjunction with applicationCredentialID"))
 		}
 	}
 
 	if c.Image == "" {
-		return errors.New("image must be configured")
+		allErrs = append(allErrs, field.Required(specPath.Child("image"), "image must be configured"))
 	}
 
 	if c.Flavor == "" {
-		return errors.New("flavor must be configured")
+		allErrs = append(allErrs, field.Required(specPath.Child("flavor"), "flavor must be configured"))
+	}
+
+	// validate reserved tags.
+	if _, ok := c.Tags[machineUIDMetaKey]; ok {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("tags").Key(machineUIDMetaKey), "the tag is reserved, choose a different one"))
+	}
+
+	networks, err := p.resolveNetworks(c)
+	if err != nil {
+		allErrs = append(allErrs, field.Required(specPath.Child("networks"), err.Error()))
+	}
+
+	// The cloud can not be queried without credentials.
+	if len(allErrs) > 0 {
+		return allErrs, nil
 	}
 
 	client, err := p.clientGetter(c)
 	if err != nil {
-		return fmt.Errorf("failed to get a openstack client: %w", err)
+		return nil, fmt.Errorf("failed to get a openstack client: %w", err)
 	}
 
 	// Required fields.
 	if !strings.Contains(c.IdentityEndpoint, ovhAuthURL) {
 		if _, err := getRegion(client, c.Region); err != nil {
-			return fmt.Errorf("failed to get region %q: %w", c.Region, err)
+			allErrs = append(allErrs, field.Invalid(specPath.Child("region"), c.Region, fmt.Sprintf("failed to get region: %v", err)))
 		}
 	}
 
 	// Get OS Compute Client
 	computeClient, err := getNewComputeV2(client, c)
 	if err != nil {
-		return fmt.Errorf("failed to get compute client: %w", err)
+		return nil, fmt.Errorf("failed to get compute client: %w", err)
 	}
 
 	// Get OS Image Client.
 	imageClient, err := goopenstack.NewImageServiceV2(client, gophercloud.EndpointOpts{Region: c.Region})
 	if err != nil {
-		return fmt.Errorf("failed to get image client: %w", err)
+		return nil, fmt.Errorf("failed to get image client: %w", err)
 	}
 
 	image, err := getImageByName(imageClient, c)
 	if err != nil {
-		return fmt.Errorf("failed to get image %q: %w", c.Image, err)
-	}
-	if c.RootDiskSizeGB != nil {
-		if *c.RootDiskSizeGB < image.MinDiskGigabytes {
-			return fmt.Errorf("rootDiskSize %d is smaller than minimum disk size for image %q(%d)",
-				*c.RootDiskSizeGB, image.Name, image.MinDiskGigabytes)
-		}
+		allErrs = append(allErrs, field.Invalid(specPath.Child("image"), c.Image, fmt.Sprintf("failed to get image: %v", err)))
+	} else if c.RootDiskSizeGB != nil && *c.RootDiskSizeGB < image.MinDiskGigabytes {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("rootDiskSizeGB"), *c.RootDiskSizeGB,
+			fmt.Sprintf("smaller than minimum disk size for image %q(%d)", image.Name, image.MinDiskGigabytes)))
 	}
 
 	if _, err := getFlavor(computeClient, c); err != nil {
-		return fmt.Errorf("failed to get flavor %q: %w", c.Flavor, err)
+		allErrs = append(allErrs, field.Invalid(specPath.Child("flavor"), c.Flavor, fmt.Sprintf("failed to get flavor: %v", err)))
 	}
 
 	netClient, err := goopenstack.NewNetworkV2(client, gophercloud.EndpointOpts{Region: c.Region})
 	if err != nil {
-		return err
-	}
-
-	networks, err := p.resolveNetworks(c)
-	if err != nil {
-		return err
+		return nil, fmt.Errorf("failed to get network client: %w", err)
 	}
 
 	// Validate each network exists
 	for _, networkName := range networks {
 		if _, err := getNetwork(netClient, networkName); err != nil {
-			return fmt.Errorf("failed to get network %q: %w", networkName, err)
+			networkPath := specPath.Child("network")
+			if networkName != c.Network {
+				networkPath = specPath.Child("networks")
+			}
+			allErrs = append(allErrs, field.Invalid(networkPath, networkName, fmt.Sprintf("failed to get network: %v", err)))
 		}
 	}
 
 	if _, err := getSubnet(netClient, c.Subnet); err != nil {
-		return fmt.Errorf("failed to get subnet %q: %w", c.Subnet, err)
+		allErrs = append(allErrs, field.Invalid(specPath.Child("subnet"), c.Subnet, fmt.Sprintf("failed to get subnet: %v", err)))
 	}
 
 	if c.FloatingIPPool != "" {
 		if _, err := getNetwork(netClient, c.FloatingIPPool); err != nil {
-			return fmt.Errorf("failed to get floating ip pool %q: %w", c.FloatingIPPool, err)
+			allErrs = append(allErrs, field.Invalid(specPath.Child("floatingIpPool"), c.FloatingIPPool, fmt.Sprintf("failed to get floating ip pool: %v", err)))
 		}
 	}
 
 	if _, err := getAvailabilityZone(computeClient, c); err != nil {
-		return fmt.Errorf("failed to get availability zone %q: %w", c.AvailabilityZone, err)
+		allErrs = append(allErrs, field.Invalid(specPath.Child("availabilityZone"), c.AvailabilityZone, fmt.Sprintf("failed to get availability zone: %v", err)))
 	}
 
 	// Optional fields.
-	if len(c.SecurityGroups) != 0 {
-		for _, s := range c.SecurityGroups {
-			if _, err := getSecurityGroup(client, c.Region, s); err != nil {
-				return fmt.Errorf("failed to get security group %q: %w", s, err)
-			}
+	for i, s := range c.SecurityGroups {
+		if _, err := getSecurityGroup(client, c.Region, s); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("securityGroups").Index(i), s, fmt.Sprintf("failed to get security group: %v", err)))
 		}
 	}
 
-	// validate reserved tags.
-	if _, ok := c.Tags[machineUIDMetaKey]; ok {
-		return fmt.Errorf("the tag with the given name =%s is reserved, choose a different one", machineUIDMetaKey)
+	// Failed API calls of canceled requests are no validation errors.
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	return nil
+	return allErrs, nil
 }
 
 func (p *provider) Create(ctx context.Context, log *zap.SugaredLogger, machine *clusterv1alpha1.Machine, data *cloudprovidertypes.ProviderData, userdata string) (instance.Instance, error) {
@@ -747,7 +867,7 @@ func (p *provider) Create(ctx context.Context, log *zap.SugaredLogger, machine *
 		}
 
 		// Find a free FloatingIP or allocate a new one.
-		if err := assignFloatingIPToInstance(instanceLog, data.Update, machine, netClient, server.ID, cfg.FloatingIPPool, cfg.Region, primaryNetwork); err != nil {
+		if err := assignFloatingIPToInstance(ctx, instanceLog, data.Update, machine, netClient, server.ID, cfg.FloatingIPPool, cfg.Region, primaryNetwork); err != nil {
 			defer deleteInstanceDueToFatalLogged(instanceLog, computeClient, server.ID)
 			return nil, fmt.Errorf("failed to assign a floating ip to instance %s: %w", server.ID, err)
 		}
@@ -807,7 +927,7 @@ func (p *provider) Cleanup(ctx context.Context, log *zap.SugaredLogger, machine
 	if err != nil {
 		if errors.Is(err, cloudprovidererrors.ErrInstanceNotFound) {
 			if hasFloatingIPReleaseFinalizer {
-				if err := p.cleanupFloatingIP(log, machine, data.Update); err != nil {
+				if err := p.cleanupFloatingIP(ctx, log, machine, data.Update); err != nil {
 					return false, fmt.Errorf("failed to clean up floating ip: %w", err)
 				}
 			}
@@ -839,7 +959,7 @@ func (p *provider) Cleanup(ctx context.Context, log *zap.SugaredLogger, machine
 	}
 
 	if hasFloatingIPReleaseFinalizer {
-		return false, p.cleanupFloatingIP(log, machine, data.Update)
+		return false, p.cleanupFloatingIP(ctx, log, machine, data.Update)
 	}
 
 	return false, nil
@@ -1006,6 +1126,7 @@ func osErrorToTerminalError(log *zap.SugaredLogger, err error, msg string) error
 		return cloudprovidererrors.TerminalError{
 			Reason:  common.InvalidConfigurationMachineError,
 			Message: fmt.Sprintf("A request has been rejected due to invalid credentials which were taken from the MachineSpec: %v", errUnauthorized),
+			Err:     cloudprovidererrors.ErrInvalidCredentials,
 		}
 	}
 
@@ -1046,7 +1167,7 @@ type forbiddenResponse struct {
 	} `json:"forbidden"`
 }
 
-func (p *provider) cleanupFloatingIP(log *zap.SugaredLogger, machine *clusterv1alpha1.Machine, updater cloudprovidertypes.MachineUpdater) error {
+func (p *provider) cleanupFloatingIP(ctx context.Context, log *zap.SugaredLogger, machine *clusterv1alpha1.Machine, updater cloudprovidertypes.MachineUpdater) error {
 	floatingIPID, exists := machine.Annotations[floatingIPIDAnnotationKey]
 	if !exists {
 		return osErrorToTerminalError(log, fmt.Errorf("failed to release floating ip"),
@@ -1069,7 +1190,13 @@ func (p *provider) cleanupFloatingIP(log *zap.SugaredLogger, machine *clusterv1a
 	if err != nil {
 		return fmt.Errorf("failed to create the networkv2 client for region %s: %w", c.Region, err)
 	}
-	if err := osfloatingips.Delete(netClient, floatingIPID).ExtractErr(); err != nil && !errors.As(err, &gophercloud.ErrDefault404{}) {
+	err = audit.Call(ctx, "openstack.DeleteFloatingIP", map[string]string{"floatingIPID": floatingIPID}, func() error {
+		if err := osfloatingips.Delete(netClient, floatingIPID).ExtractErr(); err != nil && !errors.As(err, &gophercloud.ErrDefault404{}) {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
 		return fmt.Errorf("failed to delete floating ip %s: %w", floatingIPID, err)
 	}
 	if err := updater(machine, func(m *clusterv1alpha1.Machine) {
@@ -1083,7 +1210,7 @@ func (p *provider) cleanupFloatingIP(log *zap.SugaredLogger, machine *clusterv1a
 	return nil
 }
 
-func assignFloatingIPToInstance(instanceLog *zap.SugaredLogger, machineUpdater cloudprovidertypes.MachineUpdater, machine *clusterv1alpha1.Machine, netClient *gophercloud.ServiceClient, instanceID, floatingIPPoolName, region string, network *osnetworks.Network) error {
+func assignFloatingIPToInstance(ctx context.Context, instanceLog *zap.SugaredLogger, machineUpdater cloudprovidertypes.MachineUpdater, machine *clusterv1alpha1.Machine, netClient *gophercloud.ServiceClient, instanceID, floatingIPPoolName, region string, network *osnetworks.Network) error {
 	port, err := getInstancePort(netClient, instanceID, network.ID)
 	if err != nil {
 		return fmt.Errorf("failed to get instance port for network %s in region %s: %w", network.ID, region, err)
@@ -1106,7 +1233,11 @@ func assignFloatingIPToInstance(instanceLog *zap.SugaredLogger, machineUpdater c
 
 	var ip *osfloatingips.FloatingIP
 	if len(freeFloatingIps) < 1 {
-		if ip, err = createFloatingIP(netClient, port.ID, floatingIPPool); err != nil {
+		err = audit.Call(ctx, "openstack.CreateFloatingIP", map[string]string{"portID": port.ID, "floatingIPPool": floatingIPPool.ID}, func() error {
+			ip, err = createFloatingIP(netClient, port.ID, floatingIPPool)
+			return err
+		})
+		if err != nil {
 			return osErrorToTerminalError(instanceLog, err, "failed to allocate a floating ip")
 		}
 		if err := machineUpdater(machine, func(m *clusterv1alpha1.Machine) {
@@ -1120,9 +1251,12 @@ func assignFloatingIPToInstance(instanceLog *zap.SugaredLogger, machineUpdater c
 		}
 	} else {
 		freeIP := freeFloatingIps[0]
-		ip, err = osfloatingips.Update(netClient, freeIP.ID, osfloatingips.UpdateOpts{
-			PortID: &port.ID,
-		}).Extract()
+		err = audit.Call(ctx, "openstack.AssignFloatingIP", map[string]string{"floatingIPID": freeIP.ID, "portID": port.ID}, func() error {
+			ip, err = osfloatingips.Update(netClient, freeIP.ID, osfloatingips.UpdateOpts{
+				PortID: &port.ID,
+			}).Extract()
+			return err
+		})
 		if err != nil {
 			return fmt.Errorf("failed to update floating IP %s(%s): %w", freeIP.ID, freeIP.FloatingIP, err)
 		}
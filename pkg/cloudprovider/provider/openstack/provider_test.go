@@ -22,11 +22,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 	"text/template"
 	"time"
 
 	"github.com/gophercloud/gophercloud"
+	goopenstack "github.com/gophercloud/gophercloud/openstack"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
 	th "github.com/gophercloud/gophercloud/testhelper"
 	"github.com/gophercloud/gophercloud/testhelper/client"
@@ -34,6 +38,7 @@ import (
 
 	cloudprovidertesting "k8c.io/machine-controller/pkg/cloudprovider/testing"
 	cloudprovidertypes "k8c.io/machine-controller/pkg/cloudprovider/types"
+	cloudproviderutil "k8c.io/machine-controller/pkg/cloudprovider/util"
 	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
 	"k8c.io/machine-controller/sdk/providerconfig/configvar"
 
@@ -444,6 +449,56 @@ func TestProjectAuthVarsAreCorrectlyLoaded(t *testing.T) {
 	}
 }
 
+func TestGetWorkloadIdentityAuthOptions(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("service-account-token"), 0o600); err != nil {
+		t.Fatalf("failed to write token: %v", err)
+	}
+	cloudproviderutil.WorkloadIdentityTokenFile = tokenFile
+	defer func() { cloudproviderutil.WorkloadIdentityTokenFile = "" }()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v3/OS-FEDERATION/identity_providers/kubernetes/protocols/openid/auth" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer service-account-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("X-Subject-Token", "unscoped-token")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	pc, err := goopenstack.NewClient(server.URL + "/v3")
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	opts, err := getWorkloadIdentityAuthOptions(pc, &Config{
+		IdentityEndpoint: server.URL + "/v3",
+		IdentityProvider: "kubernetes",
+		Protocol:         defaultFederationProtocol,
+		ProjectName:      "the_project_name",
+		DomainName:       "the_domain_name",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if opts.TokenID != "unscoped-token" {
+		t.Errorf("TokenID = %v, wanted %v", opts.TokenID, "unscoped-token")
+	}
+	expectedScope := gophercloud.AuthScope{ProjectName: "the_project_name", DomainName: "the_domain_name"}
+	if opts.Scope == nil || *opts.Scope != expectedScope {
+		t.Errorf("Scope = %v, wanted %v", opts.Scope, expectedScope)
+	}
+	if opts.Password != "" || opts.ApplicationCredentialSecret != "" {
+		t.Error("expected no long-lived credentials in the auth options")
+	}
+}
+
 func TestResolveNetworks(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -24,6 +24,8 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strconv"
+	"strings"
 	"text/template"
 
 	"github.com/vmware/govmomi/object"
@@ -33,6 +35,8 @@ import (
 	"github.com/vmware/govmomi/vim25/types"
 	"go.uber.org/zap"
 
+	"k8c.io/machine-controller/pkg/audit"
+
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 )
 
@@ -96,13 +100,25 @@ func createClonedVM(ctx context.Context, log *zap.SugaredLogger, vmName string,
 	// Create a cloned VM from the template VM's snapshot.
 	// We split the cloning from the reconfiguring as those actions differ on the permission side.
 	// It's nicer to tell which specific action failed due to lacking permissions.
-	clonedVMTask, err := tpl.Clone(ctx, targetVMFolder, vmName, cloneSpec)
+	cloneRequest := map[string]string{
+		"name":         vmName,
+		"template":     config.TemplateVMName,
+		"folder":       config.Folder,
+		"datastore":    datastoreref.Value,
+		"resourcePool": resourcepoolref.Value,
+	}
+	err = audit.Call(ctx, "vsphere.CloneVM", cloneRequest, func() error {
+		clonedVMTask, err := tpl.Clone(ctx, targetVMFolder, vmName, cloneSpec)
+		if err != nil {
+			return fmt.Errorf("failed to clone template vm: %w", err)
+		}
+		if err := clonedVMTask.WaitEx(ctx); err != nil {
+			return fmt.Errorf("error when waiting for result of clone task: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to clone template vm: %w", err)
-	}
-
-	if err := clonedVMTask.WaitEx(ctx); err != nil {
-		return nil, fmt.Errorf("error when waiting for result of clone task: %w", err)
+		return nil, err
 	}
 	virtualMachine, err := session.Finder.VirtualMachine(ctx, vmName)
 	if err != nil {
@@ -204,12 +220,31 @@ func createClonedVM(ctx context.Context, log *zap.SugaredLogger, vmName string,
 		MemoryMB:   config.MemoryMB,
 		VAppConfig: vAppAconfig,
 	}
-	reconfigureTask, err := virtualMachine.Reconfigure(ctx, vmConfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to reconfigure the VM: %w", err)
+	// The reconfiguration resizes the boot disk and attaches the network interfaces.
+	reconfigureRequest := map[string]string{
+		"name":     vmName,
+		"cpus":     strconv.Itoa(int(config.CPUs)),
+		"memoryMB": strconv.FormatInt(config.MemoryMB, 10),
+		"networks": strings.Join(config.Networks, ","),
 	}
-	if err := reconfigureTask.WaitEx(ctx); err != nil {
-		return nil, fmt.Errorf("error when waiting for result of the reconfigure task: %w", err)
+	if config.VMNetName != "" {
+		reconfigureRequest["vmNetName"] = config.VMNetName
+	}
+	if config.DiskSizeGB != nil {
+		reconfigureRequest["diskSizeGB"] = strconv.FormatInt(*config.DiskSizeGB, 10)
+	}
+	err = audit.Call(ctx, "vsphere.ReconfigureVM", reconfigureRequest, func() error {
+		reconfigureTask, err := virtualMachine.Reconfigure(ctx, vmConfig)
+		if err != nil {
+			return fmt.Errorf("failed to reconfigure the VM: %w", err)
+		}
+		if err := reconfigureTask.WaitEx(ctx); err != nil {
+			return fmt.Errorf("error when waiting for result of the reconfigure task: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Ubuntu won't boot with attached floppy device, because it tries to write to it
@@ -310,7 +345,9 @@ func uploadAndAttachISO(ctx context.Context, log *zap.SugaredLogger, session *Se
 	}
 	cdrom.Connectable.StartConnected = true
 	iso := datastore.Path(remoteIsoFilePath)
-	return vmRef.EditDevice(ctx, devices.InsertIso(cdrom, iso))
+	return audit.Call(ctx, "vsphere.InsertISO", map[string]string{"name": vmRef.Name(), "iso": iso}, func() error {
+		return vmRef.EditDevice(ctx, devices.InsertIso(cdrom, iso))
+	})
 }
 
 func generateLocalUserdataISO(ctx context.Context, userdata, name string) (string, error) {
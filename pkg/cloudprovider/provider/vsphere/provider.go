@@ -31,6 +31,7 @@ import (
 	"github.com/vmware/govmomi/vim25/types"
 	"go.uber.org/zap"
 
+	"k8c.io/machine-controller/pkg/audit"
 	cloudprovidererrors "k8c.io/machine-controller/pkg/cloudprovider/errors"
 	"k8c.io/machine-controller/pkg/cloudprovider/instance"
 	cloudprovidertypes "k8c.io/machine-controller/pkg/cloudprovider/types"
@@ -40,8 +41,10 @@ import (
 	"k8c.io/machine-controller/sdk/providerconfig"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	ktypes "k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 type provider struct {
@@ -232,38 +235,50 @@ func (p *provider) getConfig(provSpec clusterv1alpha1.ProviderSpec) (*Config, *p
 }
 
 func (p *provider) Validate(ctx context.Context, log *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec) error {
+	allErrs, err := p.ValidateFields(ctx, log, spec, field.NewPath("spec", "providerSpec", "value"))
+	if err != nil {
+		return err
+	}
+	return allErrs.ToAggregate()
+}
+
+// ValidateFields validates the spec and reports all errors at once.
+func (p *provider) ValidateFields(ctx context.Context, log *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec, fldPath *field.Path) (field.ErrorList, error) {
 	config, _, _, err := p.getConfig(spec.ProviderSpec)
 	if err != nil {
-		return fmt.Errorf("failed to get config: %w", err)
+		return field.ErrorList{field.Invalid(fldPath, field.OmitValueType{}, fmt.Sprintf("failed to get config: %v", err))}, nil
 	}
 
+	allErrs := field.ErrorList{}
+	specPath := fldPath.Child("cloudProviderSpec")
+
 	session, err := NewSession(ctx, config)
 	if err != nil {
-		return fmt.Errorf("failed to create vCenter session: %w", err)
+		return nil, fmt.Errorf("failed to create vCenter session: %w", err)
 	}
 	defer session.Logout(ctx)
 
 	if len(config.Networks) > 0 && config.VMNetName != "" {
-		return fmt.Errorf("both networks and vmNetName are specified, only one of them can be used")
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("vmNetName"), "both networks and vmNetName are specified, only one of them can be used"))
 	}
 
 	if config.Tags != nil {
 		restAPISession, err := NewRESTSession(ctx, config)
 		if err != nil {
-			return fmt.Errorf("failed to create REST API session: %w", err)
+			return nil, fmt.Errorf("failed to create REST API session: %w", err)
 		}
 		defer restAPISession.Logout(ctx)
 		tagManager := tags.NewManager(restAPISession.Client)
 		log.Debug("Found tags")
-		for _, tag := range config.Tags {
+		for i, tag := range config.Tags {
+			tagPath := specPath.Child("tags").Index(i)
 			if tag.ID == "" && tag.Name == "" {
-				return fmt.Errorf("either tag id or name must be specified")
+				allErrs = append(allErrs, field.Required(tagPath.Child("id"), "either tag id or name must be specified"))
 			}
 			if tag.CategoryID == "" {
-				return fmt.Errorf("one of the tags category is empty")
-			}
-			if _, err := tagManager.GetCategory(ctx, tag.CategoryID); err != nil {
-				return fmt.Errorf("can't get the category with ID %s, %w", tag.CategoryID, err)
+				allErrs = append(allErrs, field.Required(tagPath.Child("categoryID"), "the tag category is empty"))
+			} else if _, err := tagManager.GetCategory(ctx, tag.CategoryID); err != nil {
+				allErrs = append(allErrs, field.Invalid(tagPath.Child("categoryID"), tag.CategoryID, fmt.Sprintf("can't get the category: %v", err)))
 			}
 		}
 		log.Debug("Tag validation passed")
@@ -271,65 +286,66 @@ func (p *provider) Validate(ctx context.Context, log *zap.SugaredLogger, spec cl
 
 	// Only and only one between datastore and datastre cluster should be
 	// present, otherwise an error is raised.
-	if config.DatastoreCluster != "" && config.Datastore == "" {
+	switch {
+	case config.DatastoreCluster != "" && config.Datastore == "":
 		if _, err := session.Finder.DatastoreCluster(ctx, config.DatastoreCluster); err != nil {
-			return fmt.Errorf("failed to get datastore cluster %s: %w", config.DatastoreCluster, err)
+			allErrs = append(allErrs, field.Invalid(specPath.Child("datastoreCluster"), config.DatastoreCluster, fmt.Sprintf("failed to get datastore cluster: %v", err)))
 		}
-	} else if config.Datastore != "" && config.DatastoreCluster == "" {
+	case config.Datastore != "" && config.DatastoreCluster == "":
 		if _, err := session.Finder.Datastore(ctx, config.Datastore); err != nil {
-			return fmt.Errorf("failed to get datastore %s: %w", config.Datastore, err)
+			allErrs = append(allErrs, field.Invalid(specPath.Child("datastore"), config.Datastore, fmt.Sprintf("failed to get datastore: %v", err)))
 		}
-	} else {
-		return fmt.Errorf("one between datastore and datastore cluster should be specified: %w", err)
+	default:
+		allErrs = append(allErrs, field.Invalid(specPath.Child("datastore"), config.Datastore, "one between datastore and datastore cluster should be specified"))
 	}
 
 	if _, err := session.Finder.Folder(ctx, config.Folder); err != nil {
-		return fmt.Errorf("failed to get folder %q: %w", config.Folder, err)
-	}
-
-	if _, err := p.get(ctx, config.Folder, spec, session.Finder); err == nil {
-		return fmt.Errorf("a vm %s/%s already exists", config.Folder, spec.Name)
+		allErrs = append(allErrs, field.Invalid(specPath.Child("folder"), config.Folder, fmt.Sprintf("failed to get folder: %v", err)))
+	} else if _, err := p.get(ctx, config.Folder, spec, session.Finder); err == nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("folder"), config.Folder, fmt.Sprintf("a vm %s already exists", spec.Name)))
 	}
 
 	if config.ResourcePool != "" {
 		if _, err := session.Finder.ResourcePool(ctx, config.ResourcePool); err != nil {
-			return fmt.Errorf("failed to get resourcepool %q: %w", config.ResourcePool, err)
+			allErrs = append(allErrs, field.Invalid(specPath.Child("resourcePool"), config.ResourcePool, fmt.Sprintf("failed to get resourcepool: %v", err)))
 		}
 	}
 
-	templateVM, err := session.Finder.VirtualMachine(ctx, config.TemplateVMName)
-	if err != nil {
-		return fmt.Errorf("failed to get template vm %q: %w", config.TemplateVMName, err)
-	}
-
-	disks, err := getDisksFromVM(ctx, templateVM)
-	if err != nil {
-		return fmt.Errorf("failed to get disks from VM: %w", err)
-	}
-	if diskLen := len(disks); diskLen != 1 {
-		return fmt.Errorf("expected vm to have exactly one disk, had %d", diskLen)
-	}
-
-	if config.DiskSizeGB != nil {
-		if err := validateDiskResizing(disks, *config.DiskSizeGB); err != nil {
-			return err
+	templatePath := specPath.Child("templateVMName")
+	if templateVM, err := session.Finder.VirtualMachine(ctx, config.TemplateVMName); err != nil {
+		allErrs = append(allErrs, field.Invalid(templatePath, config.TemplateVMName, fmt.Sprintf("failed to get template vm: %v", err)))
+	} else {
+		disks, err := getDisksFromVM(ctx, templateVM)
+		switch {
+		case err != nil:
+			allErrs = append(allErrs, field.Invalid(templatePath, config.TemplateVMName, fmt.Sprintf("failed to get disks from VM: %v", err)))
+		case len(disks) != 1:
+			allErrs = append(allErrs, field.Invalid(templatePath, config.TemplateVMName, fmt.Sprintf("expected vm to have exactly one disk, had %d", len(disks))))
+		case config.DiskSizeGB != nil:
+			if err := validateDiskResizing(disks, *config.DiskSizeGB); err != nil {
+				allErrs = append(allErrs, field.Invalid(specPath.Child("diskSizeGB"), *config.DiskSizeGB, err.Error()))
+			}
 		}
 	}
 
 	if config.VMAntiAffinity && config.Cluster == "" {
-		return fmt.Errorf("cluster is required for vm anti affinity")
+		allErrs = append(allErrs, field.Required(specPath.Child("cluster"), "cluster is required for vm anti affinity"))
 	} else if config.VMGroup != "" && config.Cluster == "" {
-		return fmt.Errorf("cluster is required for vm group")
+		allErrs = append(allErrs, field.Required(specPath.Child("cluster"), "cluster is required for vm group"))
 	}
 
 	if config.Cluster != "" {
-		_, err = session.Finder.ClusterComputeResource(ctx, config.Cluster)
-		if err != nil {
-			return fmt.Errorf("failed to get cluster %q, %w", config.Cluster, err)
+		if _, err := session.Finder.ClusterComputeResource(ctx, config.Cluster); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("cluster"), config.Cluster, fmt.Sprintf("failed to get cluster: %v", err)))
 		}
 	}
 
-	return nil
+	// Failed API calls of canceled requests are no validation errors.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return allErrs, nil
 }
 
 func machineInvalidConfigurationTerminalError(err error) error {
@@ -411,26 +427,17 @@ func (p *provider) create(ctx context.Context, log *zap.SugaredLogger, machine *
 
 		if err := uploadAndAttachISO(ctx, log, session, virtualMachine, localUserdataIsoFilePath); err != nil {
 			// Destroy VM to avoid a leftover.
-			destroyTask, vmErr := virtualMachine.Destroy(ctx)
-			if vmErr != nil {
-				return nil, fmt.Errorf("failed to destroy vm %s after failing upload and attach userdata iso: %w / %w", virtualMachine.Name(), err, vmErr)
-			}
-			if vmErr := destroyTask.WaitEx(ctx); vmErr != nil {
+			if vmErr := destroyVM(ctx, virtualMachine); vmErr != nil {
 				return nil, fmt.Errorf("failed to destroy vm %s after failing upload and attach userdata iso: %w / %w", virtualMachine.Name(), err, vmErr)
 			}
 			return nil, machineInvalidConfigurationTerminalError(fmt.Errorf("failed to upload and attach userdata iso: %w", err))
 		}
 	}
 
-	powerOnTask, err := virtualMachine.PowerOn(ctx)
-	if err != nil {
+	if err := powerOnVM(ctx, virtualMachine); err != nil {
 		return nil, fmt.Errorf("failed to power on machine: %w", err)
 	}
 
-	if err := powerOnTask.WaitEx(ctx); err != nil {
-		return nil, fmt.Errorf("error when waiting for vm powerOn task: %w", err)
-	}
-
 	return Server{name: virtualMachine.Name(), status: instance.StatusRunning, id: virtualMachine.Reference().Value, uuid: virtualMachine.UUID(ctx)}, nil
 }
 
@@ -472,13 +479,16 @@ func (p *provider) Cleanup(ctx context.Context, log *zap.SugaredLogger, machine
 	// We cannot destroy a VM that's powered on, but we also
 	// cannot power off a machine that is already off.
 	if powerState != types.VirtualMachinePowerStatePoweredOff {
-		powerOffTask, err := virtualMachine.PowerOff(ctx)
+		err := audit.Call(ctx, "vsphere.PowerOffVM", map[string]string{"name": virtualMachine.Name()}, func() error {
+			powerOffTask, err := virtualMachine.PowerOff(ctx)
+			if err != nil {
+				return err
+			}
+			return powerOffTask.WaitEx(ctx)
+		})
 		if err != nil {
 			return false, fmt.Errorf("failed to poweroff vm %s: %w", virtualMachine.Name(), err)
 		}
-		if err = powerOffTask.WaitEx(ctx); err != nil {
-			return false, fmt.Errorf("failed to poweroff vm %s: %w", virtualMachine.Name(), err)
-		}
 	}
 
 	virtualMachineDeviceList, err := virtualMachine.Device(ctx)
@@ -511,11 +521,7 @@ func (p *provider) Cleanup(ctx context.Context, log *zap.SugaredLogger, machine
 	if err != nil {
 		return false, fmt.Errorf("failed to get datastore from VM %s: %w", virtualMachine.Name(), err)
 	}
-	destroyTask, err := virtualMachine.Destroy(ctx)
-	if err != nil {
-		return false, fmt.Errorf("failed to destroy VM %s: %w", virtualMachine.Name(), err)
-	}
-	if err := destroyTask.WaitEx(ctx); err != nil {
+	if err := destroyVM(ctx, virtualMachine); err != nil {
 		return false, fmt.Errorf("failed to destroy VM %s: %w", virtualMachine.Name(), err)
 	}
 
@@ -558,13 +564,9 @@ func (p *provider) Get(ctx context.Context, log *zap.SugaredLogger, machine *clu
 	}
 
 	if powerState != types.VirtualMachinePowerStatePoweredOn {
-		powerOnTask, err := virtualMachine.PowerOn(ctx)
-		if err != nil {
+		if err := powerOnVM(ctx, virtualMachine); err != nil {
 			return nil, fmt.Errorf("failed to power on instance that was in state %q: %w", powerState, err)
 		}
-		if err := powerOnTask.WaitEx(ctx); err != nil {
-			return nil, fmt.Errorf("failed waiting for instance to be powered on: %w", err)
-		}
 		// We must return here because the vendored code for determining if the guest
 		// utils are running yields an NPD when using with an instance that is not running
 		return Server{name: virtualMachine.Name(), status: instance.StatusUnknown, uuid: virtualMachine.UUID(ctx)}, nil
@@ -598,6 +600,28 @@ func (p *provider) Get(ctx context.Context, log *zap.SugaredLogger, machine *clu
 	return Server{name: virtualMachine.Name(), status: instance.StatusRunning, addresses: addresses, id: virtualMachine.Reference().Value, uuid: virtualMachine.UUID(ctx)}, nil
 }
 
+// powerOnVM powers on the VM and waits for the task to finish.
+func powerOnVM(ctx context.Context, vm *object.VirtualMachine) error {
+	return audit.Call(ctx, "vsphere.PowerOnVM", map[string]string{"name": vm.Name()}, func() error {
+		powerOnTask, err := vm.PowerOn(ctx)
+		if err != nil {
+			return err
+		}
+		return powerOnTask.WaitEx(ctx)
+	})
+}
+
+// destroyVM destroys the VM along with its disks and waits for the task to finish.
+func destroyVM(ctx context.Context, vm *object.VirtualMachine) error {
+	return audit.Call(ctx, "vsphere.DestroyVM", map[string]string{"name": vm.Name()}, func() error {
+		destroyTask, err := vm.Destroy(ctx)
+		if err != nil {
+			return err
+		}
+		return destroyTask.WaitEx(ctx)
+	})
+}
+
 func (p *provider) MigrateUID(_ context.Context, _ *zap.SugaredLogger, _ *clusterv1alpha1.Machine, _ ktypes.UID) error {
 	return nil
 }
@@ -614,6 +638,24 @@ func (p *provider) MachineMetricsLabels(machine *clusterv1alpha1.Machine) (map[s
 	return labels, err
 }
 
+// MachineCapacity returns the CPUs, memory and, if set, the disk size from the machine's specification.
+func (p *provider) MachineCapacity(_ context.Context, _ *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec) (*cloudprovidertypes.MachineCapacity, error) {
+	c, _, _, err := p.getConfig(spec.ProviderSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	capacity := &cloudprovidertypes.MachineCapacity{
+		CPU:    resource.NewQuantity(int64(c.CPUs), resource.DecimalSI),
+		Memory: resource.NewQuantity(c.MemoryMB*1024*1024, resource.BinarySI),
+	}
+	if c.DiskSizeGB != nil {
+		capacity.EphemeralStorage = resource.NewQuantity(*c.DiskSizeGB*1024*1024*1024, resource.BinarySI)
+	}
+
+	return capacity, nil
+}
+
 func (p *provider) SetMetricsForMachines(_ clusterv1alpha1.MachineList) error {
 	return nil
 }
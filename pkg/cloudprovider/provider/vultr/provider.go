@@ -625,6 +625,7 @@ func vltErrorToTerminalError(status int, err error) error {
 		return cloudprovidererrors.TerminalError{
 			Reason:  common.InvalidConfigurationMachineError,
 			Message: "A request has been rejected due to invalid credentials which were taken from the MachineSpec",
+			Err:     cloudprovidererrors.ErrInvalidCredentials,
 		}
 	default:
 		return err
@@ -26,7 +26,9 @@ import (
 	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
 
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/util/retry"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -76,6 +78,33 @@ type Provider interface {
 	SetMetricsForMachines(machines clusterv1alpha1.MachineList) error
 }
 
+// MachineCapacity describes the resources of an instance created for a machine.
+// Unknown resources are left empty.
+type MachineCapacity struct {
+	CPU              *resource.Quantity
+	Memory           *resource.Quantity
+	EphemeralStorage *resource.Quantity
+	GPUCount         int64
+	GPUType          string
+}
+
+// CapacityProvider is an optional interface for providers which can tell the capacity of an
+// instance from the machine's specification. It is used to annotate MachineDeployments, so the
+// cluster-autoscaler can scale them up from zero.
+type CapacityProvider interface {
+	// MachineCapacity returns the capacity of an instance created for the given machine specification.
+	MachineCapacity(ctx context.Context, log *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec) (*MachineCapacity, error)
+}
+
+// FieldValidator is an optional interface for providers which can report all errors of a machine's
+// specification at once instead of the first one. It is used for dry-run validations.
+type FieldValidator interface {
+	// ValidateFields validates the specification and returns its errors with paths below fldPath, the
+	// path of the providerSpec value. Failures which prevent the validation, like an unreachable cloud
+	// API, are returned as error.
+	ValidateFields(ctx context.Context, log *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec, fldPath *field.Path) (field.ErrorList, error)
+}
+
 // MachineModifier defines a function to modify a machine.
 type MachineModifier func(*clusterv1alpha1.Machine)
 
@@ -27,6 +27,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -84,24 +85,47 @@ type file struct {
 }
 
 func extractAPIServer(userdata string) (string, error) {
+	config, err := extractBootstrapKubeconfig(userdata)
+	if err != nil {
+		return "", err
+	}
+
+	return config.Host, nil
+}
+
+// ExtractAPIServerCA returns the CA bundle of the api-server from the bootstrap kubeconfig in the userdata.
+func ExtractAPIServerCA(userdata string) ([]byte, error) {
+	config, err := extractBootstrapKubeconfig(userdata)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(config.CAData) == 0 {
+		return nil, errors.New("bootstrap kubeconfig does not contain a CA bundle")
+	}
+
+	return config.CAData, nil
+}
+
+func extractBootstrapKubeconfig(userdata string) (*rest.Config, error) {
 	files := &struct {
 		WriteFiles []file `yaml:"write_files"`
 	}{}
 
 	if err := yaml.Unmarshal([]byte(userdata), files); err != nil {
-		return "", fmt.Errorf("failed to unmarshal userdata: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal userdata: %w", err)
 	}
 
 	for _, file := range files.WriteFiles {
 		if file.Path == "/etc/kubernetes/bootstrap-kubelet.conf" {
 			config, err := clientcmd.RESTConfigFromKubeConfig([]byte(file.Content))
 			if err != nil {
-				return "", fmt.Errorf("failed to get kubeconfig from userdata: %w", err)
+				return nil, fmt.Errorf("failed to get kubeconfig from userdata: %w", err)
 			}
 
-			return config.Host, nil
+			return config, nil
 		}
 	}
 
-	return "", errors.New("failed to find api-server host")
+	return nil, errors.New("failed to find api-server host")
 }
@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var (
+	// WorkloadIdentityTokenFile is set globally once by the main() function
+	// and is the path of a projected ServiceAccount token, which the providers
+	// exchange for short-lived cloud credentials. Workload identity federation
+	// is disabled if it is empty.
+	WorkloadIdentityTokenFile string
+
+	// ErrWorkloadIdentityNotConfigured is returned if a provider spec uses workload
+	// identity federation, but no token file is configured.
+	ErrWorkloadIdentityNotConfigured = errors.New("workload identity federation is not configured, -workload-identity-token-file must be set")
+)
+
+// SetWorkloadIdentityTokenFile sets the global WorkloadIdentityTokenFile. The
+// file must contain a token.
+func SetWorkloadIdentityTokenFile(filename string) error {
+	if _, err := readWorkloadIdentityToken(filename); err != nil {
+		return err
+	}
+
+	WorkloadIdentityTokenFile = filename
+	return nil
+}
+
+// WorkloadIdentityToken returns the current ServiceAccount token. The file is
+// read on every call, as the kubelet rotates projected tokens before they expire.
+func WorkloadIdentityToken() (string, error) {
+	if WorkloadIdentityTokenFile == "" {
+		return "", ErrWorkloadIdentityNotConfigured
+	}
+
+	return readWorkloadIdentityToken(WorkloadIdentityTokenFile)
+}
+
+func readWorkloadIdentityToken(filename string) (string, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to read workload identity token: %w", err)
+	}
+
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", errors.New("workload identity token file is empty")
+	}
+
+	return token, nil
+}
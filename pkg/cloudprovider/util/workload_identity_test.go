@@ -0,0 +1,65 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWorkloadIdentityToken(t *testing.T) {
+	defer func() { WorkloadIdentityTokenFile = "" }()
+
+	if _, err := WorkloadIdentityToken(); !errors.Is(err, ErrWorkloadIdentityNotConfigured) {
+		t.Fatalf("expected %v without token file, got %v", ErrWorkloadIdentityNotConfigured, err)
+	}
+
+	filename := filepath.Join(t.TempDir(), "token")
+	if err := SetWorkloadIdentityTokenFile(filename); err == nil {
+		t.Fatal("expected an error for a missing token file")
+	}
+
+	if err := os.WriteFile(filename, []byte("\n"), 0o600); err != nil {
+		t.Fatalf("failed to write token: %v", err)
+	}
+	if err := SetWorkloadIdentityTokenFile(filename); err == nil {
+		t.Fatal("expected an error for an empty token file")
+	}
+
+	if err := os.WriteFile(filename, []byte("first\n"), 0o600); err != nil {
+		t.Fatalf("failed to write token: %v", err)
+	}
+	if err := SetWorkloadIdentityTokenFile(filename); err != nil {
+		t.Fatalf("failed to set token file: %v", err)
+	}
+
+	// The token is read again after the kubelet rotated it.
+	for _, expected := range []string{"first", "second"} {
+		if err := os.WriteFile(filename, []byte(expected+"\n"), 0o600); err != nil {
+			t.Fatalf("failed to write token: %v", err)
+		}
+		token, err := WorkloadIdentityToken()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if token != expected {
+			t.Errorf("expected token %q, got %q", expected, token)
+		}
+	}
+}
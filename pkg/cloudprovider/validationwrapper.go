@@ -28,6 +28,7 @@ import (
 	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
 
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 type cachingValidationWrapper struct {
@@ -98,3 +99,32 @@ func (w *cachingValidationWrapper) MachineMetricsLabels(machine *clusterv1alpha1
 func (w *cachingValidationWrapper) SetMetricsForMachines(machines clusterv1alpha1.MachineList) error {
 	return w.actualProvider.SetMetricsForMachines(machines)
 }
+
+// MachineCapacity calls the underlying cloudproviders MachineCapacity if it implements
+// cloudprovidertypes.CapacityProvider.
+func (w *cachingValidationWrapper) MachineCapacity(ctx context.Context, log *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec) (*cloudprovidertypes.MachineCapacity, error) {
+	capacityProvider, ok := w.actualProvider.(cloudprovidertypes.CapacityProvider)
+	if !ok {
+		return nil, ErrCapacityNotSupported
+	}
+	return capacityProvider.MachineCapacity(ctx, log, spec)
+}
+
+// ValidateFields calls the underlying cloudproviders ValidateFields if it implements
+// cloudprovidertypes.FieldValidator. Otherwise the error of Validate is reported for the
+// whole providerSpec value.
+func (w *cachingValidationWrapper) ValidateFields(ctx context.Context, log *zap.SugaredLogger, spec clusterv1alpha1.MachineSpec, fldPath *field.Path) (field.ErrorList, error) {
+	if fieldValidator, ok := w.actualProvider.(cloudprovidertypes.FieldValidator); ok {
+		return fieldValidator.ValidateFields(ctx, log, spec, fldPath)
+	}
+
+	err := w.Validate(ctx, log, spec)
+	switch {
+	case err == nil:
+		return nil, nil
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		return nil, err
+	default:
+		return field.ErrorList{field.Invalid(fldPath, field.OmitValueType{}, err.Error())}, nil
+	}
+}
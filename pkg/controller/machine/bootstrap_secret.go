@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"k8c.io/machine-controller/pkg/bootstrap"
+	"k8c.io/machine-controller/pkg/cloudprovider/util"
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// bootstrapSecretPredicate only passes the secrets of the cloud-init namespace.
+var bootstrapSecretPredicate = predicate.NewPredicateFuncs(func(secret ctrlruntimeclient.Object) bool {
+	return secret.GetNamespace() == util.CloudInitNamespace
+})
+
+// machineUserdata returns the userdata of the machine from the bootstrap provider of its
+// MachineDeployment. While the userdata is not available yet, false is returned and the
+// readiness is recorded in the BootstrapSecretReady condition. Machines using the bootstrap
+// secret are reconciled again once it changes.
+func (r *Reconciler) machineUserdata(ctx context.Context, log *zap.SugaredLogger, machine *clusterv1alpha1.Machine) (string, bool, error) {
+	machineDeployment, err := r.machineDeploymentForMachine(ctx, log, machine)
+	if err != nil {
+		return "", false, err
+	}
+	prov, err := bootstrap.ForMachineDeployment(r.client, machineDeployment)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get bootstrap provider: %w", err)
+	}
+
+	userdata, err := prov.Userdata(ctx, log, machine, machineDeployment)
+	if err != nil {
+		var notReady *bootstrap.NotReadyError
+		if !errors.As(err, &notReady) {
+			return "", false, err
+		}
+
+		return "", false, r.ensureMachineCondition(machine, corev1.NodeCondition{
+			Type:    clusterv1alpha1.MachineBootstrapSecretReadyCondition,
+			Status:  corev1.ConditionFalse,
+			Reason:  notReady.Reason,
+			Message: notReady.Message,
+		})
+	}
+
+	if bootstrap.ProviderType(machineDeployment) == clusterv1alpha1.BootstrapProviderOSM {
+		if err := r.ensureMachineCondition(machine, corev1.NodeCondition{
+			Type:    clusterv1alpha1.MachineBootstrapSecretReadyCondition,
+			Status:  corev1.ConditionTrue,
+			Reason:  clusterv1alpha1.BootstrapSecretAvailableReason,
+			Message: "Bootstrap secret is available",
+		}); err != nil {
+			return "", false, err
+		}
+	}
+
+	return userdata, true, nil
+}
+
+// enqueueRequestsForBootstrapSecrets enqueues the machines without a node of the MachineDeployment
+// a bootstrap secret belongs to.
+func enqueueRequestsForBootstrapSecrets(ctx context.Context, client ctrlruntimeclient.Client) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(_ context.Context, secret ctrlruntimeclient.Object) []reconcile.Request {
+		machineDeployments := &clusterv1alpha1.MachineDeploymentList{}
+		if err := client.List(ctx, machineDeployments); err != nil {
+			utilruntime.HandleError(fmt.Errorf("failed to list MachineDeployments: %w", err))
+			return nil
+		}
+
+		var result []reconcile.Request
+		for _, machineDeployment := range machineDeployments.Items {
+			if bootstrap.OSMSecretName(machineDeployment.Name, machineDeployment.Namespace) != secret.GetName() {
+				continue
+			}
+
+			selector, err := metav1.LabelSelectorAsSelector(&machineDeployment.Spec.Selector)
+			if err != nil {
+				utilruntime.HandleError(fmt.Errorf("failed to parse selector of MachineDeployment %s/%s: %w", machineDeployment.Namespace, machineDeployment.Name, err))
+				continue
+			}
+
+			machines := &clusterv1alpha1.MachineList{}
+			if err := client.List(ctx, machines, ctrlruntimeclient.InNamespace(machineDeployment.Namespace), ctrlruntimeclient.MatchingLabelsSelector{Selector: selector}); err != nil {
+				utilruntime.HandleError(fmt.Errorf("failed to list machines: %w", err))
+				continue
+			}
+
+			for _, machine := range machines.Items {
+				if machine.Status.NodeRef == nil && machine.DeletionTimestamp == nil {
+					result = append(result, reconcile.Request{NamespacedName: types.NamespacedName{
+						Namespace: machine.Namespace,
+						Name:      machine.Name,
+					}})
+				}
+			}
+		}
+		return result
+	})
+}
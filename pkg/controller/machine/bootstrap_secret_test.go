@@ -0,0 +1,212 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"k8c.io/machine-controller/pkg/bootstrap"
+	cloudprovidertypes "k8c.io/machine-controller/pkg/cloudprovider/types"
+	"k8c.io/machine-controller/pkg/cloudprovider/util"
+	controllerutil "k8c.io/machine-controller/pkg/controller/util"
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+	sdkbootstrap "k8c.io/machine-controller/sdk/bootstrap"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/workqueue"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestMachineUserdata(t *testing.T) {
+	ctx := context.Background()
+
+	machineSet := &clusterv1alpha1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "md-1234",
+			Namespace:       metav1.NamespaceSystem,
+			Annotations:     map[string]string{controllerutil.RevisionAnnotation: "2"},
+			OwnerReferences: []metav1.OwnerReference{{Kind: "MachineDeployment", Name: "md"}},
+		},
+	}
+	newMachine := func() *clusterv1alpha1.Machine {
+		return &clusterv1alpha1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "machine",
+				Namespace:       metav1.NamespaceSystem,
+				OwnerReferences: []metav1.OwnerReference{{Kind: "MachineSet", Name: machineSet.Name}},
+			},
+		}
+	}
+	newSecret := func(revision string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        bootstrap.OSMSecretName("md", metav1.NamespaceSystem),
+				Namespace:   util.CloudInitNamespace,
+				Annotations: map[string]string{sdkbootstrap.MachineDeploymentRevision: revision},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		secret         *corev1.Secret
+		expectReady    bool
+		expectedStatus corev1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name:           "missing secret",
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: clusterv1alpha1.BootstrapSecretMissingReason,
+		},
+		{
+			name:           "outdated secret",
+			secret:         newSecret("1"),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: clusterv1alpha1.BootstrapSecretOutdatedReason,
+		},
+		{
+			name:           "ready secret",
+			secret:         newSecret("2"),
+			expectReady:    true,
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: clusterv1alpha1.BootstrapSecretAvailableReason,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			machine := newMachine()
+			objects := []ctrlruntimeclient.Object{machine, machineSet}
+			if tc.secret != nil {
+				objects = append(objects, tc.secret)
+			}
+			client := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objects...).Build()
+			reconciler := &Reconciler{
+				client: client,
+				providerData: &cloudprovidertypes.ProviderData{
+					Ctx:    ctx,
+					Update: cloudprovidertypes.GetMachineUpdater(ctx, client),
+					Client: client,
+				},
+			}
+
+			_, ready, err := reconciler.machineUserdata(ctx, zap.NewNop().Sugar(), machine)
+			if err != nil {
+				t.Fatalf("failed to get userdata: %v", err)
+			}
+			if ready != tc.expectReady {
+				t.Errorf("expected userdata to be ready: %v, got %v", tc.expectReady, ready)
+			}
+
+			if len(machine.Status.Conditions) != 1 {
+				t.Fatalf("expected one condition, got %v", machine.Status.Conditions)
+			}
+			condition := machine.Status.Conditions[0]
+			if condition.Type != clusterv1alpha1.MachineBootstrapSecretReadyCondition || condition.Status != tc.expectedStatus || condition.Reason != tc.expectedReason {
+				t.Errorf("expected condition %s=%s with reason %s, got %s=%s with reason %s",
+					clusterv1alpha1.MachineBootstrapSecretReadyCondition, tc.expectedStatus, tc.expectedReason, condition.Type, condition.Status, condition.Reason)
+			}
+		})
+	}
+}
+
+func TestMachineUserdataWithUnavailableMachineDeployment(t *testing.T) {
+	ctx := context.Background()
+
+	machineSet := &clusterv1alpha1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "md-1234",
+			Namespace:       metav1.NamespaceSystem,
+			OwnerReferences: []metav1.OwnerReference{{Kind: "MachineDeployment", Name: "md"}},
+		},
+	}
+	machine := &clusterv1alpha1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "machine",
+			Namespace:       metav1.NamespaceSystem,
+			OwnerReferences: []metav1.OwnerReference{{Kind: "MachineSet", Name: machineSet.Name}},
+		},
+	}
+	client := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(machine, machineSet).WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(ctx context.Context, client ctrlruntimeclient.WithWatch, key ctrlruntimeclient.ObjectKey, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.GetOption) error {
+			if _, ok := obj.(*clusterv1alpha1.MachineDeployment); ok {
+				return apierrors.NewServiceUnavailable("apiserver is unavailable")
+			}
+			return client.Get(ctx, key, obj, opts...)
+		},
+	}).Build()
+	reconciler := &Reconciler{client: client}
+
+	// Falling back to operating-system-manager would create instances with the wrong userdata.
+	if _, ready, err := reconciler.machineUserdata(ctx, zap.NewNop().Sugar(), machine); err == nil || ready {
+		t.Errorf("expected the machine to be requeued, got ready: %v, error: %v", ready, err)
+	}
+}
+
+func TestEnqueueRequestsForBootstrapSecrets(t *testing.T) {
+	ctx := context.Background()
+
+	machineDeployment := &clusterv1alpha1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "md", Namespace: metav1.NamespaceSystem},
+		Spec: clusterv1alpha1.MachineDeploymentSpec{
+			Selector: metav1.LabelSelector{MatchLabels: map[string]string{"md": "md"}},
+		},
+	}
+	waiting := &clusterv1alpha1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "waiting", Namespace: metav1.NamespaceSystem, Labels: map[string]string{"md": "md"}},
+	}
+	joined := &clusterv1alpha1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "joined", Namespace: metav1.NamespaceSystem, Labels: map[string]string{"md": "md"}},
+		Status:     clusterv1alpha1.MachineStatus{NodeRef: &corev1.ObjectReference{Name: "node"}},
+	}
+	other := &clusterv1alpha1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: metav1.NamespaceSystem, Labels: map[string]string{"md": "other"}},
+	}
+
+	client := fakectrlruntimeclient.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(machineDeployment, waiting, joined, other).
+		Build()
+
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+	defer queue.ShutDown()
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name:      bootstrap.OSMSecretName("md", metav1.NamespaceSystem),
+		Namespace: util.CloudInitNamespace,
+	}}
+	enqueueRequestsForBootstrapSecrets(ctx, client).Create(ctx, event.CreateEvent{Object: secret}, queue)
+
+	if queue.Len() != 1 {
+		t.Fatalf("expected one machine to be enqueued, got %d", queue.Len())
+	}
+	request, _ := queue.Get()
+	if request.Name != waiting.Name {
+		t.Errorf("expected machine %q to be enqueued, got %q", waiting.Name, request.Name)
+	}
+}
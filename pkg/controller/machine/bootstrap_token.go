@@ -0,0 +1,175 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+	"k8c.io/machine-controller/sdk/bootstrap"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// BootstrapTokenMachineUIDLabel is set on the bootstrap token secrets created for a Machine.
+	BootstrapTokenMachineUIDLabel = "machine-controller.kubermatic.io/bootstrap-token-machine-uid"
+
+	bootstrapTokenSecretPrefix = "bootstrap-token-"
+	bootstrapTokenAuthGroup    = "system:bootstrappers:machine-controller:default-node-token"
+	bootstrapTokenCharset      = "0123456789abcdefghijklmnopqrstuvwxyz"
+	bootstrapTokenIDLength     = 6
+	bootstrapTokenSecretLength = 16
+
+	// bootstrapTokenMinRemainingTTL is how long an existing token must at least stay valid to be
+	// reused for a new instance.
+	bootstrapTokenMinRemainingTTL = 5 * time.Minute
+
+	bootstrapTokenRevokedJoined  = "joined"
+	bootstrapTokenRevokedDeleted = "deleted"
+)
+
+// bootstrapTokenForMachine returns a bootstrap token which is only valid for the machine. An existing
+// token is reused as long as it is valid for long enough, otherwise a new one is created.
+func (r *Reconciler) bootstrapTokenForMachine(ctx context.Context, log *zap.SugaredLogger, machine *clusterv1alpha1.Machine) (string, error) {
+	secrets, err := r.bootstrapTokenSecrets(ctx, machine)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	for _, secret := range secrets {
+		expiration, err := time.Parse(time.RFC3339, string(secret.Data["expiration"]))
+		if err != nil || expiration.Sub(now) < bootstrapTokenMinRemainingTTL {
+			continue
+		}
+		return fmt.Sprintf("%s.%s", secret.Data["token-id"], secret.Data["token-secret"]), nil
+	}
+
+	tokenID, err := randomBootstrapTokenString(bootstrapTokenIDLength)
+	if err != nil {
+		return "", err
+	}
+	tokenSecret, err := randomBootstrapTokenString(bootstrapTokenSecretLength)
+	if err != nil {
+		return "", err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      bootstrapTokenSecretPrefix + tokenID,
+			Namespace: metav1.NamespaceSystem,
+			Labels: map[string]string{
+				BootstrapTokenMachineUIDLabel: string(machine.UID),
+			},
+		},
+		Type: corev1.SecretTypeBootstrapToken,
+		Data: map[string][]byte{
+			"description":                    []byte(fmt.Sprintf("Bootstrap token for machine %s/%s", machine.Namespace, machine.Name)),
+			"token-id":                       []byte(tokenID),
+			"token-secret":                   []byte(tokenSecret),
+			"expiration":                     []byte(now.Add(r.bootstrapTokenTTL).UTC().Format(time.RFC3339)),
+			"usage-bootstrap-authentication": []byte("true"),
+			"usage-bootstrap-signing":        []byte("true"),
+			"auth-extra-groups":              []byte(bootstrapTokenAuthGroup),
+		},
+	}
+	if err := r.client.Create(ctx, secret); err != nil {
+		return "", fmt.Errorf("failed to create bootstrap token secret: %w", err)
+	}
+
+	r.metrics.BootstrapTokensCreated.Inc()
+	log.Infow("Created bootstrap token", "secret", secret.Name, "ttl", r.bootstrapTokenTTL)
+
+	return fmt.Sprintf("%s.%s", tokenID, tokenSecret), nil
+}
+
+// revokeBootstrapTokens deletes all bootstrap tokens of the machine, so they can no longer be
+// used to join a node, e.g. from leaked userdata.
+func (r *Reconciler) revokeBootstrapTokens(ctx context.Context, log *zap.SugaredLogger, machine *clusterv1alpha1.Machine, reason string) error {
+	secrets, err := r.bootstrapTokenSecrets(ctx, machine)
+	if err != nil {
+		return err
+	}
+
+	for i := range secrets {
+		secret := &secrets[i]
+		if err := r.client.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete bootstrap token secret %s: %w", secret.Name, err)
+		}
+
+		r.metrics.BootstrapTokensRevoked.WithLabelValues(reason).Inc()
+		r.metrics.BootstrapTokenAge.Observe(time.Since(secret.CreationTimestamp.Time).Seconds())
+		log.Infow("Revoked bootstrap token", "secret", secret.Name, "reason", reason)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) bootstrapTokenSecrets(ctx context.Context, machine *clusterv1alpha1.Machine) ([]corev1.Secret, error) {
+	secrets := &corev1.SecretList{}
+	if err := r.client.List(ctx, secrets,
+		ctrlruntimeclient.InNamespace(metav1.NamespaceSystem),
+		ctrlruntimeclient.MatchingLabels{BootstrapTokenMachineUIDLabel: string(machine.UID)},
+	); err != nil {
+		return nil, fmt.Errorf("failed to list bootstrap token secrets: %w", err)
+	}
+
+	var tokens []corev1.Secret
+	for _, secret := range secrets.Items {
+		if secret.Type == corev1.SecretTypeBootstrapToken && secret.DeletionTimestamp == nil {
+			tokens = append(tokens, secret)
+		}
+	}
+	return tokens, nil
+}
+
+// injectBootstrapToken replaces the bootstrap token placeholder of the userdata with the token.
+func injectBootstrapToken(userdata, token string) string {
+	userdata = strings.ReplaceAll(userdata, bootstrap.BootstrapTokenPlaceholder, token)
+	// Data is HTML Encoded for ignition.
+	return strings.ReplaceAll(userdata, url.QueryEscape(bootstrap.BootstrapTokenPlaceholder), url.QueryEscape(token))
+}
+
+func hasBootstrapTokenPlaceholder(userdata string) bool {
+	return strings.Contains(userdata, bootstrap.BootstrapTokenPlaceholder) ||
+		strings.Contains(userdata, url.QueryEscape(bootstrap.BootstrapTokenPlaceholder))
+}
+
+func randomBootstrapTokenString(length int) (string, error) {
+	charsetSize := big.NewInt(int64(len(bootstrapTokenCharset)))
+	token := make([]byte, length)
+	for i := range token {
+		n, err := rand.Int(rand.Reader, charsetSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate bootstrap token: %w", err)
+		}
+		token[i] = bootstrapTokenCharset[n.Int64()]
+	}
+	return string(token), nil
+}
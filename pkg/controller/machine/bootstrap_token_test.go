@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+	"k8c.io/machine-controller/sdk/bootstrap"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestBootstrapTokenLifecycle(t *testing.T) {
+	ctx := context.Background()
+	log := zap.NewNop().Sugar()
+
+	machine := &clusterv1alpha1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "machine", Namespace: metav1.NamespaceSystem, UID: "machine-uid"},
+	}
+	otherMachine := &clusterv1alpha1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: metav1.NamespaceSystem, UID: "other-uid"},
+	}
+
+	reconciler := &Reconciler{
+		client:            fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).Build(),
+		metrics:           NewMachineControllerMetrics(),
+		bootstrapTokenTTL: time.Hour,
+	}
+
+	token, err := reconciler.bootstrapTokenForMachine(ctx, log, machine)
+	if err != nil {
+		t.Fatalf("failed to create bootstrap token: %v", err)
+	}
+	if !regexp.MustCompile(`^[a-z0-9]{6}\.[a-z0-9]{16}$`).MatchString(token) {
+		t.Fatalf("invalid bootstrap token %q", token)
+	}
+
+	reused, err := reconciler.bootstrapTokenForMachine(ctx, log, machine)
+	if err != nil {
+		t.Fatalf("failed to get bootstrap token: %v", err)
+	}
+	if reused != token {
+		t.Errorf("expected the valid token %q to be reused, got %q", token, reused)
+	}
+
+	other, err := reconciler.bootstrapTokenForMachine(ctx, log, otherMachine)
+	if err != nil {
+		t.Fatalf("failed to create bootstrap token: %v", err)
+	}
+	if other == token {
+		t.Error("expected machines not to share bootstrap tokens")
+	}
+
+	secrets, err := reconciler.bootstrapTokenSecrets(ctx, machine)
+	if err != nil {
+		t.Fatalf("failed to list bootstrap tokens: %v", err)
+	}
+	if len(secrets) != 1 {
+		t.Fatalf("expected one bootstrap token for the machine, got %d", len(secrets))
+	}
+	if secrets[0].Type != corev1.SecretTypeBootstrapToken || secrets[0].Name != bootstrapTokenSecretPrefix+token[:bootstrapTokenIDLength] {
+		t.Errorf("unexpected bootstrap token secret %s of type %s", secrets[0].Name, secrets[0].Type)
+	}
+
+	if err := reconciler.revokeBootstrapTokens(ctx, log, machine, bootstrapTokenRevokedJoined); err != nil {
+		t.Fatalf("failed to revoke bootstrap tokens: %v", err)
+	}
+	if secrets, _ := reconciler.bootstrapTokenSecrets(ctx, machine); len(secrets) != 0 {
+		t.Errorf("expected bootstrap tokens of the machine to be revoked, got %d", len(secrets))
+	}
+	if secrets, _ := reconciler.bootstrapTokenSecrets(ctx, otherMachine); len(secrets) != 1 {
+		t.Errorf("expected bootstrap token of the other machine to be kept, got %d", len(secrets))
+	}
+}
+
+func TestInjectBootstrapToken(t *testing.T) {
+	userdata := "token: " + bootstrap.BootstrapTokenPlaceholder + "\nignition: " + url.QueryEscape(bootstrap.BootstrapTokenPlaceholder)
+	if !hasBootstrapTokenPlaceholder(userdata) {
+		t.Fatal("expected userdata to contain the placeholder")
+	}
+
+	injected := injectBootstrapToken(userdata, "abcdef.0123456789abcdef")
+	if expected := "token: abcdef.0123456789abcdef\nignition: abcdef.0123456789abcdef"; injected != expected {
+		t.Errorf("expected %q, got %q", expected, injected)
+	}
+	if hasBootstrapTokenPlaceholder(injected) {
+		t.Error("expected all placeholders to be replaced")
+	}
+}
@@ -31,19 +31,18 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
+	"k8c.io/machine-controller/pkg/bootstrap"
 	"k8c.io/machine-controller/pkg/cloudprovider"
+	cloudprovidercache "k8c.io/machine-controller/pkg/cloudprovider/cache"
 	cloudprovidererrors "k8c.io/machine-controller/pkg/cloudprovider/errors"
 	"k8c.io/machine-controller/pkg/cloudprovider/instance"
 	cloudprovidertypes "k8c.io/machine-controller/pkg/cloudprovider/types"
-	"k8c.io/machine-controller/pkg/cloudprovider/util"
-	controllerutil "k8c.io/machine-controller/pkg/controller/util"
 	kuberneteshelper "k8c.io/machine-controller/pkg/kubernetes"
 	"k8c.io/machine-controller/pkg/node/eviction"
 	"k8c.io/machine-controller/pkg/node/poddeletion"
 	"k8c.io/machine-controller/pkg/rhsm"
 	"k8c.io/machine-controller/sdk/apis/cluster/common"
 	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
-	"k8c.io/machine-controller/sdk/bootstrap"
 	"k8c.io/machine-controller/sdk/providerconfig"
 	"k8c.io/machine-controller/sdk/providerconfig/configvar"
 	"k8c.io/machine-controller/sdk/userdata/rhel"
@@ -112,13 +111,17 @@ type Reconciler struct {
 	joinClusterTimeout               *time.Duration
 	name                             string
 	bootstrapTokenServiceAccountName *types.NamespacedName
+	bootstrapTokenTTL                time.Duration
 	skipEvictionAfter                time.Duration
+	drainPolicy                      eviction.Policy
+	volumeDetachProviders            sets.Set[providerconfig.CloudProvider]
 	nodeSettings                     NodeSettings
 	redhatSubscriptionManager        rhsm.RedHatSubscriptionManager
 	satelliteSubscriptionManager     rhsm.SatelliteSubscriptionManager
 
 	nodePortRange                     string
 	overrideBootstrapKubeletAPIServer string
+	allowCrossNamespaceRefs           bool
 }
 
 type NodeSettings struct {
@@ -151,10 +154,14 @@ type KubeconfigProvider interface {
 // MetricsCollection is a struct of all metrics used in
 // this controller.
 type MetricsCollection struct {
-	Workers        prometheus.Gauge
-	Errors         prometheus.Counter
-	Provisioning   prometheus.Histogram
-	Deprovisioning prometheus.Histogram
+	Workers                prometheus.Gauge
+	Errors                 prometheus.Counter
+	Provisioning           prometheus.Histogram
+	Deprovisioning         prometheus.Histogram
+	DrainDuration          *prometheus.HistogramVec
+	BootstrapTokensCreated prometheus.Counter
+	BootstrapTokensRevoked *prometheus.CounterVec
+	BootstrapTokenAge      prometheus.Histogram
 }
 
 func (mc *MetricsCollection) MustRegister(registerer prometheus.Registerer) {
@@ -163,6 +170,10 @@ func (mc *MetricsCollection) MustRegister(registerer prometheus.Registerer) {
 		mc.Workers,
 		mc.Provisioning,
 		mc.Deprovisioning,
+		mc.DrainDuration,
+		mc.BootstrapTokensCreated,
+		mc.BootstrapTokensRevoked,
+		mc.BootstrapTokenAge,
 	)
 }
 
@@ -178,10 +189,14 @@ func Add(
 	joinClusterTimeout *time.Duration,
 	name string,
 	bootstrapTokenServiceAccountName *types.NamespacedName,
+	bootstrapTokenTTL time.Duration,
 	skipEvictionAfter time.Duration,
+	drainPolicy eviction.Policy,
+	volumeDetachProviders []providerconfig.CloudProvider,
 	nodeSettings NodeSettings,
 	nodePortRange string,
 	overrideBootstrapKubeletAPIServer string,
+	allowCrossNamespaceRefs bool,
 ) error {
 	reconciler := &Reconciler{
 		log:                              log.Named(ControllerName),
@@ -194,13 +209,17 @@ func Add(
 		joinClusterTimeout:               joinClusterTimeout,
 		name:                             name,
 		bootstrapTokenServiceAccountName: bootstrapTokenServiceAccountName,
+		bootstrapTokenTTL:                bootstrapTokenTTL,
 		skipEvictionAfter:                skipEvictionAfter,
+		drainPolicy:                      drainPolicy,
+		volumeDetachProviders:            sets.New(volumeDetachProviders...),
 		nodeSettings:                     nodeSettings,
 		redhatSubscriptionManager:        rhsm.NewRedHatSubscriptionManager(log),
 		satelliteSubscriptionManager:     rhsm.NewSatelliteSubscriptionManager(log),
 
 		nodePortRange:                     nodePortRange,
 		overrideBootstrapKubeletAPIServer: overrideBootstrapKubeletAPIServer,
+		allowCrossNamespaceRefs:           allowCrossNamespaceRefs,
 	}
 	utilruntime.ErrorHandlers = append(utilruntime.ErrorHandlers, func(context.Context, error, string, ...interface{}) {
 		reconciler.metrics.Errors.Add(1)
@@ -208,6 +227,10 @@ func Add(
 
 	metrics.Workers.Set(float64(numWorkers))
 
+	if err := poddeletion.IndexVolumeAttachments(ctx, mgr.GetFieldIndexer()); err != nil {
+		return fmt.Errorf("failed to index VolumeAttachments: %w", err)
+	}
+
 	nodePredicate := predicate.Funcs{UpdateFunc: func(e event.UpdateEvent) bool {
 		oldNode := e.ObjectOld.(*corev1.Node)
 		newNode := e.ObjectNew.(*corev1.Node)
@@ -242,6 +265,10 @@ func Add(
 		}).
 		For(&clusterv1alpha1.Machine{}).
 		Watches(&corev1.Node{}, enqueueRequestsForNodes(ctx, log, mgr), builder.WithPredicates(nodePredicate)).
+		Watches(&corev1.Secret{}, enqueueRequestsForBootstrapSecrets(ctx, mgr.GetClient()), builder.WithPredicates(bootstrapSecretPredicate)).
+		Watches(&corev1.Secret{}, enqueueRequestsForCredentials(ctx, mgr.GetClient(), cloudprovidercache.KindSecret), builder.WithPredicates(credentialsPredicate)).
+		Watches(&corev1.ConfigMap{}, enqueueRequestsForCredentials(ctx, mgr.GetClient(), cloudprovidercache.KindConfigMap), builder.WithPredicates(credentialsPredicate)).
+		Watches(&clusterv1alpha1.ProviderCredentials{}, enqueueRequestsForCredentials(ctx, mgr.GetClient(), cloudprovidercache.KindProviderCredentials), builder.WithPredicates(credentialsPredicate)).
 		Build(reconciler)
 
 	return err
@@ -390,6 +417,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	} else {
 		r.clearMachineError(machine)
 	}
+	r.updateCredentialsCondition(machine, err)
 
 	if result == nil {
 		result = &reconcile.Result{}
@@ -410,7 +438,10 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, mach
 	if err != nil {
 		return nil, fmt.Errorf("failed to get provider config: %w", err)
 	}
-	configResolver := configvar.NewResolver(ctx, r.client)
+	configResolver := configvar.NewResolver(ctx, r.client, append(
+		configvar.ForConfig(machine.Namespace, providerConfig, r.allowCrossNamespaceRefs),
+		configvar.WithAccessor(fmt.Sprintf("Machine %s/%s", machine.Namespace, machine.Name)),
+	)...)
 	prov, err := cloudprovider.ForProvider(providerConfig.CloudProvider, configResolver)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cloud provider %q: %w", providerConfig.CloudProvider, err)
@@ -449,6 +480,13 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, mach
 		if err := r.ensureMachineHasNodeReadyCondition(machine); err != nil {
 			return nil, fmt.Errorf("failed to set nodeReady condition on machine: %w", err)
 		}
+		// The node joined, so its bootstrap token is no longer needed.
+		if err := r.revokeBootstrapTokens(ctx, nodeLog, machine, bootstrapTokenRevokedJoined); err != nil {
+			return nil, err
+		}
+		if err := bootstrap.DeleteStagedUserdata(ctx, r.client, machine); err != nil {
+			return nil, err
+		}
 	} else {
 		if r.nodeSettings.ExternalCloudProvider {
 			return r.handleNodeFailuresWithExternalCCM(ctx, log, prov, providerConfig, node, machine)
@@ -506,9 +544,15 @@ func (r *Reconciler) machineHasValidNode(ctx context.Context, machine *clusterv1
 	return true, nil
 }
 
-func (r *Reconciler) shouldCleanupVolumes(ctx context.Context, log *zap.SugaredLogger, machine *clusterv1alpha1.Machine, providerName providerconfig.CloudProvider) (bool, error) {
-	// we need to wait for volumeAttachments clean up only for vSphere
-	if providerName != providerconfig.CloudProviderVsphere {
+// shouldCleanupVolumes returns true if the instance may only be deleted once all volumes were
+// detached from its node. This is configured per cloud provider and can be overridden by the
+// drain policy of the MachineDeployment.
+func (r *Reconciler) shouldCleanupVolumes(ctx context.Context, log *zap.SugaredLogger, machine *clusterv1alpha1.Machine, machineDeployment *clusterv1alpha1.MachineDeployment, providerName providerconfig.CloudProvider) (bool, error) {
+	waitForVolumeDetach := r.volumeDetachProviders.Has(providerName)
+	if machineDeployment != nil && machineDeployment.Spec.DrainPolicy != nil && machineDeployment.Spec.DrainPolicy.WaitForVolumeDetach != nil {
+		waitForVolumeDetach = *machineDeployment.Spec.DrainPolicy.WaitForVolumeDetach
+	}
+	if !waitForVolumeDetach {
 		return false, nil
 	}
 
@@ -590,21 +634,59 @@ func (r *Reconciler) deleteMachine(
 		err         error
 	)
 
+	if err := r.revokeBootstrapTokens(ctx, log, machine, bootstrapTokenRevokedDeleted); err != nil {
+		return nil, err
+	}
+	if err := bootstrap.DeleteStagedUserdata(ctx, r.client, machine); err != nil {
+		return nil, err
+	}
+
 	if !skipEviction {
 		shouldEvict, err = r.shouldEvict(ctx, log, machine)
 		if err != nil {
 			return nil, err
 		}
 	}
-	shouldCleanUpVolumes, err := r.shouldCleanupVolumes(ctx, log, machine, providerName)
+	machineDeployment, err := r.machineDeploymentForMachine(ctx, log, machine)
+	if err != nil {
+		return nil, err
+	}
+	shouldCleanUpVolumes, err := r.shouldCleanupVolumes(ctx, log, machine, machineDeployment, providerName)
 	if err != nil {
 		return nil, err
 	}
 
+	// Nodes which are deleted without being drained still get the pre-drain taint, so workloads
+	// can prepare for the node going away.
+	if skipEviction && machine.Status.NodeRef != nil {
+		hasNode, err := r.machineHasValidNode(ctx, machine)
+		if err != nil {
+			return nil, err
+		}
+		if hasNode {
+			waiting, err := eviction.New(machine.Status.NodeRef.Name, r.client, r.kubeClient, r.drainPolicyForMachine(log, machineDeployment), time.Time{}).PreDrain(ctx, log)
+			if err != nil {
+				return nil, err
+			}
+			if waiting {
+				return &reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+			}
+		}
+	}
+
 	var evictedSomething, deletedSomething bool
 	var volumesFree = true
 	if shouldEvict {
-		evictedSomething, err = eviction.New(machine.Status.NodeRef.Name, r.client, r.kubeClient).Run(ctx, log)
+		drainStart := time.Now()
+		if machine.Status.Drain != nil {
+			drainStart = machine.Status.Drain.StartTime.Time
+		}
+
+		var progress eviction.Progress
+		evictedSomething, progress, err = eviction.New(machine.Status.NodeRef.Name, r.client, r.kubeClient, r.drainPolicyForMachine(log, machineDeployment), drainStart).Run(ctx, log)
+		if updateErr := r.updateDrainStatus(machine, machineDeployment, drainStart, evictedSomething || err != nil, progress); updateErr != nil {
+			return nil, fmt.Errorf("failed to update drain status: %w", updateErr)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to evict node %s: %w", machine.Status.NodeRef.Name, err)
 		}
@@ -800,32 +882,26 @@ func (r *Reconciler) ensureInstanceExistsForMachine(
 		if errors.Is(err, cloudprovidererrors.ErrInstanceNotFound) {
 			log.Debug("Validated machine spec")
 
-			// Here we do stuff!
-			var userdata string
-			referencedMachineDeployment, machineDeploymentRevision, err := controllerutil.GetMachineDeploymentNameAndRevisionForMachine(ctx, machine, r.client)
+			// The machine is reconciled again by the bootstrap secret watch once the secret is ready.
+			userdata, ready, err := r.machineUserdata(ctx, log, machine)
 			if err != nil {
-				return nil, fmt.Errorf("failed to find machine's MachineDployment: %w", err)
+				message := fmt.Sprintf("%v. Failed to get the userdata.", err)
+				return nil, r.updateMachineErrorIfTerminalError(machine, common.InvalidConfigurationMachineError, message, err, "failed to get userdata")
 			}
-
-			bootstrapSecretName := fmt.Sprintf(bootstrap.CloudConfigSecretNamePattern,
-				referencedMachineDeployment,
-				machine.Namespace,
-				bootstrap.BootstrapCloudConfig)
-
-			bootstrapSecret := &corev1.Secret{}
-			if err := r.client.Get(ctx,
-				types.NamespacedName{Name: bootstrapSecretName, Namespace: util.CloudInitNamespace},
-				bootstrapSecret); err != nil {
-				log.Errorw("cloud-init configuration: cloud config is not ready yet", "secret", bootstrap.BootstrapCloudConfig)
-				return &reconcile.Result{RequeueAfter: 3 * time.Second}, nil
+			if !ready {
+				return nil, nil
 			}
-
-			bootstrapSecretRevision := bootstrapSecret.Annotations[bootstrap.MachineDeploymentRevision]
-			if bootstrapSecretRevision != machineDeploymentRevision {
-				return nil, fmt.Errorf("cloud-init configuration: cloud config %q is not ready yet", bootstrap.BootstrapCloudConfig)
+			if r.bootstrapTokenTTL > 0 && hasBootstrapTokenPlaceholder(userdata) {
+				token, err := r.bootstrapTokenForMachine(ctx, log, machine)
+				if err != nil {
+					return nil, err
+				}
+				userdata = injectBootstrapToken(userdata, token)
+			}
+			if userdata, err = bootstrap.EncodeUserdata(ctx, log, r.client, machine, providerConfig, userdata); err != nil {
+				message := fmt.Sprintf("%v. Failed to encode the userdata.", err)
+				return nil, r.updateMachineErrorIfTerminalError(machine, common.InvalidConfigurationMachineError, message, err, "failed to encode userdata")
 			}
-
-			userdata = getOSMBootstrapUserdata(machine.Spec.Name, *bootstrapSecret)
 
 			// Create the instance
 			if _, err = r.createProviderInstance(ctx, log, prov, machine, userdata); err != nil {
@@ -26,16 +26,21 @@ import (
 	"go.uber.org/zap"
 
 	"k8c.io/machine-controller/pkg/cloudprovider/instance"
+	"k8c.io/machine-controller/pkg/cloudprovider/provider/fake"
 	cloudprovidertypes "k8c.io/machine-controller/pkg/cloudprovider/types"
+	"k8c.io/machine-controller/pkg/node/eviction"
 	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+	nodetypes "k8c.io/machine-controller/sdk/node"
 	providerconfigtypes "k8c.io/machine-controller/sdk/providerconfig"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	kubefake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
+	ccmapi "k8s.io/cloud-provider/api"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -707,3 +712,125 @@ func TestControllerFindNodeByProviderID(t *testing.T) {
 		})
 	}
 }
+
+func TestControllerHandleNodeFailuresWithExternalCCM(t *testing.T) {
+	shutdownTaint := corev1.Taint{Key: ccmapi.TaintNodeShutdown, Effect: corev1.TaintEffectNoSchedule}
+
+	tests := []struct {
+		name          string
+		cloudProvider providerconfigtypes.CloudProvider
+		taints        []corev1.Taint
+		drainPolicy   eviction.Policy
+		// nodeDeleted is true if the node gets deleted without being drained.
+		nodeDeleted bool
+		// preDrainTainted is true if the node gets the pre-drain taint.
+		preDrainTainted bool
+	}{
+		{
+			name:          "shut down kubevirt node is deleted without eviction",
+			cloudProvider: providerconfigtypes.CloudProviderKubeVirt,
+			taints:        []corev1.Taint{shutdownTaint},
+			drainPolicy:   eviction.DefaultPolicy(),
+			nodeDeleted:   true,
+		},
+		{
+			name:            "shut down kubevirt node gets the pre-drain taint before it is deleted",
+			cloudProvider:   providerconfigtypes.CloudProviderKubeVirt,
+			taints:          []corev1.Taint{shutdownTaint},
+			drainPolicy:     eviction.Policy{GracePeriodSeconds: -1, PreDrainTaintEffect: corev1.TaintEffectNoExecute, PreDrainDelay: time.Hour},
+			preDrainTainted: true,
+		},
+		{
+			name:          "running kubevirt node is not deleted",
+			cloudProvider: providerconfigtypes.CloudProviderKubeVirt,
+			drainPolicy:   eviction.DefaultPolicy(),
+		},
+		{
+			name:          "shut down aws node is left to the ccm",
+			cloudProvider: providerconfigtypes.CloudProviderAWS,
+			taints:        []corev1.Taint{shutdownTaint},
+			drainPolicy:   eviction.DefaultPolicy(),
+		},
+	}
+
+	log := zap.NewNop().Sugar()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			machine := &clusterv1alpha1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "machine-1",
+					Namespace:         metav1.NamespaceSystem,
+					Finalizers:        []string{FinalizerDeleteNode},
+					DeletionTimestamp: &metav1.Time{Time: time.Now()},
+				},
+				Status: clusterv1alpha1.MachineStatus{
+					NodeRef: &corev1.ObjectReference{Name: "node-1"},
+				},
+			}
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+				Spec:       corev1.NodeSpec{Taints: test.taints},
+			}
+			// Pods could be evicted to the other node, so a drain would find the pod and wait
+			// for its eviction instead of deleting the node.
+			otherNode := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: metav1.NamespaceDefault},
+				Spec:       corev1.PodSpec{NodeName: node.Name},
+			}
+
+			client := fakectrlruntimeclient.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(machine, node, otherNode).
+				Build()
+
+			reconciler := &Reconciler{
+				client:      client,
+				kubeClient:  kubefake.NewSimpleClientset(pod),
+				recorder:    &record.FakeRecorder{},
+				metrics:     NewMachineControllerMetrics(),
+				drainPolicy: test.drainPolicy,
+				// Machines would be drained if the KubeVirt shutdown path did not skip the eviction.
+				skipEvictionAfter: time.Hour,
+				providerData: &cloudprovidertypes.ProviderData{
+					Ctx:    ctx,
+					Update: cloudprovidertypes.GetMachineUpdater(ctx, client),
+					Client: client,
+				},
+			}
+
+			providerConfig := &providerconfigtypes.Config{CloudProvider: test.cloudProvider}
+			if _, err := reconciler.handleNodeFailuresWithExternalCCM(ctx, log, fake.New(nil), providerConfig, node, machine); err != nil {
+				t.Fatalf("failed to handle node failure: %v", err)
+			}
+
+			current := &corev1.Node{}
+			err := client.Get(ctx, types.NamespacedName{Name: node.Name}, current)
+			if test.nodeDeleted {
+				if !apierrors.IsNotFound(err) {
+					t.Fatalf("expected node to be deleted, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected node to exist, got %v", err)
+			}
+			if current.Spec.Unschedulable {
+				t.Error("expected node not to be cordoned for a drain")
+			}
+
+			preDrainTainted := false
+			for _, taint := range current.Spec.Taints {
+				if taint.Key == nodetypes.ToBeDeletedTaintKey {
+					preDrainTainted = true
+				}
+			}
+			if preDrainTainted != test.preDrainTainted {
+				t.Errorf("expected pre-drain taint: %t, got %t", test.preDrainTainted, preDrainTainted)
+			}
+		})
+	}
+}
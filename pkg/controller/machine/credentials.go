@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"k8c.io/machine-controller/pkg/cloudprovider"
+	cloudprovidercache "k8c.io/machine-controller/pkg/cloudprovider/cache"
+	cloudprovidererrors "k8c.io/machine-controller/pkg/cloudprovider/errors"
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// credentialsPredicate passes changes of the content of Secrets, ConfigMaps and ProviderCredentials,
+// but not the objects listed when the watch is started.
+var credentialsPredicate = predicate.Funcs{
+	CreateFunc: func(event.CreateEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return e.ObjectOld.GetResourceVersion() != e.ObjectNew.GetResourceVersion()
+	},
+	DeleteFunc:  func(event.DeleteEvent) bool { return true },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+}
+
+// credentialsRejected tells whether the cloud provider rejected the credentials of the machine
+// the last time they were used.
+func credentialsRejected(machine *clusterv1alpha1.Machine) bool {
+	for _, condition := range machine.Status.Conditions {
+		if condition.Type == clusterv1alpha1.MachineCredentialsValidCondition {
+			return condition.Status == corev1.ConditionFalse
+		}
+	}
+	return false
+}
+
+// updateCredentialsCondition records in the CredentialsValid condition whether the cloud provider
+// rejected the credentials of the machine. The condition is only added once the credentials were
+// rejected. It does not return an error as it's used around the sync handler.
+func (r *Reconciler) updateCredentialsCondition(machine *clusterv1alpha1.Machine, reconcileErr error) {
+	var condition corev1.NodeCondition
+	switch {
+	case cloudprovidererrors.IsInvalidCredentials(reconcileErr):
+		message := reconcileErr.Error()
+		if ok, _, terminalMessage := cloudprovidererrors.IsTerminalError(reconcileErr); ok {
+			message = terminalMessage
+		}
+		condition = corev1.NodeCondition{
+			Type:    clusterv1alpha1.MachineCredentialsValidCondition,
+			Status:  corev1.ConditionFalse,
+			Reason:  clusterv1alpha1.CredentialsRejectedReason,
+			Message: message,
+		}
+	case reconcileErr == nil && credentialsRejected(machine):
+		condition = corev1.NodeCondition{
+			Type:    clusterv1alpha1.MachineCredentialsValidCondition,
+			Status:  corev1.ConditionTrue,
+			Reason:  clusterv1alpha1.CredentialsAcceptedReason,
+			Message: "The cloud provider accepted the credentials",
+		}
+	default:
+		return
+	}
+
+	if err := r.ensureMachineCondition(machine, condition); err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to update machine: %w", err))
+	}
+}
+
+// enqueueRequestsForCredentials enqueues the machines whose credentials were rejected and which
+// reference the changed Secret, ConfigMap or ProviderCredentials, so rotated credentials are used
+// without waiting for the backoff of the failed reconciliation.
+func enqueueRequestsForCredentials(ctx context.Context, client ctrlruntimeclient.Client, kind string) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(_ context.Context, obj ctrlruntimeclient.Object) []reconcile.Request {
+		refs, err := cloudprovider.CredentialReferences(ctx, client, kind, obj)
+		if err != nil {
+			utilruntime.HandleError(fmt.Errorf("failed to get references of %s %s: %w", kind, ctrlruntimeclient.ObjectKeyFromObject(obj), err))
+			return nil
+		}
+
+		machines := &clusterv1alpha1.MachineList{}
+		if err := client.List(ctx, machines); err != nil {
+			utilruntime.HandleError(fmt.Errorf("failed to list machines: %w", err))
+			return nil
+		}
+
+		var result []reconcile.Request
+		for _, machine := range machines.Items {
+			if !credentialsRejected(&machine) {
+				continue
+			}
+
+			machineRefs := cloudprovidercache.References(machine.Spec)
+			if slices.ContainsFunc(refs, func(ref cloudprovidercache.Reference) bool { return slices.Contains(machineRefs, ref) }) {
+				result = append(result, reconcile.Request{NamespacedName: types.NamespacedName{
+					Namespace: machine.Namespace,
+					Name:      machine.Name,
+				}})
+			}
+		}
+		return result
+	})
+}
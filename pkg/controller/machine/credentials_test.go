@@ -0,0 +1,191 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	cloudprovidercache "k8c.io/machine-controller/pkg/cloudprovider/cache"
+	cloudprovidererrors "k8c.io/machine-controller/pkg/cloudprovider/errors"
+	cloudprovidertypes "k8c.io/machine-controller/pkg/cloudprovider/types"
+	"k8c.io/machine-controller/sdk/apis/cluster/common"
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/workqueue"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestUpdateCredentialsCondition(t *testing.T) {
+	ctx := context.Background()
+
+	rejected := cloudprovidererrors.TerminalError{
+		Reason:  common.InvalidConfigurationMachineError,
+		Message: "A request has been rejected due to invalid credentials",
+		Err:     cloudprovidererrors.ErrInvalidCredentials,
+	}
+
+	testCases := []struct {
+		name            string
+		conditions      []corev1.NodeCondition
+		err             error
+		expectCondition bool
+		expectedStatus  corev1.ConditionStatus
+		expectedReason  string
+	}{
+		{
+			name: "successful reconciliation without rejected credentials",
+		},
+		{
+			name: "other error",
+			err:  errors.New("quota exceeded"),
+		},
+		{
+			name:            "rejected credentials",
+			err:             fmt.Errorf("failed to create instance: %w", rejected),
+			expectCondition: true,
+			expectedStatus:  corev1.ConditionFalse,
+			expectedReason:  clusterv1alpha1.CredentialsRejectedReason,
+		},
+		{
+			name: "accepted after rotation",
+			conditions: []corev1.NodeCondition{{
+				Type:   clusterv1alpha1.MachineCredentialsValidCondition,
+				Status: corev1.ConditionFalse,
+				Reason: clusterv1alpha1.CredentialsRejectedReason,
+			}},
+			expectCondition: true,
+			expectedStatus:  corev1.ConditionTrue,
+			expectedReason:  clusterv1alpha1.CredentialsAcceptedReason,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			machine := &clusterv1alpha1.Machine{
+				ObjectMeta: metav1.ObjectMeta{Name: "machine", Namespace: metav1.NamespaceSystem},
+				Status:     clusterv1alpha1.MachineStatus{Conditions: tc.conditions},
+			}
+			client := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(machine).Build()
+			reconciler := &Reconciler{
+				client: client,
+				providerData: &cloudprovidertypes.ProviderData{
+					Ctx:    ctx,
+					Update: cloudprovidertypes.GetMachineUpdater(ctx, client),
+					Client: client,
+				},
+			}
+
+			reconciler.updateCredentialsCondition(machine, tc.err)
+
+			if !tc.expectCondition {
+				if len(machine.Status.Conditions) != 0 {
+					t.Fatalf("expected no condition, got %v", machine.Status.Conditions)
+				}
+				return
+			}
+			if len(machine.Status.Conditions) != 1 {
+				t.Fatalf("expected one condition, got %v", machine.Status.Conditions)
+			}
+			condition := machine.Status.Conditions[0]
+			if condition.Type != clusterv1alpha1.MachineCredentialsValidCondition || condition.Status != tc.expectedStatus || condition.Reason != tc.expectedReason {
+				t.Errorf("expected condition %s=%s with reason %s, got %s=%s with reason %s",
+					clusterv1alpha1.MachineCredentialsValidCondition, tc.expectedStatus, tc.expectedReason, condition.Type, condition.Status, condition.Reason)
+			}
+		})
+	}
+}
+
+func TestEnqueueRequestsForCredentials(t *testing.T) {
+	ctx := context.Background()
+
+	rejectedCondition := []corev1.NodeCondition{{
+		Type:   clusterv1alpha1.MachineCredentialsValidCondition,
+		Status: corev1.ConditionFalse,
+		Reason: clusterv1alpha1.CredentialsRejectedReason,
+	}}
+	newMachine := func(name, providerSpec string, conditions []corev1.NodeCondition) *clusterv1alpha1.Machine {
+		machine := &clusterv1alpha1.Machine{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceSystem},
+			Status:     clusterv1alpha1.MachineStatus{Conditions: conditions},
+		}
+		machine.Spec.ProviderSpec.Value = &runtime.RawExtension{Raw: []byte(providerSpec)}
+		return machine
+	}
+
+	secretRef := `{"cloudProviderSpec":{"token":{"secretKeyRef":{"namespace":"kube-system","name":"cloud","key":"token"}}}}`
+	credentialsRef := `{"credentialsRef":{"name":"team-a"},"cloudProviderSpec":{}}`
+
+	client := fakectrlruntimeclient.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(
+			&clusterv1alpha1.ProviderCredentials{
+				ObjectMeta: metav1.ObjectMeta{Name: "team-a"},
+				Spec: clusterv1alpha1.ProviderCredentialsSpec{
+					Type:      "hetzner",
+					SecretRef: corev1.SecretReference{Namespace: metav1.NamespaceSystem, Name: "team-a"},
+				},
+			},
+			newMachine("rejected-secret", secretRef, rejectedCondition),
+			newMachine("accepted-secret", secretRef, nil),
+			newMachine("rejected-credentials", credentialsRef, rejectedCondition),
+		).
+		Build()
+
+	testCases := []struct {
+		name     string
+		secret   string
+		expected string
+	}{
+		{
+			name:     "referenced by secretKeyRef",
+			secret:   "cloud",
+			expected: "rejected-secret",
+		},
+		{
+			name:     "referenced by ProviderCredentials",
+			secret:   "team-a",
+			expected: "rejected-credentials",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+			defer queue.ShutDown()
+
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: tc.secret, Namespace: metav1.NamespaceSystem}}
+			enqueueRequestsForCredentials(ctx, client, cloudprovidercache.KindSecret).Update(ctx, event.UpdateEvent{ObjectOld: secret, ObjectNew: secret}, queue)
+
+			if queue.Len() != 1 {
+				t.Fatalf("expected one machine to be enqueued, got %d", queue.Len())
+			}
+			request, _ := queue.Get()
+			if request.Name != tc.expected {
+				t.Errorf("expected machine %q to be enqueued, got %q", tc.expected, request.Name)
+			}
+		})
+	}
+}
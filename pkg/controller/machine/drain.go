@@ -0,0 +1,201 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	controllerutil "k8c.io/machine-controller/pkg/controller/util"
+	"k8c.io/machine-controller/pkg/node/eviction"
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// machineDeploymentForMachine returns the MachineDeployment the machine belongs to or nil, if it
+// does not belong to one. Errors retrieving it are returned, so the machine is requeued instead of
+// falling back to the defaults of machines without a MachineDeployment.
+func (r *Reconciler) machineDeploymentForMachine(ctx context.Context, log *zap.SugaredLogger, machine *clusterv1alpha1.Machine) (*clusterv1alpha1.MachineDeployment, error) {
+	machineDeploymentName, _, err := controllerutil.GetMachineDeploymentNameAndRevisionForMachine(ctx, machine, r.client)
+	if err != nil {
+		var status apierrors.APIStatus
+		if errors.As(err, &status) && !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get MachineSet of the machine: %w", err)
+		}
+		log.Debugw("MachineDeployment of the machine could not be determined", zap.Error(err))
+		return nil, nil
+	}
+
+	machineDeployment := &clusterv1alpha1.MachineDeployment{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: machine.Namespace, Name: machineDeploymentName}, machineDeployment); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get MachineDeployment %s: %w", machineDeploymentName, err)
+		}
+		log.Debugw("MachineDeployment of the machine does not exist", "machinedeployment", machineDeploymentName)
+		return nil, nil
+	}
+
+	return machineDeployment, nil
+}
+
+// drainPolicyForMachine returns the drain policy of the controller, overridden by the one of the
+// MachineDeployment the machine belongs to.
+func (r *Reconciler) drainPolicyForMachine(log *zap.SugaredLogger, machineDeployment *clusterv1alpha1.MachineDeployment) eviction.Policy {
+	if machineDeployment == nil {
+		return r.drainPolicy
+	}
+
+	policy, err := r.drainPolicy.WithOverride(machineDeployment.Spec.DrainPolicy)
+	if err != nil {
+		log.Errorw("Using the default drain policy, as the one of the MachineDeployment is invalid", "machinedeployment", machineDeployment.Name, zap.Error(err))
+		return r.drainPolicy
+	}
+
+	return policy
+}
+
+// updateDrainStatus records the progress of the drain on the machine and emits events when
+// the drain starts, gets blocked by PodDisruptionBudgets and completes.
+func (r *Reconciler) updateDrainStatus(machine *clusterv1alpha1.Machine, machineDeployment *clusterv1alpha1.MachineDeployment, start time.Time, draining bool, progress eviction.Progress) error {
+	previous := machine.Status.Drain
+	if previous != nil && previous.CompletionTime != nil {
+		return nil
+	}
+
+	status := &clusterv1alpha1.MachineDrainStatus{
+		StartTime:        metav1.NewTime(start),
+		PodsRemaining:    int32(progress.PodsRemaining),
+		PodsBlockedByPDB: int32(progress.PodsBlockedByPDB),
+	}
+	condition := drainingCondition(draining, progress)
+	if previous != nil && draining && previous.PodsRemaining == status.PodsRemaining && previous.PodsBlockedByPDB == status.PodsBlockedByPDB &&
+		machineConditionReason(machine.Status.Conditions, condition.Type) == condition.Reason {
+		return nil
+	}
+
+	nodeName := machine.Status.NodeRef.Name
+
+	switch {
+	case !draining:
+		now := metav1.Now()
+		status.CompletionTime = &now
+
+		duration := now.Sub(start)
+		machineDeploymentName := ""
+		if machineDeployment != nil {
+			machineDeploymentName = machineDeployment.Name
+		}
+		r.metrics.DrainDuration.WithLabelValues(machineDeploymentName).Observe(duration.Seconds())
+		r.recorder.Eventf(machine, corev1.EventTypeNormal, "Drained", "Drained node %s in %s", nodeName, duration.Round(time.Second))
+	case progress.PodsBlockedByPDB > 0:
+		r.recorder.Eventf(machine, corev1.EventTypeWarning, "DrainBlocked", "Draining node %s: %s", nodeName, condition.Message)
+	case previous == nil:
+		r.recorder.Eventf(machine, corev1.EventTypeNormal, "DrainStarted", "Draining node %s: %s", nodeName, condition.Message)
+	}
+
+	return r.updateMachine(machine, func(m *clusterv1alpha1.Machine) {
+		m.Status.Drain = status
+		setMachineCondition(&m.Status.Conditions, condition)
+	})
+}
+
+func drainingCondition(draining bool, progress eviction.Progress) corev1.NodeCondition {
+	if !draining {
+		return corev1.NodeCondition{
+			Type:    clusterv1alpha1.MachineDrainingCondition,
+			Status:  corev1.ConditionFalse,
+			Reason:  clusterv1alpha1.DrainCompletedReason,
+			Message: "Node was drained",
+		}
+	}
+
+	if progress.PreDrainDelay {
+		return corev1.NodeCondition{
+			Type:    clusterv1alpha1.MachineDrainingCondition,
+			Status:  corev1.ConditionTrue,
+			Reason:  clusterv1alpha1.DrainPreDrainDelayReason,
+			Message: "Waiting after tainting the node before draining it",
+		}
+	}
+
+	if progress.PodsBlockedByPDB > 0 {
+		return corev1.NodeCondition{
+			Type:    clusterv1alpha1.MachineDrainingCondition,
+			Status:  corev1.ConditionTrue,
+			Reason:  clusterv1alpha1.DrainBlockedByPDBReason,
+			Message: fmt.Sprintf("%d pods remaining, eviction of %d pods refused by PodDisruptionBudgets", progress.PodsRemaining, progress.PodsBlockedByPDB),
+		}
+	}
+
+	return corev1.NodeCondition{
+		Type:    clusterv1alpha1.MachineDrainingCondition,
+		Status:  corev1.ConditionTrue,
+		Reason:  clusterv1alpha1.DrainInProgressReason,
+		Message: fmt.Sprintf("%d pods remaining", progress.PodsRemaining),
+	}
+}
+
+func machineConditionReason(conditions []corev1.NodeCondition, conditionType corev1.NodeConditionType) string {
+	for _, condition := range conditions {
+		if condition.Type == conditionType {
+			return condition.Reason
+		}
+	}
+	return ""
+}
+
+// setMachineCondition adds or replaces the condition of the same type. The transition time is
+// only updated if the status of the condition changed.
+func setMachineCondition(conditions *[]corev1.NodeCondition, condition corev1.NodeCondition) {
+	now := metav1.Now()
+	condition.LastHeartbeatTime = now
+	condition.LastTransitionTime = now
+
+	for i, existing := range *conditions {
+		if existing.Type != condition.Type {
+			continue
+		}
+		if existing.Status == condition.Status {
+			condition.LastTransitionTime = existing.LastTransitionTime
+		}
+		(*conditions)[i] = condition
+		return
+	}
+
+	*conditions = append(*conditions, condition)
+}
+
+// ensureMachineCondition sets the condition on the machine, unless an equal one is already set.
+func (r *Reconciler) ensureMachineCondition(machine *clusterv1alpha1.Machine, condition corev1.NodeCondition) error {
+	for _, existing := range machine.Status.Conditions {
+		if existing.Type == condition.Type && existing.Status == condition.Status && existing.Reason == condition.Reason && existing.Message == condition.Message {
+			return nil
+		}
+	}
+
+	return r.updateMachine(machine, func(m *clusterv1alpha1.Machine) {
+		setMachineCondition(&m.Status.Conditions, condition)
+	})
+}
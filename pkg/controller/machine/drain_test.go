@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	cloudprovidertypes "k8c.io/machine-controller/pkg/cloudprovider/types"
+	"k8c.io/machine-controller/pkg/node/eviction"
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestUpdateDrainStatus(t *testing.T) {
+	ctx := context.Background()
+	start := time.Now().Add(-time.Minute).Truncate(time.Second)
+
+	machine := &clusterv1alpha1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "machine", Namespace: "kube-system"},
+		Status: clusterv1alpha1.MachineStatus{
+			NodeRef: &corev1.ObjectReference{Name: "node"},
+		},
+	}
+	client := fakectrlruntimeclient.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(machine).
+		Build()
+	recorder := record.NewFakeRecorder(10)
+
+	reconciler := &Reconciler{
+		client:   client,
+		recorder: recorder,
+		metrics:  NewMachineControllerMetrics(),
+		providerData: &cloudprovidertypes.ProviderData{
+			Ctx:    ctx,
+			Update: cloudprovidertypes.GetMachineUpdater(ctx, client),
+			Client: client,
+		},
+	}
+
+	steps := []struct {
+		name              string
+		draining          bool
+		progress          eviction.Progress
+		expectedEvent     string
+		expectedReason    string
+		expectedRemaining int32
+		expectedBlocked   int32
+	}{
+		{
+			name:              "drain started",
+			draining:          true,
+			progress:          eviction.Progress{PodsRemaining: 3},
+			expectedEvent:     "DrainStarted",
+			expectedReason:    clusterv1alpha1.DrainInProgressReason,
+			expectedRemaining: 3,
+		},
+		{
+			name:              "drain blocked by PDB",
+			draining:          true,
+			progress:          eviction.Progress{PodsRemaining: 1, PodsBlockedByPDB: 1},
+			expectedEvent:     "DrainBlocked",
+			expectedReason:    clusterv1alpha1.DrainBlockedByPDBReason,
+			expectedRemaining: 1,
+			expectedBlocked:   1,
+		},
+		{
+			name:           "drain completed",
+			progress:       eviction.Progress{},
+			expectedEvent:  "Drained",
+			expectedReason: clusterv1alpha1.DrainCompletedReason,
+		},
+	}
+
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			if err := reconciler.updateDrainStatus(machine, nil, start, step.draining, step.progress); err != nil {
+				t.Fatalf("failed to update drain status: %v", err)
+			}
+
+			select {
+			case event := <-recorder.Events:
+				if !strings.Contains(event, step.expectedEvent) {
+					t.Errorf("expected event %q, got %q", step.expectedEvent, event)
+				}
+			default:
+				t.Errorf("expected event %q, got none", step.expectedEvent)
+			}
+
+			drain := machine.Status.Drain
+			if drain == nil {
+				t.Fatal("expected drain status to be set")
+			}
+			if !drain.StartTime.Time.Equal(start) {
+				t.Errorf("expected start time %v, got %v", start, drain.StartTime)
+			}
+			if drain.PodsRemaining != step.expectedRemaining || drain.PodsBlockedByPDB != step.expectedBlocked {
+				t.Errorf("expected %d remaining and %d blocked pods, got %d and %d", step.expectedRemaining, step.expectedBlocked, drain.PodsRemaining, drain.PodsBlockedByPDB)
+			}
+			if (drain.CompletionTime != nil) == step.draining {
+				t.Errorf("expected completion time to be set only after the drain, got %v", drain.CompletionTime)
+			}
+
+			if len(machine.Status.Conditions) != 1 {
+				t.Fatalf("expected one condition, got %v", machine.Status.Conditions)
+			}
+			if reason := machine.Status.Conditions[0].Reason; reason != step.expectedReason {
+				t.Errorf("expected condition reason %q, got %q", step.expectedReason, reason)
+			}
+		})
+	}
+}
@@ -60,11 +60,30 @@ func NewMachineControllerMetrics() *MetricsCollection {
 			Help:    "Histogram of times spent from deleting a Machine to be removed from cluster and cloud provider",
 			Buckets: prometheus.ExponentialBuckets(32, 1.5, 10),
 		}),
+		DrainDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    metricsPrefix + "drain_duration_seconds",
+			Help:    "Histogram of times spent draining the node of a Machine before deleting it",
+			Buckets: prometheus.ExponentialBuckets(8, 2, 10),
+		}, []string{"machine_deployment"}),
+		BootstrapTokensCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: metricsPrefix + "bootstrap_tokens_created_total",
+			Help: "The total number of bootstrap tokens created for Machines",
+		}),
+		BootstrapTokensRevoked: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: metricsPrefix + "bootstrap_tokens_revoked_total",
+			Help: "The total number of bootstrap tokens of Machines revoked, by the reason of the revocation",
+		}, []string{"reason"}),
+		BootstrapTokenAge: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    metricsPrefix + "bootstrap_token_age_seconds",
+			Help:    "Histogram of the age of bootstrap tokens of Machines when they are revoked",
+			Buckets: prometheus.ExponentialBuckets(32, 1.5, 10),
+		}),
 	}
 
 	// Set default values, so that these metrics always show up
 	cm.Workers.Set(0)
 	cm.Errors.Add(0)
+	cm.BootstrapTokensCreated.Add(0)
 
 	return cm
 }
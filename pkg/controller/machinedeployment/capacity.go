@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinedeployment
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	gocache "github.com/patrickmn/go-cache"
+	"go.uber.org/zap"
+
+	"k8c.io/machine-controller/pkg/cloudprovider"
+	cloudprovidertypes "k8c.io/machine-controller/pkg/cloudprovider/types"
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+	"k8c.io/machine-controller/sdk/providerconfig"
+	"k8c.io/machine-controller/sdk/providerconfig/configvar"
+)
+
+// capacityAnnotationPrefix is the prefix of the annotations the cluster-autoscaler
+// reads to build a template node for MachineDeployments scaled to zero.
+const capacityAnnotationPrefix = "capacity.cluster-autoscaler.kubernetes.io/"
+
+// Annotations describing the nodes of a MachineDeployment to the cluster-autoscaler.
+const (
+	CPUCapacityAnnotation           = capacityAnnotationPrefix + "cpu"
+	MemoryCapacityAnnotation        = capacityAnnotationPrefix + "memory"
+	EphemeralDiskCapacityAnnotation = capacityAnnotationPrefix + "ephemeral-disk"
+	GPUCountCapacityAnnotation      = capacityAnnotationPrefix + "gpu-count"
+	GPUTypeCapacityAnnotation       = capacityAnnotationPrefix + "gpu-type"
+	LabelsCapacityAnnotation        = capacityAnnotationPrefix + "labels"
+	TaintsCapacityAnnotation        = capacityAnnotationPrefix + "taints"
+)
+
+// capacityCacheTTL is how long the capacity of the machines of a cloudProviderSpec is cached.
+const capacityCacheTTL = 30 * time.Minute
+
+// capacityCache caches the capacity per cloud provider and cloudProviderSpec, which holds the
+// region and instance type, so the cloud provider APIs are not called on every reconcile.
+var capacityCache = gocache.New(capacityCacheTTL, capacityCacheTTL)
+
+// reconcileCapacityAnnotations publishes the capacity of the machines of a MachineDeployment as
+// annotations, so the cluster-autoscaler can scale it up from zero replicas. The annotations are
+// removed for providers which can not report the capacity of their instances.
+func (r *ReconcileMachineDeployment) reconcileCapacityAnnotations(ctx context.Context, log *zap.SugaredLogger, d *clusterv1alpha1.MachineDeployment) error {
+	capacity, err := r.machineCapacity(ctx, log, d)
+	if err != nil {
+		return err
+	}
+
+	var annotations map[string]string
+	if capacity != nil {
+		annotations = capacityAnnotations(capacity, &d.Spec.Template.Spec)
+	}
+	return r.updateMachineDeployment(ctx, d, func(md *clusterv1alpha1.MachineDeployment) {
+		md.Annotations = setCapacityAnnotations(md.Annotations, annotations)
+	})
+}
+
+// machineCapacity returns the capacity of the machines of the MachineDeployment, or nil if the
+// cloud provider can not report it.
+func (r *ReconcileMachineDeployment) machineCapacity(ctx context.Context, log *zap.SugaredLogger, d *clusterv1alpha1.MachineDeployment) (*cloudprovidertypes.MachineCapacity, error) {
+	providerConfig, err := providerconfig.GetConfig(d.Spec.Template.Spec.ProviderSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provider config: %w", err)
+	}
+
+	cacheKey := fmt.Sprintf("%s-%x", providerConfig.CloudProvider, sha256.Sum256(providerConfig.CloudProviderSpec.Raw))
+	if capacity, found := capacityCache.Get(cacheKey); found {
+		return capacity.(*cloudprovidertypes.MachineCapacity), nil
+	}
+
+	configResolver := configvar.NewResolver(ctx, r.Client, append(
+		configvar.ForConfig(d.Namespace, providerConfig, r.allowCrossNamespaceRefs),
+		configvar.WithAccessor(fmt.Sprintf("MachineDeployment %s/%s", d.Namespace, d.Name)),
+	)...)
+	prov, err := cloudprovider.ForProvider(providerConfig.CloudProvider, configResolver)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cloud provider %q: %w", providerConfig.CloudProvider, err)
+	}
+
+	capacityProvider, ok := prov.(cloudprovidertypes.CapacityProvider)
+	if !ok {
+		return nil, nil
+	}
+
+	capacity, err := capacityProvider.MachineCapacity(ctx, log, d.Spec.Template.Spec)
+	if err != nil {
+		if errors.Is(err, cloudprovider.ErrCapacityNotSupported) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get machine capacity: %w", err)
+	}
+
+	capacityCache.SetDefault(cacheKey, capacity)
+	return capacity, nil
+}
+
+// setCapacityAnnotations replaces the capacity annotations with the given ones. Capacity annotations
+// which are no longer computed are removed, so the cluster-autoscaler does not use stale values.
+func setCapacityAnnotations(existing, annotations map[string]string) map[string]string {
+	for key := range existing {
+		if _, ok := annotations[key]; !ok && strings.HasPrefix(key, capacityAnnotationPrefix) {
+			delete(existing, key)
+		}
+	}
+	if len(annotations) == 0 {
+		return existing
+	}
+
+	if existing == nil {
+		existing = map[string]string{}
+	}
+	for key, value := range annotations {
+		existing[key] = value
+	}
+	return existing
+}
+
+// capacityAnnotations returns the cluster-autoscaler capacity annotations for the given capacity and
+// the node labels and taints of the machine spec. Unknown resources are omitted.
+func capacityAnnotations(capacity *cloudprovidertypes.MachineCapacity, spec *clusterv1alpha1.MachineSpec) map[string]string {
+	annotations := map[string]string{}
+
+	if capacity.CPU != nil {
+		annotations[CPUCapacityAnnotation] = capacity.CPU.String()
+	}
+	if capacity.Memory != nil {
+		annotations[MemoryCapacityAnnotation] = capacity.Memory.String()
+	}
+	if capacity.EphemeralStorage != nil && !capacity.EphemeralStorage.IsZero() {
+		annotations[EphemeralDiskCapacityAnnotation] = capacity.EphemeralStorage.String()
+	}
+	if capacity.GPUCount > 0 {
+		annotations[GPUCountCapacityAnnotation] = strconv.FormatInt(capacity.GPUCount, 10)
+		if capacity.GPUType != "" {
+			annotations[GPUTypeCapacityAnnotation] = capacity.GPUType
+		}
+	}
+
+	if len(spec.Labels) > 0 {
+		labels := make([]string, 0, len(spec.Labels))
+		for key, value := range spec.Labels {
+			labels = append(labels, fmt.Sprintf("%s=%s", key, value))
+		}
+		sort.Strings(labels)
+		annotations[LabelsCapacityAnnotation] = strings.Join(labels, ",")
+	}
+
+	if len(spec.Taints) > 0 {
+		taints := make([]string, 0, len(spec.Taints))
+		for _, taint := range spec.Taints {
+			taints = append(taints, fmt.Sprintf("%s=%s:%s", taint.Key, taint.Value, taint.Effect))
+		}
+		annotations[TaintsCapacityAnnotation] = strings.Join(taints, ",")
+	}
+
+	return annotations
+}
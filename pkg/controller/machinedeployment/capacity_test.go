@@ -0,0 +1,180 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinedeployment
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"go.uber.org/zap"
+
+	cloudprovidertypes "k8c.io/machine-controller/pkg/cloudprovider/types"
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+	"k8c.io/machine-controller/sdk/providerconfig"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCapacityAnnotations(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity cloudprovidertypes.MachineCapacity
+		spec     clusterv1alpha1.MachineSpec
+		expected map[string]string
+	}{
+		{
+			name: "cpu, memory and disk",
+			capacity: cloudprovidertypes.MachineCapacity{
+				CPU:              resource.NewQuantity(4, resource.DecimalSI),
+				Memory:           resource.NewQuantity(8*1024*1024*1024, resource.BinarySI),
+				EphemeralStorage: resource.NewQuantity(0, resource.BinarySI),
+			},
+			expected: map[string]string{
+				CPUCapacityAnnotation:    "4",
+				MemoryCapacityAnnotation: "8Gi",
+			},
+		},
+		{
+			name: "gpus, labels and taints",
+			capacity: cloudprovidertypes.MachineCapacity{
+				EphemeralStorage: resource.NewQuantity(25*1024*1024*1024, resource.BinarySI),
+				GPUCount:         2,
+				GPUType:          "T4",
+			},
+			spec: clusterv1alpha1.MachineSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"pool": "gpu", "env": "prod"},
+				},
+				Taints: []corev1.Taint{
+					{Key: "nvidia.com/gpu", Value: "present", Effect: corev1.TaintEffectNoSchedule},
+				},
+			},
+			expected: map[string]string{
+				EphemeralDiskCapacityAnnotation: "25Gi",
+				GPUCountCapacityAnnotation:      "2",
+				GPUTypeCapacityAnnotation:       "T4",
+				LabelsCapacityAnnotation:        "env=prod,pool=gpu",
+				TaintsCapacityAnnotation:        "nvidia.com/gpu=present:NoSchedule",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			annotations := capacityAnnotations(&test.capacity, &test.spec)
+			if !reflect.DeepEqual(annotations, test.expected) {
+				t.Errorf("expected annotations %v, got %v", test.expected, annotations)
+			}
+		})
+	}
+}
+
+// reconcileTestCapacityAnnotations reconciles the capacity annotations of a MachineDeployment of the
+// fake cloud provider, which can not report the capacity of its machines.
+func reconcileTestCapacityAnnotations(t *testing.T, cloudProviderSpec string, annotations map[string]string) map[string]string {
+	t.Helper()
+	ctx := context.Background()
+
+	rawConfig, err := json.Marshal(providerconfig.Config{
+		CloudProvider:     providerconfig.CloudProviderFake,
+		CloudProviderSpec: runtime.RawExtension{Raw: []byte(cloudProviderSpec)},
+		OperatingSystem:   providerconfig.OperatingSystemUbuntu,
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal provider config: %v", err)
+	}
+
+	md := &clusterv1alpha1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "md", Namespace: metav1.NamespaceSystem, Annotations: annotations},
+		Spec: clusterv1alpha1.MachineDeploymentSpec{
+			Template: clusterv1alpha1.MachineTemplateSpec{
+				Spec: clusterv1alpha1.MachineSpec{
+					ProviderSpec: clusterv1alpha1.ProviderSpec{Value: &runtime.RawExtension{Raw: rawConfig}},
+				},
+			},
+		},
+	}
+
+	testScheme := runtime.NewScheme()
+	if err := clusterv1alpha1.AddToScheme(testScheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	r := &ReconcileMachineDeployment{
+		Client: fakectrlruntimeclient.NewClientBuilder().WithScheme(testScheme).WithObjects(md).Build(),
+	}
+
+	if err := r.reconcileCapacityAnnotations(ctx, zap.NewNop().Sugar(), md.DeepCopy()); err != nil {
+		t.Fatalf("failed to reconcile capacity annotations: %v", err)
+	}
+
+	updated := &clusterv1alpha1.MachineDeployment{}
+	if err := r.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(md), updated); err != nil {
+		t.Fatalf("failed to get MachineDeployment: %v", err)
+	}
+	return updated.Annotations
+}
+
+func TestReconcileCapacityAnnotationsRemovesStaleAnnotations(t *testing.T) {
+	annotations := reconcileTestCapacityAnnotations(t, `{}`, map[string]string{
+		CPUCapacityAnnotation:    "4",
+		MemoryCapacityAnnotation: "8Gi",
+		"unrelated":              "value",
+	})
+	if expected := map[string]string{"unrelated": "value"}; !reflect.DeepEqual(annotations, expected) {
+		t.Errorf("expected annotations %v, got %v", expected, annotations)
+	}
+}
+
+func TestReconcileCapacityAnnotationsUsesCache(t *testing.T) {
+	cloudProviderSpec := `{"instanceType":"cached"}`
+	cacheKey := fmt.Sprintf("%s-%x", providerconfig.CloudProviderFake, sha256.Sum256([]byte(cloudProviderSpec)))
+	capacityCache.SetDefault(cacheKey, &cloudprovidertypes.MachineCapacity{CPU: resource.NewQuantity(2, resource.DecimalSI)})
+	defer capacityCache.Delete(cacheKey)
+
+	annotations := reconcileTestCapacityAnnotations(t, cloudProviderSpec, nil)
+	if expected := map[string]string{CPUCapacityAnnotation: "2"}; !reflect.DeepEqual(annotations, expected) {
+		t.Errorf("expected annotations %v, got %v", expected, annotations)
+	}
+}
+
+func TestSetCapacityAnnotations(t *testing.T) {
+	existing := map[string]string{
+		CPUCapacityAnnotation:      "4",
+		GPUCountCapacityAnnotation: "1",
+		"unrelated":                "value",
+	}
+	expected := map[string]string{
+		CPUCapacityAnnotation: "8",
+		"unrelated":           "value",
+	}
+
+	if annotations := setCapacityAnnotations(existing, map[string]string{CPUCapacityAnnotation: "8"}); !reflect.DeepEqual(annotations, expected) {
+		t.Errorf("expected annotations %v, got %v", expected, annotations)
+	}
+	if annotations := setCapacityAnnotations(nil, nil); annotations != nil {
+		t.Errorf("expected no annotations to be created, got %v", annotations)
+	}
+}
@@ -18,13 +18,17 @@ package machinedeployment
 
 import (
 	"context"
+	"fmt"
 	"reflect"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/go-logr/zapr"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	dutil "k8c.io/machine-controller/pkg/controller/util"
 	"k8c.io/machine-controller/sdk/apis/cluster/common"
 	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
 
@@ -57,21 +61,26 @@ type ReconcileMachineDeployment struct {
 	log      *zap.SugaredLogger
 	scheme   *runtime.Scheme
 	recorder record.EventRecorder
+
+	// allowCrossNamespaceRefs allows providerSpecs to reference secrets and configmaps of other namespaces.
+	allowCrossNamespaceRefs bool
 }
 
 // newReconciler returns a new reconcile.Reconciler.
-func newReconciler(mgr manager.Manager, log *zap.SugaredLogger) *ReconcileMachineDeployment {
+func newReconciler(mgr manager.Manager, log *zap.SugaredLogger, allowCrossNamespaceRefs bool) *ReconcileMachineDeployment {
 	return &ReconcileMachineDeployment{
 		Client:   mgr.GetClient(),
 		log:      log.Named(controllerName),
 		scheme:   mgr.GetScheme(),
 		recorder: mgr.GetEventRecorderFor(controllerName),
+
+		allowCrossNamespaceRefs: allowCrossNamespaceRefs,
 	}
 }
 
 // Add creates a new MachineDeployment Controller and adds it to the Manager with default RBAC.
-func Add(mgr manager.Manager, log *zap.SugaredLogger) error {
-	r := newReconciler(mgr, log)
+func Add(mgr manager.Manager, log *zap.SugaredLogger, allowCrossNamespaceRefs bool) error {
+	r := newReconciler(mgr, log, allowCrossNamespaceRefs)
 	return add(mgr, r, r.MachineSetToDeployments())
 }
 
@@ -169,11 +178,20 @@ func (r *ReconcileMachineDeployment) reconcile(ctx context.Context, log *zap.Sug
 		return reconcile.Result{Requeue: true}, nil
 	}
 
-	msList, err := r.getMachineSetsForDeployment(ctx, log, d)
+	// A failure to determine the capacity must not block scaling or rollouts, so it is only logged.
+	if err := r.reconcileCapacityAnnotations(ctx, log, d); err != nil {
+		log.Errorw("Failed to reconcile capacity annotations", zap.Error(err))
+	}
+
+	msList, conflicts, err := r.getMachineSetsForDeployment(ctx, log, d)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
+	if err := r.reportOwnershipConflicts(ctx, d, conflicts); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	if d.DeletionTimestamp != nil {
 		return reconcile.Result{}, r.sync(ctx, log, d, msList)
 	}
@@ -182,6 +200,22 @@ func (r *ReconcileMachineDeployment) reconcile(ctx context.Context, log *zap.Sug
 		return reconcile.Result{}, r.sync(ctx, log, d, msList)
 	}
 
+	// Outside of maintenance windows, changes of the template are not rolled out, but the
+	// MachineSets are still scaled to the desired replicas.
+	if dutil.RolloutPending(d, msList) {
+		open, start, err := dutil.MaintenanceWindowOpen(d.Spec.MaintenanceWindows, time.Now())
+		if err != nil {
+			// Invalid maintenance windows never open, so the rollout is postponed until they are fixed.
+			log.Errorw("Postponing rollout, failed to evaluate maintenance windows", zap.Error(err))
+			r.recorder.Eventf(d, corev1.EventTypeWarning, "InvalidMaintenanceWindows", "Postponing rollout: %v", err)
+			return reconcile.Result{}, r.sync(ctx, log, d, msList)
+		}
+		if !open {
+			log.Infow("Postponing rollout until the next maintenance window", "start", start)
+			return reconcile.Result{RequeueAfter: time.Until(start)}, r.sync(ctx, log, d, msList)
+		}
+	}
+
 	switch d.Spec.Strategy.Type {
 	case common.RollingUpdateMachineDeploymentStrategyType:
 		return reconcile.Result{}, r.rolloutRolling(ctx, log, d, msList)
@@ -190,15 +224,17 @@ func (r *ReconcileMachineDeployment) reconcile(ctx context.Context, log *zap.Sug
 	return reconcile.Result{}, errors.Errorf("unexpected deployment strategy type: %s", d.Spec.Strategy.Type)
 }
 
-// getMachineSetsForDeployment returns a list of MachineSets associated with a MachineDeployment.
-func (r *ReconcileMachineDeployment) getMachineSetsForDeployment(ctx context.Context, log *zap.SugaredLogger, d *clusterv1alpha1.MachineDeployment) ([]*clusterv1alpha1.MachineSet, error) {
+// getMachineSetsForDeployment returns a list of MachineSets associated with a MachineDeployment and
+// a description of every matching MachineSet which can not be associated because of conflicting ownership.
+func (r *ReconcileMachineDeployment) getMachineSetsForDeployment(ctx context.Context, log *zap.SugaredLogger, d *clusterv1alpha1.MachineDeployment) ([]*clusterv1alpha1.MachineSet, []string, error) {
 	// List all MachineSets to find those we own but that no longer match our selector.
 	machineSets := &clusterv1alpha1.MachineSetList{}
 	listOptions := &ctrlruntimeclient.ListOptions{Namespace: d.Namespace}
 	if err := r.List(ctx, machineSets, listOptions); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	var conflicts []string
 	filtered := make([]*clusterv1alpha1.MachineSet, 0, len(machineSets.Items))
 	for idx := range machineSets.Items {
 		ms := &machineSets.Items[idx]
@@ -222,21 +258,50 @@ func (r *ReconcileMachineDeployment) getMachineSetsForDeployment(ctx context.Con
 		}
 
 		// Attempt to adopt machine if it meets previous conditions and it has no controller references.
+		// MachineSets matching several MachineDeployments are left alone, as adopting them would
+		// only make the MachineDeployments fight over them.
 		if metav1.GetControllerOf(ms) == nil {
+			if others := r.getMachineDeploymentsForMachineSet(ctx, msLog, ms); len(others) > 1 {
+				names := make([]string, 0, len(others))
+				for _, other := range others {
+					names = append(names, other.Name)
+				}
+				msLog.Infow("Not adopting MachineSet matching multiple MachineDeployments", "machinedeployments", names)
+				conflicts = append(conflicts, fmt.Sprintf("MachineSet %s matches the MachineDeployments %s", ms.Name, strings.Join(names, ", ")))
+				continue
+			}
+
 			if err := r.adoptOrphan(ctx, d, ms); err != nil {
 				msLog.Infow("Failed to adopt MachineSet into MachineDeployment", zap.Error(err))
 				continue
 			}
+			r.recorder.Eventf(d, corev1.EventTypeNormal, "Adopted", "Adopted MachineSet %s", ms.Name)
 		}
 
-		if !metav1.IsControlledBy(ms, d) {
+		if controllerRef := metav1.GetControllerOf(ms); !metav1.IsControlledBy(ms, d) {
+			msLog.Debugw("Skipping MachineSet controlled by another object", "controller", controllerRef.Name)
+			conflicts = append(conflicts, fmt.Sprintf("MachineSet %s is controlled by %s %s", ms.Name, controllerRef.Kind, controllerRef.Name))
 			continue
 		}
 
 		filtered = append(filtered, ms)
 	}
 
-	return filtered, nil
+	return filtered, conflicts, nil
+}
+
+// reportOwnershipConflicts sets the OwnershipConflict condition of the MachineDeployment and
+// emits an event whenever new conflicts are found.
+func (r *ReconcileMachineDeployment) reportOwnershipConflicts(ctx context.Context, d *clusterv1alpha1.MachineDeployment, conflicts []string) error {
+	if !dutil.SetOwnershipConflictCondition(&d.Status.Conditions, d.Generation, conflicts) {
+		return nil
+	}
+
+	if len(conflicts) > 0 {
+		r.recorder.Eventf(d, corev1.EventTypeWarning, clusterv1alpha1.SelectorOverlapReason, "Selector matches MachineSets with conflicting ownership: %s", strings.Join(conflicts, "; "))
+	}
+
+	return r.Status().Update(ctx, d)
 }
 
 // adoptOrphan sets the MachineDeployment as a controller OwnerReference to the MachineSet.
@@ -23,6 +23,8 @@ import (
 
 	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -36,6 +38,7 @@ type Collector struct {
 	availableReplicas *prometheus.Desc
 	readyReplicas     *prometheus.Desc
 	updatedReplicas   *prometheus.Desc
+	drainBlockedPods  *prometheus.Desc
 }
 
 // NewCollector creates new machine deployment collector for metrics collection.
@@ -63,6 +66,11 @@ func NewCollector(ctx context.Context, client ctrlruntimeclient.Client) *Collect
 			"The number of replicas updated for a machine deployment",
 			[]string{"name", "namespace"}, nil,
 		),
+		drainBlockedPods: prometheus.NewDesc(
+			metricsPrefix+"drain_blocked_pods",
+			"The number of pods whose eviction from draining nodes of a machine deployment is refused by PodDisruptionBudgets",
+			[]string{"name", "namespace"}, nil,
+		),
 	}
 }
 
@@ -72,6 +80,7 @@ func (c *Collector) Describe(desc chan<- *prometheus.Desc) {
 	desc <- c.readyReplicas
 	desc <- c.availableReplicas
 	desc <- c.readyReplicas
+	desc <- c.drainBlockedPods
 }
 
 // Collect implements the prometheus.Collector interface.
@@ -81,6 +90,11 @@ func (c *Collector) Collect(metrics chan<- prometheus.Metric) {
 		return
 	}
 
+	drainBlockedPods, err := c.drainBlockedPodsPerMachineDeployment()
+	if err != nil {
+		return
+	}
+
 	for _, machineDeployment := range machineDeployments.Items {
 		metrics <- prometheus.MustNewConstMetric(
 			c.replicas,
@@ -110,5 +124,51 @@ func (c *Collector) Collect(metrics chan<- prometheus.Metric) {
 			machineDeployment.Name,
 			machineDeployment.Namespace,
 		)
+		metrics <- prometheus.MustNewConstMetric(
+			c.drainBlockedPods,
+			prometheus.GaugeValue,
+			float64(drainBlockedPods[types.NamespacedName{Namespace: machineDeployment.Namespace, Name: machineDeployment.Name}]),
+			machineDeployment.Name,
+			machineDeployment.Namespace,
+		)
 	}
 }
+
+// drainBlockedPodsPerMachineDeployment sums up the pods blocked by PodDisruptionBudgets on the
+// draining machines of each machine deployment.
+func (c *Collector) drainBlockedPodsPerMachineDeployment() (map[types.NamespacedName]int32, error) {
+	machineSets := &clusterv1alpha1.MachineSetList{}
+	if err := c.client.List(c.ctx, machineSets); err != nil {
+		return nil, err
+	}
+	machines := &clusterv1alpha1.MachineList{}
+	if err := c.client.List(c.ctx, machines); err != nil {
+		return nil, err
+	}
+
+	machineDeploymentForMachineSet := map[types.NamespacedName]string{}
+	for _, machineSet := range machineSets.Items {
+		if controllerRef := metav1.GetControllerOf(&machineSet); controllerRef != nil && controllerRef.Kind == "MachineDeployment" {
+			machineDeploymentForMachineSet[types.NamespacedName{Namespace: machineSet.Namespace, Name: machineSet.Name}] = controllerRef.Name
+		}
+	}
+
+	blockedPods := map[types.NamespacedName]int32{}
+	for _, machine := range machines.Items {
+		drain := machine.Status.Drain
+		if drain == nil || drain.CompletionTime != nil || drain.PodsBlockedByPDB == 0 {
+			continue
+		}
+		controllerRef := metav1.GetControllerOf(&machine)
+		if controllerRef == nil || controllerRef.Kind != "MachineSet" {
+			continue
+		}
+		machineDeploymentName, ok := machineDeploymentForMachineSet[types.NamespacedName{Namespace: machine.Namespace, Name: controllerRef.Name}]
+		if !ok {
+			continue
+		}
+		blockedPods[types.NamespacedName{Namespace: machine.Namespace, Name: machineDeploymentName}] += drain.PodsBlockedByPDB
+	}
+
+	return blockedPods, nil
+}
@@ -22,6 +22,7 @@ import (
 	"reflect"
 	"sort"
 	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -109,8 +110,10 @@ func (r *ReconcileMachineDeployment) getNewMachineSet(ctx context.Context, log *
 		annotationsUpdated := dutil.SetNewMachineSetAnnotations(log, d, msCopy, newRevision, true)
 
 		minReadySecondsNeedsUpdate := msCopy.Spec.MinReadySeconds != *d.Spec.MinReadySeconds
-		if annotationsUpdated || minReadySecondsNeedsUpdate {
+		failureDomainsNeedUpdate := !equality.Semantic.DeepEqual(msCopy.Spec.FailureDomains, d.Spec.FailureDomains)
+		if annotationsUpdated || minReadySecondsNeedsUpdate || failureDomainsNeedUpdate {
 			msCopy.Spec.MinReadySeconds = *d.Spec.MinReadySeconds
+			msCopy.Spec.FailureDomains = d.Spec.FailureDomains
 			return nil, r.Update(ctx, msCopy)
 		}
 
@@ -154,6 +157,7 @@ func (r *ReconcileMachineDeployment) getNewMachineSet(ctx context.Context, log *
 			MinReadySeconds: minReadySeconds,
 			Selector:        *newMSSelector,
 			Template:        newMSTemplate,
+			FailureDomains:  d.Spec.FailureDomains,
 		},
 	}
 
@@ -347,6 +351,13 @@ func calculateStatus(allMSs []*clusterv1alpha1.MachineSet, newMS *clusterv1alpha
 		ReadyReplicas:       dutil.GetReadyReplicaCountForMachineSets(allMSs),
 		AvailableReplicas:   availableReplicas,
 		UnavailableReplicas: unavailableReplicas,
+		Conditions:          deployment.Status.Conditions,
+	}
+
+	if deployment.Spec.MaintenanceWindows != nil {
+		if _, start, err := dutil.MaintenanceWindowOpen(deployment.Spec.MaintenanceWindows, time.Now()); err == nil && !start.IsZero() {
+			status.NextMaintenanceWindow = &metav1.Time{Time: start}
+		}
 	}
 
 	return status
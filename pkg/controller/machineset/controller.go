@@ -28,6 +28,7 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	controllerutil "k8c.io/machine-controller/pkg/controller/util"
 	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
 
 	corev1 "k8s.io/api/core/v1"
@@ -59,8 +60,22 @@ var (
 	// stateConfirmationInterval is the amount of time between polling for the desired state.
 	// The polling is against a local memory cache.
 	stateConfirmationInterval = 100 * time.Millisecond
+
+	// unsafeToEvictRequeueInterval is the amount of time after which deleting Machines, whose
+	// Nodes run Pods that are not safe to evict, is retried.
+	unsafeToEvictRequeueInterval = time.Minute
 )
 
+// unsafeToEvictError is returned when Machines could not be deleted because their Nodes
+// run Pods that are not safe to evict.
+type unsafeToEvictError struct {
+	machines int
+}
+
+func (e *unsafeToEvictError) Error() string {
+	return fmt.Sprintf("%d Machine(s) could not be deleted because their Nodes run Pods that are not safe to evict", e.machines)
+}
+
 // Add creates a new MachineSet Controller and adds it to the Manager with default RBAC.
 // The Manager will set fields on the Controller and Start it when the Manager is Started.
 func Add(mgr manager.Manager, log *zap.SugaredLogger) error {
@@ -71,10 +86,11 @@ func Add(mgr manager.Manager, log *zap.SugaredLogger) error {
 // newReconciler returns a new reconcile.Reconciler.
 func newReconciler(mgr manager.Manager, log *zap.SugaredLogger) *ReconcileMachineSet {
 	return &ReconcileMachineSet{
-		Client:   mgr.GetClient(),
-		scheme:   mgr.GetScheme(),
-		log:      log.Named(controllerName),
-		recorder: mgr.GetEventRecorderFor(controllerName),
+		Client:    mgr.GetClient(),
+		apiReader: mgr.GetAPIReader(),
+		scheme:    mgr.GetScheme(),
+		log:       log.Named(controllerName),
+		recorder:  mgr.GetEventRecorderFor(controllerName),
 	}
 }
 
@@ -104,9 +120,12 @@ func add(mgr manager.Manager, r reconcile.Reconciler, mapFn handler.MapFunc) err
 // ReconcileMachineSet reconciles a MachineSet object.
 type ReconcileMachineSet struct {
 	ctrlruntimeclient.Client
-	log      *zap.SugaredLogger
-	scheme   *runtime.Scheme
-	recorder record.EventRecorder
+	// apiReader reads directly from the API server. It is used to list Pods, for which
+	// we explicitly do not want an informer to be started.
+	apiReader ctrlruntimeclient.Reader
+	log       *zap.SugaredLogger
+	scheme    *runtime.Scheme
+	recorder  record.EventRecorder
 }
 
 // Reconcile reads that state of the cluster for a MachineSet object and makes changes based on the state read
@@ -182,26 +201,48 @@ func (r *ReconcileMachineSet) reconcile(ctx context.Context, log *zap.SugaredLog
 	}
 
 	// Filter out irrelevant machines (deleting/mismatch labels) and claim orphaned machines.
+	var conflicts []string
 	filteredMachines := make([]*clusterv1alpha1.Machine, 0, len(allMachines.Items))
 	for idx := range allMachines.Items {
 		machine := &allMachines.Items[idx]
 		machineLog := log.With("machine", ctrlruntimeclient.ObjectKeyFromObject(machine))
 
+		if conflict := ownershipConflict(machineLog, machineSet, machine); conflict != "" {
+			conflicts = append(conflicts, conflict)
+		}
+
 		if shouldExcludeMachine(machineLog, machineSet, machine) {
 			continue
 		}
 
 		// Attempt to adopt machine if it meets previous conditions and it has no controller references.
+		// Machines matching several MachineSets are left alone, as adopting them would only make the
+		// MachineSets fight over them.
 		if metav1.GetControllerOf(machine) == nil {
+			if others := r.getMachineSetsForMachine(ctx, machineLog, machine); len(others) > 1 {
+				names := make([]string, 0, len(others))
+				for _, other := range others {
+					names = append(names, other.Name)
+				}
+				machineLog.Infow("Not adopting Machine matching multiple MachineSets", "machinesets", names)
+				conflicts = append(conflicts, fmt.Sprintf("Machine %s matches the MachineSets %s", machine.Name, strings.Join(names, ", ")))
+				continue
+			}
+
 			if err := r.adoptOrphan(ctx, machineSet, machine); err != nil {
 				machineLog.Errorw("Failed to adopt Machine into MachineSet", zap.Error(err))
 				continue
 			}
+			r.recorder.Eventf(machineSet, corev1.EventTypeNormal, "Adopted", "Adopted Machine %s", machine.Name)
 		}
 
 		filteredMachines = append(filteredMachines, machine)
 	}
 
+	if err := r.reportOwnershipConflicts(ctx, machineSet, conflicts); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to report ownership conflicts")
+	}
+
 	syncErr := r.syncReplicas(ctx, log, machineSet, filteredMachines)
 
 	ms := machineSet.DeepCopy()
@@ -216,6 +257,14 @@ func (r *ReconcileMachineSet) reconcile(ctx context.Context, log *zap.SugaredLog
 		return reconcile.Result{}, errors.Wrap(err, "failed to update machine set status")
 	}
 
+	// Scaling down is retried later, as the Pods may become safe to evict.
+	var unsafeErr *unsafeToEvictError
+	if errors.As(syncErr, &unsafeErr) {
+		log.Infow("Postponing scale down", zap.Error(syncErr))
+		r.recorder.Eventf(machineSet, corev1.EventTypeWarning, "MachinesNotSafeToEvict", "%v", syncErr)
+		return reconcile.Result{RequeueAfter: unsafeToEvictRequeueInterval}, nil
+	}
+
 	if syncErr != nil {
 		return reconcile.Result{}, errors.Wrapf(syncErr, "failed to sync Machineset replicas")
 	}
@@ -255,16 +304,25 @@ func (r *ReconcileMachineSet) syncReplicas(ctx context.Context, log *zap.Sugared
 
 		var machineList []*clusterv1alpha1.Machine
 		var errstrings []string
+		failureDomains := failureDomainCounts(machines)
 		for i := 0; i < diff; i++ {
 			replicasLog.Infow("Creating new machine", "index", i+1)
 
-			machine := r.createMachine(ms)
+			failureDomain := nextFailureDomain(ms.Spec.FailureDomains, failureDomains)
+			machine, err := r.createMachine(ms, failureDomain)
+			if err != nil {
+				return err
+			}
+
 			if err := r.Create(ctx, machine); err != nil {
 				log.Errorw("Failed to create Machine", "machine", ctrlruntimeclient.ObjectKeyFromObject(machine), zap.Error(err))
 				errstrings = append(errstrings, err.Error())
 				continue
 			}
 
+			if failureDomain != nil {
+				failureDomains[failureDomain.Name]++
+			}
 			machineList = append(machineList, machine)
 		}
 
@@ -276,18 +334,23 @@ func (r *ReconcileMachineSet) syncReplicas(ctx context.Context, log *zap.Sugared
 	} else if diff > 0 {
 		replicasLog.Infow("Too many replicas, deleting extras", "diff", diff, "deletepolicy", ms.Spec.DeletePolicy)
 
-		deletePriorityFunc, err := getDeletePriorityFunc(ms)
+		deletePriorityFunc, err := r.getDeletePriorityFunc(ctx, ms, machines)
 		if err != nil {
 			return err
 		}
 
 		// Choose which Machines to delete.
-		machinesToDelete := getMachinesToDeletePrioritized(machines, diff, deletePriorityFunc)
+		var machinesToDelete []*clusterv1alpha1.Machine
+		if len(ms.Spec.FailureDomains) > 0 {
+			machinesToDelete = getMachinesToDeleteBalanced(machines, diff, deletePriorityFunc, ms.Spec.FailureDomains)
+		} else {
+			machinesToDelete = getMachinesToDeletePrioritized(machines, diff, deletePriorityFunc)
+		}
 
 		// TODO: Add cap to limit concurrent delete calls.
-		errCh := make(chan error, diff)
+		errCh := make(chan error, len(machinesToDelete))
 		var wg sync.WaitGroup
-		wg.Add(diff)
+		wg.Add(len(machinesToDelete))
 		for _, machine := range machinesToDelete {
 			go func(targetMachine *clusterv1alpha1.Machine) {
 				defer wg.Done()
@@ -309,28 +372,50 @@ func (r *ReconcileMachineSet) syncReplicas(ctx context.Context, log *zap.Sugared
 		default:
 		}
 
-		return r.waitForMachineDeletion(ctx, machinesToDelete)
+		if err := r.waitForMachineDeletion(ctx, machinesToDelete); err != nil {
+			return err
+		}
+
+		if protected := diff - len(machinesToDelete); protected > 0 {
+			return &unsafeToEvictError{machines: protected}
+		}
 	}
 
 	return nil
 }
 
 // createMachine creates a Machine resource. The name of the newly created resource is going
-// to be created by the API server, we set the generateName field.
-func (r *ReconcileMachineSet) createMachine(machineSet *clusterv1alpha1.MachineSet) *clusterv1alpha1.Machine {
+// to be created by the API server, we set the generateName field. If a failure domain is given,
+// it is applied to the providerSpec of the Machine.
+func (r *ReconcileMachineSet) createMachine(machineSet *clusterv1alpha1.MachineSet, failureDomain *clusterv1alpha1.FailureDomain) (*clusterv1alpha1.Machine, error) {
 	gv := clusterv1alpha1.SchemeGroupVersion
+	template := machineSet.Spec.Template.DeepCopy()
 	machine := &clusterv1alpha1.Machine{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       gv.WithKind("Machine").Kind,
 			APIVersion: gv.String(),
 		},
-		ObjectMeta: machineSet.Spec.Template.ObjectMeta,
-		Spec:       machineSet.Spec.Template.Spec,
+		ObjectMeta: template.ObjectMeta,
+		Spec:       template.Spec,
 	}
 	machine.GenerateName = fmt.Sprintf("%s-", machineSet.Name)
 	machine.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(machineSet, controllerKind)}
 	machine.Namespace = machineSet.Namespace
-	return machine
+
+	if failureDomain != nil {
+		providerSpec, err := controllerutil.ApplyFailureDomain(machine.Spec.ProviderSpec, *failureDomain)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to apply failure domain %q", failureDomain.Name)
+		}
+		machine.Spec.ProviderSpec = providerSpec
+
+		if machine.Labels == nil {
+			machine.Labels = map[string]string{}
+		}
+		machine.Labels[clusterv1alpha1.MachineFailureDomainLabelName] = failureDomain.Name
+	}
+
+	return machine, nil
 }
 
 // shouldExcludeMachine returns true if the machine should be filtered out, false otherwise.
@@ -352,6 +437,35 @@ func shouldExcludeMachine(machineLog *zap.SugaredLogger, machineSet *clusterv1al
 	return false
 }
 
+// ownershipConflict returns a description of the conflict if the machine is selected by the MachineSet,
+// but controlled by another object.
+func ownershipConflict(machineLog *zap.SugaredLogger, machineSet *clusterv1alpha1.MachineSet, machine *clusterv1alpha1.Machine) string {
+	controllerRef := metav1.GetControllerOf(machine)
+	if controllerRef == nil || metav1.IsControlledBy(machine, machineSet) || machine.DeletionTimestamp != nil {
+		return ""
+	}
+
+	if !hasMatchingLabels(machineLog, machineSet, machine) {
+		return ""
+	}
+
+	return fmt.Sprintf("Machine %s is controlled by %s %s", machine.Name, controllerRef.Kind, controllerRef.Name)
+}
+
+// reportOwnershipConflicts sets the OwnershipConflict condition of the MachineSet and emits
+// an event whenever new conflicts are found.
+func (r *ReconcileMachineSet) reportOwnershipConflicts(ctx context.Context, machineSet *clusterv1alpha1.MachineSet, conflicts []string) error {
+	if !controllerutil.SetOwnershipConflictCondition(&machineSet.Status.Conditions, machineSet.Generation, conflicts) {
+		return nil
+	}
+
+	if len(conflicts) > 0 {
+		r.recorder.Eventf(machineSet, corev1.EventTypeWarning, clusterv1alpha1.SelectorOverlapReason, "Selector matches Machines with conflicting ownership: %s", strings.Join(conflicts, "; "))
+	}
+
+	return r.Status().Update(ctx, machineSet)
+}
+
 // adoptOrphan sets the MachineSet as a controller OwnerReference to the Machine.
 func (r *ReconcileMachineSet) adoptOrphan(ctx context.Context, machineSet *clusterv1alpha1.MachineSet, machine *clusterv1alpha1.Machine) error {
 	newRef := *metav1.NewControllerRef(machineSet, controllerKind)
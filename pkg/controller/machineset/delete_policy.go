@@ -17,6 +17,7 @@ limitations under the License.
 package machineset
 
 import (
+	"context"
 	"math"
 	"sort"
 
@@ -24,7 +25,12 @@ import (
 
 	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type (
@@ -38,10 +44,21 @@ const (
 	// when a machineset scales down. This annotation is given top priority on all delete policies.
 	DeleteNodeAnnotation = "cluster.k8s.io/delete-machine"
 
+	// SafeToEvictAnnotation is the annotation used by the cluster-autoscaler to mark pods
+	// which must not be evicted. Nodes running such pods are never chosen by the LeastUtilized
+	// delete policy.
+	SafeToEvictAnnotation = "cluster-autoscaler.kubernetes.io/safe-to-evict"
+
 	mustDelete    deletePriority = 100.0
 	betterDelete  deletePriority = 50.0
 	couldDelete   deletePriority = 20.0
 	mustNotDelete deletePriority = 0.0
+	// neverDelete excludes a Machine from deletion altogether.
+	neverDelete deletePriority = -1.0
+
+	// leastUtilizedMinPriority is the lowest priority a fully utilized node can get, so that
+	// it can still be told apart from mustNotDelete.
+	leastUtilizedMinPriority deletePriority = 1.0
 
 	secondsPerTenDays float64 = 864000
 )
@@ -93,6 +110,119 @@ func randomDeletePolicy(machine *clusterv1alpha1.Machine) deletePriority {
 	return couldDelete
 }
 
+// leastUtilizedDeletePriority maps the utilization of a node onto the 1-50 priority range.
+// Nodes that only run DaemonSet or mirror pods get betterDelete, while nodes running pods that
+// are not safe to evict get neverDelete. The utilization is the higher of the requested CPU and
+// memory relative to the node's allocatable resources. Every pod using local storage (emptyDir
+// or hostPath volumes) makes the node less attractive for deletion.
+func leastUtilizedDeletePriority(node *corev1.Node, pods []corev1.Pod) deletePriority {
+	var (
+		requestedCPU      int64
+		requestedMemory   int64
+		workloadPods      int
+		localStoragePods  int
+		allocatableCPU    = node.Status.Allocatable.Cpu().MilliValue()
+		allocatableMemory = node.Status.Allocatable.Memory().Value()
+	)
+
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if pod.Annotations[SafeToEvictAnnotation] == "false" {
+			return neverDelete
+		}
+		if controllerRef := metav1.GetControllerOf(pod); controllerRef != nil && controllerRef.Kind == "DaemonSet" {
+			continue
+		}
+		if _, found := pod.Annotations[corev1.MirrorPodAnnotationKey]; found {
+			continue
+		}
+
+		workloadPods++
+		if hasLocalStorage(pod) {
+			localStoragePods++
+		}
+		for _, container := range pod.Spec.Containers {
+			requestedCPU += container.Resources.Requests.Cpu().MilliValue()
+			requestedMemory += container.Resources.Requests.Memory().Value()
+		}
+	}
+
+	if workloadPods == 0 {
+		return betterDelete
+	}
+
+	var utilization float64
+	if allocatableCPU > 0 {
+		utilization = math.Max(utilization, float64(requestedCPU)/float64(allocatableCPU))
+	}
+	if allocatableMemory > 0 {
+		utilization = math.Max(utilization, float64(requestedMemory)/float64(allocatableMemory))
+	}
+	utilization = math.Min(utilization, 1.0)
+
+	priority := float64(betterDelete-leastUtilizedMinPriority) * (1.0 - utilization) / float64(1+localStoragePods)
+	return leastUtilizedMinPriority + deletePriority(priority)
+}
+
+// hasLocalStorage returns true if the pod uses volumes whose data is lost when the node is deleted.
+func hasLocalStorage(pod *corev1.Pod) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.EmptyDir != nil || volume.HostPath != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// newLeastUtilizedDeletePriority computes the priority of all given machines upfront, as it
+// requires fetching their Nodes and Pods, and returns a deletePriorityFunc serving the results.
+func (r *ReconcileMachineSet) newLeastUtilizedDeletePriority(ctx context.Context, machines []*clusterv1alpha1.Machine) (deletePriorityFunc, error) {
+	priorities := make(map[types.UID]deletePriority, len(machines))
+
+	for _, machine := range machines {
+		switch {
+		case machine.DeletionTimestamp != nil && !machine.DeletionTimestamp.IsZero():
+			priorities[machine.UID] = mustDelete
+			continue
+		case machine.Annotations != nil && machine.Annotations[DeleteNodeAnnotation] != "":
+			priorities[machine.UID] = mustDelete
+			continue
+		case machine.Status.ErrorReason != nil || machine.Status.ErrorMessage != nil:
+			priorities[machine.UID] = mustDelete
+			continue
+		case machine.Status.NodeRef == nil:
+			// Machines without a Node don't run any workload.
+			priorities[machine.UID] = betterDelete
+			continue
+		}
+
+		node := &corev1.Node{}
+		if err := r.Get(ctx, types.NamespacedName{Name: machine.Status.NodeRef.Name}, node); err != nil {
+			if apierrors.IsNotFound(err) {
+				priorities[machine.UID] = betterDelete
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to get node %q", machine.Status.NodeRef.Name)
+		}
+
+		pods := &corev1.PodList{}
+		if err := r.apiReader.List(ctx, pods, &ctrlruntimeclient.ListOptions{
+			FieldSelector: fields.SelectorFromSet(fields.Set{"spec.nodeName": node.Name}),
+		}); err != nil {
+			return nil, errors.Wrapf(err, "failed to list pods for node %q", node.Name)
+		}
+
+		priorities[machine.UID] = leastUtilizedDeletePriority(node, pods.Items)
+	}
+
+	return func(machine *clusterv1alpha1.Machine) deletePriority {
+		return priorities[machine.UID]
+	}, nil
+}
+
 type sortableMachines struct {
 	machines []*clusterv1alpha1.Machine
 	priority deletePriorityFunc
@@ -107,9 +237,7 @@ func (m sortableMachines) Less(i, j int) bool {
 }
 
 func getMachinesToDeletePrioritized(filteredMachines []*clusterv1alpha1.Machine, diff int, fun deletePriorityFunc) []*clusterv1alpha1.Machine {
-	if diff >= len(filteredMachines) {
-		return filteredMachines
-	} else if diff <= 0 {
+	if diff <= 0 {
 		return []*clusterv1alpha1.Machine{}
 	}
 
@@ -119,10 +247,22 @@ func getMachinesToDeletePrioritized(filteredMachines []*clusterv1alpha1.Machine,
 	}
 	sort.Sort(sortable)
 
-	return sortable.machines[:diff]
+	if diff > len(sortable.machines) {
+		diff = len(sortable.machines)
+	}
+
+	// Machines which must never be deleted are sorted last, cut them off.
+	candidates := sortable.machines[:diff]
+	for i, machine := range candidates {
+		if fun(machine) <= neverDelete {
+			return candidates[:i]
+		}
+	}
+
+	return candidates
 }
 
-func getDeletePriorityFunc(ms *clusterv1alpha1.MachineSet) (deletePriorityFunc, error) {
+func (r *ReconcileMachineSet) getDeletePriorityFunc(ctx context.Context, ms *clusterv1alpha1.MachineSet, machines []*clusterv1alpha1.Machine) (deletePriorityFunc, error) {
 	// Map the Spec.DeletePolicy value to the appropriate delete priority function
 	switch msdp := clusterv1alpha1.MachineSetDeletePolicy(ms.Spec.DeletePolicy); msdp {
 	case clusterv1alpha1.RandomMachineSetDeletePolicy:
@@ -131,9 +271,11 @@ func getDeletePriorityFunc(ms *clusterv1alpha1.MachineSet) (deletePriorityFunc,
 		return newestDeletePriority, nil
 	case clusterv1alpha1.OldestMachineSetDeletePolicy:
 		return oldestDeletePriority, nil
+	case clusterv1alpha1.LeastUtilizedMachineSetDeletePolicy:
+		return r.newLeastUtilizedDeletePriority(ctx, machines)
 	case "":
 		return randomDeletePolicy, nil
 	default:
-		return nil, errors.Errorf("Unsupported delete policy %q. Must be one of 'Random', 'Newest', or 'Oldest'", msdp)
+		return nil, errors.Errorf("Unsupported delete policy %q. Must be one of 'Random', 'Newest', 'Oldest' or 'LeastUtilized'", msdp)
 	}
 }
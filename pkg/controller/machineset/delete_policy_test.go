@@ -0,0 +1,145 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machineset
+
+import (
+	"testing"
+
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+func testNode() *corev1.Node {
+	return &corev1.Node{
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+		},
+	}
+}
+
+func testPod(cpu, memory string) corev1.Pod {
+	return corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse(cpu),
+						corev1.ResourceMemory: resource.MustParse(memory),
+					},
+				},
+			}},
+		},
+	}
+}
+
+func TestLeastUtilizedDeletePriority(t *testing.T) {
+	daemonSetPod := testPod("1", "1Gi")
+	daemonSetPod.OwnerReferences = []metav1.OwnerReference{{Kind: "DaemonSet", Name: "ds", Controller: ptr.To(true)}}
+
+	notSafeToEvictPod := testPod("100m", "100Mi")
+	notSafeToEvictPod.Annotations = map[string]string{SafeToEvictAnnotation: "false"}
+
+	localStoragePod := testPod("1", "2Gi")
+	localStoragePod.Spec.Volumes = []corev1.Volume{{
+		Name:         "scratch",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}}
+
+	completedPod := testPod("4", "8Gi")
+	completedPod.Status.Phase = corev1.PodSucceeded
+
+	tests := []struct {
+		name     string
+		pods     []corev1.Pod
+		expected deletePriority
+	}{
+		{
+			name:     "empty node",
+			expected: betterDelete,
+		},
+		{
+			name:     "daemonset-only node",
+			pods:     []corev1.Pod{daemonSetPod},
+			expected: betterDelete,
+		},
+		{
+			name:     "completed pods are ignored",
+			pods:     []corev1.Pod{completedPod},
+			expected: betterDelete,
+		},
+		{
+			name:     "pod not safe to evict",
+			pods:     []corev1.Pod{testPod("100m", "100Mi"), notSafeToEvictPod},
+			expected: neverDelete,
+		},
+		{
+			name:     "fully utilized node",
+			pods:     []corev1.Pod{testPod("4", "1Gi")},
+			expected: leastUtilizedMinPriority,
+		},
+		{
+			name:     "half utilized node",
+			pods:     []corev1.Pod{testPod("1", "4Gi")},
+			expected: leastUtilizedMinPriority + (betterDelete-leastUtilizedMinPriority)/2,
+		},
+		{
+			name:     "half utilized node with local storage",
+			pods:     []corev1.Pod{localStoragePod, testPod("1", "2Gi")},
+			expected: leastUtilizedMinPriority + (betterDelete-leastUtilizedMinPriority)/4,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if priority := leastUtilizedDeletePriority(testNode(), test.pods); priority != test.expected {
+				t.Errorf("expected priority %v, got %v", test.expected, priority)
+			}
+		})
+	}
+}
+
+func TestGetMachinesToDeletePrioritizedSkipsNeverDelete(t *testing.T) {
+	priorities := map[types.UID]deletePriority{
+		"empty":     betterDelete,
+		"busy":      leastUtilizedMinPriority,
+		"protected": neverDelete,
+	}
+	priorityFunc := func(machine *clusterv1alpha1.Machine) deletePriority {
+		return priorities[machine.UID]
+	}
+
+	var machines []*clusterv1alpha1.Machine
+	for _, uid := range []types.UID{"protected", "busy", "empty"} {
+		machines = append(machines, &clusterv1alpha1.Machine{ObjectMeta: metav1.ObjectMeta{UID: uid}})
+	}
+
+	toDelete := getMachinesToDeletePrioritized(machines, 3, priorityFunc)
+	if len(toDelete) != 2 {
+		t.Fatalf("expected 2 machines to be deleted, got %d", len(toDelete))
+	}
+	if toDelete[0].UID != "empty" || toDelete[1].UID != "busy" {
+		t.Errorf("expected machines [empty busy], got [%s %s]", toDelete[0].UID, toDelete[1].UID)
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machineset
+
+import (
+	"sort"
+
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+)
+
+// failureDomainCounts returns the number of machines per failure domain.
+func failureDomainCounts(machines []*clusterv1alpha1.Machine) map[string]int {
+	counts := map[string]int{}
+	for _, machine := range machines {
+		counts[machine.Labels[clusterv1alpha1.MachineFailureDomainLabelName]]++
+	}
+	return counts
+}
+
+// nextFailureDomain returns the failure domain with the fewest machines. Ties are broken
+// by the order of the failure domains.
+func nextFailureDomain(failureDomains []clusterv1alpha1.FailureDomain, counts map[string]int) *clusterv1alpha1.FailureDomain {
+	var next *clusterv1alpha1.FailureDomain
+	for i := range failureDomains {
+		if next == nil || counts[failureDomains[i].Name] < counts[next.Name] {
+			next = &failureDomains[i]
+		}
+	}
+	return next
+}
+
+// getMachinesToDeleteBalanced chooses the machines to delete so that the remaining machines
+// stay spread evenly across the failure domains. Machines with mustDelete priority and machines
+// outside of the configured failure domains are chosen first. After that, the machine with the
+// highest priority in the failure domain with the most machines is chosen until diff is reached.
+func getMachinesToDeleteBalanced(machines []*clusterv1alpha1.Machine, diff int, fun deletePriorityFunc, failureDomains []clusterv1alpha1.FailureDomain) []*clusterv1alpha1.Machine {
+	if diff <= 0 {
+		return []*clusterv1alpha1.Machine{}
+	}
+
+	sortable := sortableMachines{
+		machines: machines,
+		priority: fun,
+	}
+	sort.Sort(sortable)
+
+	known := map[string]bool{}
+	for _, failureDomain := range failureDomains {
+		known[failureDomain.Name] = true
+	}
+
+	counts := failureDomainCounts(sortable.machines)
+	candidates := map[string][]*clusterv1alpha1.Machine{}
+	result := make([]*clusterv1alpha1.Machine, 0, diff)
+
+	for _, machine := range sortable.machines {
+		priority := fun(machine)
+		if priority <= neverDelete {
+			continue
+		}
+
+		failureDomain := machine.Labels[clusterv1alpha1.MachineFailureDomainLabelName]
+		if len(result) < diff && (priority >= mustDelete || !known[failureDomain]) {
+			result = append(result, machine)
+			counts[failureDomain]--
+			continue
+		}
+
+		candidates[failureDomain] = append(candidates[failureDomain], machine)
+	}
+
+	for len(result) < diff {
+		var largest string
+		for _, failureDomain := range failureDomains {
+			name := failureDomain.Name
+			if len(candidates[name]) == 0 {
+				continue
+			}
+			if largest == "" || counts[name] > counts[largest] ||
+				(counts[name] == counts[largest] && fun(candidates[name][0]) > fun(candidates[largest][0])) {
+				largest = name
+			}
+		}
+
+		if largest == "" {
+			break
+		}
+
+		result = append(result, candidates[largest][0])
+		candidates[largest] = candidates[largest][1:]
+		counts[largest]--
+	}
+
+	return result
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machineset
+
+import (
+	"fmt"
+	"testing"
+
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var testFailureDomains = []clusterv1alpha1.FailureDomain{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+
+func machinesInFailureDomains(failureDomains ...string) []*clusterv1alpha1.Machine {
+	var machines []*clusterv1alpha1.Machine
+	for i, failureDomain := range failureDomains {
+		machines = append(machines, &clusterv1alpha1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   fmt.Sprintf("machine-%d", i),
+				Labels: map[string]string{clusterv1alpha1.MachineFailureDomainLabelName: failureDomain},
+			},
+		})
+	}
+	return machines
+}
+
+func TestNextFailureDomain(t *testing.T) {
+	counts := failureDomainCounts(machinesInFailureDomains("a", "a", "b"))
+
+	var spread []string
+	for range 4 {
+		failureDomain := nextFailureDomain(testFailureDomains, counts)
+		counts[failureDomain.Name]++
+		spread = append(spread, failureDomain.Name)
+	}
+
+	if expected := "[c b c a]"; fmt.Sprint(spread) != expected {
+		t.Errorf("expected machines to be created in %s, got %v", expected, spread)
+	}
+
+	if failureDomain := nextFailureDomain(nil, counts); failureDomain != nil {
+		t.Errorf("expected no failure domain without configured failure domains, got %q", failureDomain.Name)
+	}
+}
+
+func TestGetMachinesToDeleteBalanced(t *testing.T) {
+	tests := []struct {
+		name     string
+		machines []*clusterv1alpha1.Machine
+		diff     int
+		expected map[string]int
+	}{
+		{
+			name:     "deletes from the largest failure domain",
+			machines: machinesInFailureDomains("a", "a", "a", "b", "c"),
+			diff:     2,
+			expected: map[string]int{"a": 2},
+		},
+		{
+			name:     "keeps failure domains balanced",
+			machines: machinesInFailureDomains("a", "a", "b", "b", "c", "c"),
+			diff:     3,
+			expected: map[string]int{"a": 1, "b": 1, "c": 1},
+		},
+		{
+			name:     "prefers machines outside of the failure domains",
+			machines: machinesInFailureDomains("a", "a", "b", "c", "removed"),
+			diff:     2,
+			expected: map[string]int{"a": 1, "removed": 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			toDelete := getMachinesToDeleteBalanced(test.machines, test.diff, randomDeletePolicy, testFailureDomains)
+			deleted := failureDomainCounts(toDelete)
+			if fmt.Sprint(deleted) != fmt.Sprint(test.expected) {
+				t.Errorf("expected machines to be deleted from %v, got %v", test.expected, deleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodecsrapprover
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var (
+	allowedClientUsages = []certificatesv1.KeyUsage{
+		certificatesv1.UsageDigitalSignature,
+		certificatesv1.UsageKeyEncipherment,
+		certificatesv1.UsageClientAuth,
+	}
+)
+
+// reconcileClientCSR approves the renewal of kubelet client certificates for nodes which are
+// backed by a Machine. Approvals are limited to one per node and approval interval, every
+// decision is recorded as an event on the Machine.
+func (r *reconciler) reconcileClientCSR(ctx context.Context, log *zap.SugaredLogger, csr *certificatesv1.CertificateSigningRequest) (reconcile.Result, error) {
+	if !r.approveClientCertificates {
+		log.Debug("Skipping client certificate CSR, as approving them is disabled")
+		return reconcile.Result{}, nil
+	}
+
+	if isApproved(csr) {
+		log.Debug("CSR already approved, skipping reconciling")
+		return reconcile.Result{}, nil
+	}
+	if isDenied(csr) {
+		log.Debug("CSR already denied, skipping reconciling")
+		return reconcile.Result{}, nil
+	}
+
+	nodeName, err := validateClientCSRObject(csr)
+	if err != nil {
+		log.Debugw("Skipping reconciling CSR because object is invalid", zap.Error(err))
+		return reconcile.Result{}, nil
+	}
+	nodeLog := log.With("node", nodeName)
+
+	machine, found, err := r.getMachineForNode(ctx, nodeName)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to get machine for node '%s': %w", nodeName, err)
+	}
+	if !found {
+		nodeLog.Debug("Skipping client certificate CSR of a node which is not backed by a machine")
+		return reconcile.Result{}, nil
+	}
+
+	if machine.DeletionTimestamp != nil {
+		r.recorder.Eventf(&machine, corev1.EventTypeWarning, "ClientCertificateRejected", "Not approving client certificate request %s, as the machine is being deleted", csr.Name)
+		return reconcile.Result{}, nil
+	}
+
+	certRequest, err := parseCertificateRequest(csr)
+	if err == nil {
+		err = validateClientX509CSR(csr, certRequest)
+	}
+	if err != nil {
+		nodeLog.Infow("Not approving invalid client certificate CSR", zap.Error(err))
+		r.recorder.Eventf(&machine, corev1.EventTypeWarning, "ClientCertificateRejected", "Not approving client certificate request %s: %v", csr.Name, err)
+		return reconcile.Result{}, nil
+	}
+
+	now := time.Now()
+	if wait := r.reserveClientCertificateApproval(nodeName, now); wait > 0 {
+		nodeLog.Infow("Delaying approval of client certificate CSR, as one was approved recently", "wait", wait)
+		r.recorder.Eventf(&machine, corev1.EventTypeWarning, "ClientCertificateRateLimited", "Delaying approval of client certificate request %s by %s, as one was approved recently", csr.Name, wait.Round(time.Second))
+		return reconcile.Result{RequeueAfter: wait}, nil
+	}
+
+	nodeLog.Debug("Approving client certificate CSR")
+	if err := r.approve(ctx, csr, "machine-controller NodeCSRApprover controller approved node client cert"); err != nil {
+		r.releaseClientCertificateApproval(nodeName, now)
+		return reconcile.Result{}, err
+	}
+
+	r.recorder.Eventf(&machine, corev1.EventTypeNormal, "ClientCertificateApproved", "Approved client certificate request %s of node %s", csr.Name, nodeName)
+	nodeLog.Info("Successfully approved client certificate CSR")
+	return reconcile.Result{}, nil
+}
+
+// reserveClientCertificateApproval returns how long to wait until the next client certificate of
+// the node may be approved. If it may be approved now, the approval is recorded.
+func (r *reconciler) reserveClientCertificateApproval(nodeName string, now time.Time) time.Duration {
+	r.clientCertificateApprovalsLock.Lock()
+	defer r.clientCertificateApprovalsLock.Unlock()
+
+	if last, ok := r.clientCertificateApprovals[nodeName]; ok {
+		if wait := last.Add(r.clientCertificateApprovalInterval).Sub(now); wait > 0 {
+			return wait
+		}
+	}
+
+	r.clientCertificateApprovals[nodeName] = now
+	return 0
+}
+
+// releaseClientCertificateApproval drops the approval reserved at the given time, so a failed
+// approval does not delay the next attempt.
+func (r *reconciler) releaseClientCertificateApproval(nodeName string, reserved time.Time) {
+	r.clientCertificateApprovalsLock.Lock()
+	defer r.clientCertificateApprovalsLock.Unlock()
+
+	if last, ok := r.clientCertificateApprovals[nodeName]; ok && last.Equal(reserved) {
+		delete(r.clientCertificateApprovals, nodeName)
+	}
+}
+
+// validateClientCSRObject validates the CSR object of a client certificate and returns the name
+// of the node that requested it.
+func validateClientCSRObject(csr *certificatesv1.CertificateSigningRequest) (string, error) {
+	nodeName, err := nodeNameForCSR(csr)
+	if err != nil {
+		return "", err
+	}
+
+	hasClientAuth := false
+	for _, usage := range csr.Spec.Usages {
+		if !isUsageInUsageList(usage, allowedClientUsages) {
+			return "", fmt.Errorf("usage %v is not in the list of allowed usages (%v)", usage, allowedClientUsages)
+		}
+		if usage == certificatesv1.UsageClientAuth {
+			hasClientAuth = true
+		}
+	}
+	if !hasClientAuth {
+		return "", fmt.Errorf("usage %v is missing", certificatesv1.UsageClientAuth)
+	}
+
+	return nodeName, nil
+}
+
+// validateClientX509CSR validates the certificate request of a client certificate by comparing
+// CN with username and organization with groups. Client certificates must not have SANs.
+func validateClientX509CSR(csr *certificatesv1.CertificateSigningRequest, certReq *x509.CertificateRequest) error {
+	if certReq.Subject.CommonName != csr.Spec.Username {
+		return fmt.Errorf("commonName '%s' is different then CSR username '%s'", certReq.Subject.CommonName, csr.Spec.Username)
+	}
+
+	if len(certReq.Subject.Organization) != 1 {
+		return fmt.Errorf("expected only one organization but got %d instead", len(certReq.Subject.Organization))
+	}
+	if certReq.Subject.Organization[0] != nodeGroup {
+		return fmt.Errorf("organization '%s' doesn't match node group '%s'", certReq.Subject.Organization[0], nodeGroup)
+	}
+
+	if len(certReq.DNSNames) > 0 || len(certReq.IPAddresses) > 0 || len(certReq.EmailAddresses) > 0 || len(certReq.URIs) > 0 {
+		return fmt.Errorf("client certificates must not have subject alternative names")
+	}
+
+	return nil
+}
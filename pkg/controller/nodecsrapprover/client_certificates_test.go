@@ -0,0 +1,303 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodecsrapprover
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testNodeName = "ip-172-31-114-48.eu-west-3.compute.internal"
+
+func generateClientCSR(t *testing.T, template *x509.CertificateRequest) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate request: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})
+}
+
+func clientCSR(name string, request []byte) *certificatesv1.CertificateSigningRequest {
+	return &certificatesv1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: certificatesv1.CertificateSigningRequestSpec{
+			Request:    request,
+			SignerName: certificatesv1.KubeAPIServerClientKubeletSignerName,
+			Usages: []certificatesv1.KeyUsage{
+				certificatesv1.UsageDigitalSignature,
+				certificatesv1.UsageKeyEncipherment,
+				certificatesv1.UsageClientAuth,
+			},
+			Username: nodeUserPrefix + testNodeName,
+			Groups:   []string{nodeGroup, "system:authenticated"},
+		},
+	}
+}
+
+func TestValidateClientCSRObject(t *testing.T) {
+	testCases := []struct {
+		name   string
+		modify func(*certificatesv1.CertificateSigningRequest)
+		err    string
+	}{
+		{
+			name:   "valid csr",
+			modify: func(*certificatesv1.CertificateSigningRequest) {},
+		},
+		{
+			name: "server auth usage",
+			modify: func(csr *certificatesv1.CertificateSigningRequest) {
+				csr.Spec.Usages = append(csr.Spec.Usages, certificatesv1.UsageServerAuth)
+			},
+			err: "is not in the list of allowed usages",
+		},
+		{
+			name: "missing client auth usage",
+			modify: func(csr *certificatesv1.CertificateSigningRequest) {
+				csr.Spec.Usages = []certificatesv1.KeyUsage{certificatesv1.UsageDigitalSignature}
+			},
+			err: "is missing",
+		},
+		{
+			name: "not requested by a node",
+			modify: func(csr *certificatesv1.CertificateSigningRequest) {
+				csr.Spec.Username = "system:serviceaccount:kube-system:bootstrap"
+			},
+			err: "username must have the",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			csr := clientCSR("csr", nil)
+			tc.modify(csr)
+
+			nodeName, err := validateClientCSRObject(csr)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if nodeName != testNodeName {
+					t.Errorf("expected node name %q, got %q", testNodeName, nodeName)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestValidateClientX509CSR(t *testing.T) {
+	testCases := []struct {
+		name     string
+		template *x509.CertificateRequest
+		err      string
+	}{
+		{
+			name: "valid request",
+			template: &x509.CertificateRequest{
+				Subject: pkix.Name{CommonName: nodeUserPrefix + testNodeName, Organization: []string{nodeGroup}},
+			},
+		},
+		{
+			name: "common name of another node",
+			template: &x509.CertificateRequest{
+				Subject: pkix.Name{CommonName: nodeUserPrefix + "other", Organization: []string{nodeGroup}},
+			},
+			err: "is different then CSR username",
+		},
+		{
+			name: "additional organization",
+			template: &x509.CertificateRequest{
+				Subject: pkix.Name{CommonName: nodeUserPrefix + testNodeName, Organization: []string{nodeGroup, "system:masters"}},
+			},
+			err: "expected only one organization",
+		},
+		{
+			name: "subject alternative names",
+			template: &x509.CertificateRequest{
+				Subject:  pkix.Name{CommonName: nodeUserPrefix + testNodeName, Organization: []string{nodeGroup}},
+				DNSNames: []string{testNodeName},
+			},
+			err: "must not have subject alternative names",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			csr := clientCSR("csr", generateClientCSR(t, tc.template))
+			certReq, err := parseCertificateRequest(csr)
+			if err != nil {
+				t.Fatalf("failed to parse certificate request: %v", err)
+			}
+
+			err = validateClientX509CSR(csr, certReq)
+			if tc.err == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestReconcileClientCSRRateLimit(t *testing.T) {
+	ctx := context.Background()
+	request := generateClientCSR(t, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: nodeUserPrefix + testNodeName, Organization: []string{nodeGroup}},
+	})
+	first := clientCSR("first", request)
+	second := clientCSR("second", request)
+
+	machine := &clusterv1alpha1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "machine", Namespace: metav1.NamespaceSystem},
+		Status: clusterv1alpha1.MachineStatus{
+			NodeRef: &corev1.ObjectReference{Kind: "Node", Name: testNodeName},
+		},
+	}
+
+	testScheme := runtime.NewScheme()
+	if err := clusterv1alpha1.AddToScheme(testScheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+
+	recorder := record.NewFakeRecorder(10)
+	r := &reconciler{
+		Client:                            fakectrlruntimeclient.NewClientBuilder().WithScheme(testScheme).WithObjects(machine).Build(),
+		log:                               zap.NewNop().Sugar(),
+		recorder:                          recorder,
+		certClient:                        kubefake.NewSimpleClientset(first, second).CertificatesV1().CertificateSigningRequests(),
+		approveClientCertificates:         true,
+		clientCertificateApprovalInterval: time.Hour,
+		clientCertificateApprovals:        map[string]time.Time{},
+	}
+
+	result, err := r.reconcileClientCSR(ctx, r.log, first)
+	if err != nil {
+		t.Fatalf("failed to reconcile first CSR: %v", err)
+	}
+	if result.RequeueAfter != 0 {
+		t.Errorf("expected first CSR not to be requeued, got %v", result.RequeueAfter)
+	}
+	if !isApproved(first) {
+		t.Error("expected first CSR to be approved")
+	}
+	if event := <-recorder.Events; !strings.Contains(event, "ClientCertificateApproved") {
+		t.Errorf("expected approval event, got %q", event)
+	}
+
+	result, err = r.reconcileClientCSR(ctx, r.log, second)
+	if err != nil {
+		t.Fatalf("failed to reconcile second CSR: %v", err)
+	}
+	if result.RequeueAfter <= 0 || result.RequeueAfter > time.Hour {
+		t.Errorf("expected second CSR to be requeued within the approval interval, got %v", result.RequeueAfter)
+	}
+	if isApproved(second) {
+		t.Error("expected second CSR not to be approved within the approval interval")
+	}
+	if event := <-recorder.Events; !strings.Contains(event, "ClientCertificateRateLimited") {
+		t.Errorf("expected rate limit event, got %q", event)
+	}
+}
+
+func TestReconcileClientCSRFailedApproval(t *testing.T) {
+	ctx := context.Background()
+	request := generateClientCSR(t, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: nodeUserPrefix + testNodeName, Organization: []string{nodeGroup}},
+	})
+	csr := clientCSR("csr", request)
+
+	machine := &clusterv1alpha1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "machine", Namespace: metav1.NamespaceSystem},
+		Status: clusterv1alpha1.MachineStatus{
+			NodeRef: &corev1.ObjectReference{Kind: "Node", Name: testNodeName},
+		},
+	}
+
+	testScheme := runtime.NewScheme()
+	if err := clusterv1alpha1.AddToScheme(testScheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+
+	clientset := kubefake.NewSimpleClientset(csr)
+	failed := false
+	clientset.PrependReactor("update", "certificatesigningrequests", func(_ clienttesting.Action) (bool, runtime.Object, error) {
+		if failed {
+			return false, nil, nil
+		}
+		failed = true
+		return true, nil, errors.New("apiserver is unavailable")
+	})
+
+	r := &reconciler{
+		Client:                            fakectrlruntimeclient.NewClientBuilder().WithScheme(testScheme).WithObjects(machine).Build(),
+		log:                               zap.NewNop().Sugar(),
+		recorder:                          record.NewFakeRecorder(10),
+		certClient:                        clientset.CertificatesV1().CertificateSigningRequests(),
+		approveClientCertificates:         true,
+		clientCertificateApprovalInterval: time.Hour,
+		clientCertificateApprovals:        map[string]time.Time{},
+	}
+
+	if _, err := r.reconcileClientCSR(ctx, r.log, csr.DeepCopy()); err == nil {
+		t.Fatal("expected the failed approval to be returned")
+	}
+
+	retry := csr.DeepCopy()
+	result, err := r.reconcileClientCSR(ctx, r.log, retry)
+	if err != nil {
+		t.Fatalf("failed to reconcile CSR: %v", err)
+	}
+	if result.RequeueAfter != 0 || !isApproved(retry) {
+		t.Errorf("expected the retry not to be rate limited by the failed approval, got requeue after %v", result.RequeueAfter)
+	}
+}
@@ -20,8 +20,11 @@ import (
 	"context"
 	"crypto/x509"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/go-logr/zapr"
@@ -35,6 +38,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	certificatesv1client "k8s.io/client-go/kubernetes/typed/certificates/v1"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -63,23 +67,47 @@ var (
 
 type reconciler struct {
 	ctrlruntimeclient.Client
-	log *zap.SugaredLogger
+	log      *zap.SugaredLogger
+	recorder record.EventRecorder
 	// Have to use the typed client because csr approval is a subresource
 	// the dynamic client does not approve
 	certClient certificatesv1client.CertificateSigningRequestInterface
+
+	// verifyInstanceIdentity enables validating serving certificate requests against the
+	// instance reported by the cloud provider.
+	verifyInstanceIdentity bool
+	lookupInstance         instanceLookup
+
+	// approveClientCertificates enables the approval of kubelet client certificate renewals.
+	approveClientCertificates bool
+	// clientCertificateApprovalInterval is the minimum time between two client certificate
+	// approvals for the same node.
+	clientCertificateApprovalInterval time.Duration
+	clientCertificateApprovalsLock    sync.Mutex
+	clientCertificateApprovals        map[string]time.Time
+
+	// allowCrossNamespaceRefs allows providerSpecs to reference secrets and configmaps of other namespaces.
+	allowCrossNamespaceRefs bool
 }
 
-func Add(mgr manager.Manager, log *zap.SugaredLogger) error {
+func Add(mgr manager.Manager, log *zap.SugaredLogger, verifyInstanceIdentity bool, approveClientCertificates bool, clientCertificateApprovalInterval time.Duration, allowCrossNamespaceRefs bool) error {
 	certClient, err := certificatesv1client.NewForConfig(mgr.GetConfig())
 	if err != nil {
 		return fmt.Errorf("failed to create certificate client: %w", err)
 	}
 
 	rec := &reconciler{
-		Client:     mgr.GetClient(),
-		log:        log.Named(ControllerName),
-		certClient: certClient.CertificateSigningRequests(),
+		Client:                            mgr.GetClient(),
+		log:                               log.Named(ControllerName),
+		recorder:                          mgr.GetEventRecorderFor(ControllerName),
+		certClient:                        certClient.CertificateSigningRequests(),
+		verifyInstanceIdentity:            verifyInstanceIdentity,
+		approveClientCertificates:         approveClientCertificates,
+		clientCertificateApprovalInterval: clientCertificateApprovalInterval,
+		clientCertificateApprovals:        map[string]time.Time{},
+		allowCrossNamespaceRefs:           allowCrossNamespaceRefs,
 	}
+	rec.lookupInstance = rec.getProviderInstance
 
 	_, err = builder.ControllerManagedBy(mgr).
 		Named(ControllerName).
@@ -109,6 +137,14 @@ func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		return reconcile.Result{}, err
 	}
 
+	if csr.Spec.SignerName == certificatesv1.KubeAPIServerClientKubeletSignerName {
+		result, err := r.reconcileClientCSR(ctx, log, csr)
+		if err != nil {
+			log.Errorw("Reconciling failed", zap.Error(err))
+		}
+		return result, err
+	}
+
 	err := r.reconcile(ctx, log, csr)
 	if err != nil {
 		log.Errorw("Reconciling failed", zap.Error(err))
@@ -118,12 +154,14 @@ func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 }
 
 func (r *reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, csr *certificatesv1.CertificateSigningRequest) error {
-	// If CSR is approved, skip it
-	for _, condition := range csr.Status.Conditions {
-		if condition.Type == certificatesv1.CertificateApproved {
-			log.Debug("CSR already approved, skipping reconciling")
-			return nil
-		}
+	// If CSR is approved or denied, skip it
+	if isApproved(csr) {
+		log.Debug("CSR already approved, skipping reconciling")
+		return nil
+	}
+	if isDenied(csr) {
+		log.Debug("CSR already denied, skipping reconciling")
+		return nil
 	}
 
 	// Validate the CSR object and get the node name
@@ -142,15 +180,7 @@ func (r *reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, csr
 		return fmt.Errorf("no machine found for given node '%s'", nodeName)
 	}
 
-	// Parse the certificate request
-	csrBlock, rest := pem.Decode(csr.Spec.Request)
-	if csrBlock == nil {
-		return fmt.Errorf("no certificate request found for the given CSR")
-	}
-	if len(rest) != 0 {
-		return fmt.Errorf("found more than one PEM encoded block in the result")
-	}
-	certRequest, err := x509.ParseCertificateRequest(csrBlock.Bytes)
+	certRequest, err := parseCertificateRequest(csr)
 	if err != nil {
 		return err
 	}
@@ -160,12 +190,34 @@ func (r *reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, csr
 		return fmt.Errorf("error validating the x509 certificate request: %w", err)
 	}
 
+	// Validate the certificate request against the live instance
+	if r.verifyInstanceIdentity {
+		if err := r.validateInstanceIdentity(ctx, log, &machine, certRequest); err != nil {
+			if !errors.Is(err, errInstanceIdentityMismatch) {
+				return err
+			}
+
+			log.Infow("Denying CSR", "node", nodeName, zap.Error(err))
+			r.recorder.Eventf(&machine, corev1.EventTypeWarning, "ServingCertificateDenied", "Denied serving certificate request %s: %v", csr.Name, err)
+			return r.deny(ctx, csr, InstanceIdentityMismatchReason, err.Error())
+		}
+	}
+
 	// Approve CSR
 	nodeLog := log.With("node", nodeName)
 	nodeLog.Debug("Approving CSR")
+	if err := r.approve(ctx, csr, "machine-controller NodeCSRApprover controller approved node serving cert"); err != nil {
+		return err
+	}
+
+	nodeLog.Info("Successfully approved CSR")
+	return nil
+}
+
+func (r *reconciler) approve(ctx context.Context, csr *certificatesv1.CertificateSigningRequest, reason string) error {
 	approvalCondition := certificatesv1.CertificateSigningRequestCondition{
 		Type:   certificatesv1.CertificateApproved,
-		Reason: "machine-controller NodeCSRApprover controller approved node serving cert",
+		Reason: reason,
 		Status: corev1.ConditionTrue,
 	}
 	csr.Status.Conditions = append(csr.Status.Conditions, approvalCondition)
@@ -173,13 +225,46 @@ func (r *reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, csr
 	if _, err := r.certClient.UpdateApproval(ctx, csr.Name, csr, metav1.UpdateOptions{}); err != nil {
 		return fmt.Errorf("failed to approve CSR %q: %w", csr.Name, err)
 	}
+	return nil
+}
+
+// deny marks the CSR as denied with the given reason and message, so the kubelet stops waiting for
+// it and the denial is visible on the CSR.
+func (r *reconciler) deny(ctx context.Context, csr *certificatesv1.CertificateSigningRequest, reason, message string) error {
+	denialCondition := certificatesv1.CertificateSigningRequestCondition{
+		Type:    certificatesv1.CertificateDenied,
+		Reason:  reason,
+		Message: message,
+		Status:  corev1.ConditionTrue,
+	}
+	csr.Status.Conditions = append(csr.Status.Conditions, denialCondition)
 
-	nodeLog.Info("Successfully approved CSR")
+	if _, err := r.certClient.UpdateApproval(ctx, csr.Name, csr, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to deny CSR %q: %w", csr.Name, err)
+	}
 	return nil
 }
 
 // validateCSRObject valides the CSR object and returns name of the node that requested the certificate.
 func (r *reconciler) validateCSRObject(csr *certificatesv1.CertificateSigningRequest) (string, error) {
+	nodeName, err := nodeNameForCSR(csr)
+	if err != nil {
+		return "", err
+	}
+
+	// Check that present usages matching allowed usages
+	for _, usage := range csr.Spec.Usages {
+		if !isUsageInUsageList(usage, allowedUsages) {
+			return "", fmt.Errorf("usage %v is not in the list of allowed usages (%v)", usage, allowedUsages)
+		}
+	}
+
+	return nodeName, nil
+}
+
+// nodeNameForCSR returns the name of the node that requested the certificate, after checking that
+// the CSR was created by a node.
+func nodeNameForCSR(csr *certificatesv1.CertificateSigningRequest) (string, error) {
 	// Get and validate the node name.
 	if !strings.HasPrefix(csr.Spec.Username, nodeUserPrefix) {
 		return "", fmt.Errorf("username must have the '%s' prefix", nodeUserPrefix)
@@ -197,14 +282,36 @@ func (r *reconciler) validateCSRObject(csr *certificatesv1.CertificateSigningReq
 		return "", fmt.Errorf("'%s' and/or '%s' are not in its groups", nodeGroup, authenticatedGroup)
 	}
 
-	// Check that present usages matching allowed usages
-	for _, usage := range csr.Spec.Usages {
-		if !isUsageInUsageList(usage, allowedUsages) {
-			return "", fmt.Errorf("usage %v is not in the list of allowed usages (%v)", usage, allowedUsages)
+	return nodeName, nil
+}
+
+func parseCertificateRequest(csr *certificatesv1.CertificateSigningRequest) (*x509.CertificateRequest, error) {
+	csrBlock, rest := pem.Decode(csr.Spec.Request)
+	if csrBlock == nil {
+		return nil, fmt.Errorf("no certificate request found for the given CSR")
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("found more than one PEM encoded block in the result")
+	}
+	return x509.ParseCertificateRequest(csrBlock.Bytes)
+}
+
+func isApproved(csr *certificatesv1.CertificateSigningRequest) bool {
+	for _, condition := range csr.Status.Conditions {
+		if condition.Type == certificatesv1.CertificateApproved {
+			return true
 		}
 	}
+	return false
+}
 
-	return nodeName, nil
+func isDenied(csr *certificatesv1.CertificateSigningRequest) bool {
+	for _, condition := range csr.Status.Conditions {
+		if condition.Type == certificatesv1.CertificateDenied {
+			return true
+		}
+	}
+	return false
 }
 
 // validateX509CSR validates the certificate request by comparing CN with username,
@@ -264,7 +371,7 @@ func (r *reconciler) getMachineForNode(ctx context.Context, nodeName string) (cl
 		}
 	}
 
-	return clusterv1alpha1.Machine{}, false, fmt.Errorf("failed to get machine for given node name '%s'", nodeName)
+	return clusterv1alpha1.Machine{}, false, nil
 }
 
 func isUsageInUsageList(usage certificatesv1.KeyUsage, usageList []certificatesv1.KeyUsage) bool {
@@ -16,6 +16,8 @@ limitations under the License.
 
 /*
 Package nodecsrapprover contains a controller responsible for autoapproving CSRs created by nodes
-for serving certificates.
+for serving certificates and, if enabled, for the renewal of their client certificates.
+Serving certificates can optionally be validated against the instance reported by the cloud
+provider, denying requests whose addresses no longer belong to the instance.
 */
 package nodecsrapprover
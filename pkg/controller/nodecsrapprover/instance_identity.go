@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodecsrapprover
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"k8c.io/machine-controller/pkg/cloudprovider"
+	cloudprovidererrors "k8c.io/machine-controller/pkg/cloudprovider/errors"
+	"k8c.io/machine-controller/pkg/cloudprovider/instance"
+	cloudprovidertypes "k8c.io/machine-controller/pkg/cloudprovider/types"
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+	"k8c.io/machine-controller/sdk/providerconfig"
+	"k8c.io/machine-controller/sdk/providerconfig/configvar"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// InstanceIdentityMismatchReason is the reason of the Denied condition of CSRs whose
+	// SANs do not match the instance reported by the cloud provider.
+	InstanceIdentityMismatchReason = "InstanceIdentityMismatch"
+)
+
+// instanceLookup returns the instance of the machine as reported by its cloud provider.
+type instanceLookup func(ctx context.Context, log *zap.SugaredLogger, machine *clusterv1alpha1.Machine) (instance.Instance, error)
+
+// errInstanceIdentityMismatch is returned if the CSR does not match the live instance. Other
+// errors are considered transient.
+var errInstanceIdentityMismatch = errors.New("certificate request does not match the instance")
+
+// getProviderInstance queries the cloud provider of the machine for its instance.
+func (r *reconciler) getProviderInstance(ctx context.Context, log *zap.SugaredLogger, machine *clusterv1alpha1.Machine) (instance.Instance, error) {
+	providerConfig, err := providerconfig.GetConfig(machine.Spec.ProviderSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provider config: %w", err)
+	}
+
+	configResolver := configvar.NewResolver(ctx, r.Client, append(
+		configvar.ForConfig(machine.Namespace, providerConfig, r.allowCrossNamespaceRefs),
+		configvar.WithAccessor(fmt.Sprintf("Machine %s/%s", machine.Namespace, machine.Name)),
+	)...)
+	prov, err := cloudprovider.ForProvider(providerConfig.CloudProvider, configResolver)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cloud provider %q: %w", providerConfig.CloudProvider, err)
+	}
+
+	return prov.Get(ctx, log, machine, &cloudprovidertypes.ProviderData{
+		Ctx:    ctx,
+		Update: cloudprovidertypes.GetMachineUpdater(ctx, r.Client),
+		Client: r.Client,
+	})
+}
+
+// validateInstanceIdentity confirms that the instance of the machine is running and that the
+// SANs of the certificate request are still among the addresses reported by the provider.
+// Errors wrapping errInstanceIdentityMismatch are permanent.
+func (r *reconciler) validateInstanceIdentity(ctx context.Context, log *zap.SugaredLogger, machine *clusterv1alpha1.Machine, certReq *x509.CertificateRequest) error {
+	providerInstance, err := r.lookupInstance(ctx, log, machine)
+	if err != nil {
+		if errors.Is(err, cloudprovidererrors.ErrInstanceNotFound) {
+			return fmt.Errorf("%w: instance of machine %s not found at the cloud provider", errInstanceIdentityMismatch, machine.Name)
+		}
+		return fmt.Errorf("failed to get instance of machine %s: %w", machine.Name, err)
+	}
+
+	if status := providerInstance.Status(); status != instance.StatusRunning {
+		return fmt.Errorf("%w: instance %s is %s instead of %s", errInstanceIdentityMismatch, providerInstance.Name(), status, instance.StatusRunning)
+	}
+
+	instanceAddresses := sets.New(machine.Status.NodeRef.Name)
+	for address := range providerInstance.Addresses() {
+		instanceAddresses.Insert(address)
+	}
+
+	for _, dns := range certReq.DNSNames {
+		if len(dns) > 0 && !instanceAddresses.Has(dns) {
+			return fmt.Errorf("%w: dns name '%s' is not reported for instance %s", errInstanceIdentityMismatch, dns, providerInstance.Name())
+		}
+	}
+	for _, ip := range certReq.IPAddresses {
+		if len(ip) > 0 && !instanceAddresses.Has(ip.String()) {
+			return fmt.Errorf("%w: ip address '%v' is not reported for instance %s", errInstanceIdentityMismatch, ip, providerInstance.Name())
+		}
+	}
+
+	return nil
+}
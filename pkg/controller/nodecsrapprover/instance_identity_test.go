@@ -0,0 +1,200 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodecsrapprover
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+
+	cloudprovidererrors "k8c.io/machine-controller/pkg/cloudprovider/errors"
+	"k8c.io/machine-controller/pkg/cloudprovider/instance"
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakeInstance struct {
+	status    instance.Status
+	addresses map[string]corev1.NodeAddressType
+}
+
+func (i *fakeInstance) Name() string {
+	return "instance"
+}
+
+func (i *fakeInstance) ID() string {
+	return "instance-id"
+}
+
+func (i *fakeInstance) ProviderID() string {
+	return "fake:///instance-id"
+}
+
+func (i *fakeInstance) Addresses() map[string]corev1.NodeAddressType {
+	return i.addresses
+}
+
+func (i *fakeInstance) Status() instance.Status {
+	return i.status
+}
+
+func staticInstanceLookup(inst instance.Instance, err error) instanceLookup {
+	return func(context.Context, *zap.SugaredLogger, *clusterv1alpha1.Machine) (instance.Instance, error) {
+		return inst, err
+	}
+}
+
+func testServingMachine() *clusterv1alpha1.Machine {
+	return &clusterv1alpha1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "machine", Namespace: metav1.NamespaceSystem},
+		Status: clusterv1alpha1.MachineStatus{
+			NodeRef: &corev1.ObjectReference{Kind: "Node", Name: testNodeName},
+			Addresses: []corev1.NodeAddress{
+				{Address: testNodeName, Type: corev1.NodeExternalDNS},
+				{Address: "192.0.2.24", Type: corev1.NodeExternalIP},
+			},
+		},
+	}
+}
+
+func TestValidateInstanceIdentity(t *testing.T) {
+	certReq, err := parseCertificateRequest(&certificatesv1.CertificateSigningRequest{
+		Spec: certificatesv1.CertificateSigningRequestSpec{Request: []byte(testValidCSR)},
+	})
+	if err != nil {
+		t.Fatalf("failed to parse certificate request: %v", err)
+	}
+
+	testCases := []struct {
+		name         string
+		lookup       instanceLookup
+		expectErr    bool
+		expectDenial bool
+	}{
+		{
+			name: "running instance with matching addresses",
+			lookup: staticInstanceLookup(&fakeInstance{
+				status:    instance.StatusRunning,
+				addresses: map[string]corev1.NodeAddressType{"192.0.2.24": corev1.NodeExternalIP},
+			}, nil),
+		},
+		{
+			name: "ip address reassigned",
+			lookup: staticInstanceLookup(&fakeInstance{
+				status:    instance.StatusRunning,
+				addresses: map[string]corev1.NodeAddressType{"192.0.2.25": corev1.NodeExternalIP},
+			}, nil),
+			expectErr:    true,
+			expectDenial: true,
+		},
+		{
+			name: "instance not running",
+			lookup: staticInstanceLookup(&fakeInstance{
+				status:    instance.StatusDeleting,
+				addresses: map[string]corev1.NodeAddressType{"192.0.2.24": corev1.NodeExternalIP},
+			}, nil),
+			expectErr:    true,
+			expectDenial: true,
+		},
+		{
+			name:         "instance not found",
+			lookup:       staticInstanceLookup(nil, cloudprovidererrors.ErrInstanceNotFound),
+			expectErr:    true,
+			expectDenial: true,
+		},
+		{
+			name:      "provider unavailable",
+			lookup:    staticInstanceLookup(nil, errors.New("connection refused")),
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &reconciler{lookupInstance: tc.lookup}
+
+			err := r.validateInstanceIdentity(context.Background(), zap.NewNop().Sugar(), testServingMachine(), certReq)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error: %v, got %v", tc.expectErr, err)
+			}
+			if errors.Is(err, errInstanceIdentityMismatch) != tc.expectDenial {
+				t.Errorf("expected denial: %v, got %v", tc.expectDenial, err)
+			}
+		})
+	}
+}
+
+func TestReconcileDeniesInstanceIdentityMismatch(t *testing.T) {
+	ctx := context.Background()
+	csr := &certificatesv1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "csr"},
+		Spec: certificatesv1.CertificateSigningRequestSpec{
+			Request:    []byte(testValidCSR),
+			SignerName: certificatesv1.KubeletServingSignerName,
+			Usages: []certificatesv1.KeyUsage{
+				certificatesv1.UsageDigitalSignature,
+				certificatesv1.UsageKeyEncipherment,
+				certificatesv1.UsageServerAuth,
+			},
+			Username: nodeUserPrefix + testNodeName,
+			Groups:   []string{nodeGroup, authenticatedGroup},
+		},
+	}
+
+	testScheme := runtime.NewScheme()
+	if err := clusterv1alpha1.AddToScheme(testScheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+
+	r := &reconciler{
+		Client:                 fakectrlruntimeclient.NewClientBuilder().WithScheme(testScheme).WithObjects(testServingMachine()).Build(),
+		recorder:               record.NewFakeRecorder(10),
+		certClient:             kubefake.NewSimpleClientset(csr.DeepCopy()).CertificatesV1().CertificateSigningRequests(),
+		verifyInstanceIdentity: true,
+		lookupInstance: staticInstanceLookup(&fakeInstance{
+			status:    instance.StatusRunning,
+			addresses: map[string]corev1.NodeAddressType{"192.0.2.25": corev1.NodeExternalIP},
+		}, nil),
+	}
+
+	if err := r.reconcile(ctx, zap.NewNop().Sugar(), csr); err != nil {
+		t.Fatalf("failed to reconcile: %v", err)
+	}
+
+	updated, err := r.certClient.Get(ctx, csr.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get CSR: %v", err)
+	}
+	if isApproved(updated) {
+		t.Error("expected CSR not to be approved")
+	}
+	if !isDenied(updated) {
+		t.Fatal("expected CSR to be denied")
+	}
+	if reason := updated.Status.Conditions[0].Reason; reason != InstanceIdentityMismatchReason {
+		t.Errorf("expected denial reason %q, got %q", InstanceIdentityMismatchReason, reason)
+	}
+}
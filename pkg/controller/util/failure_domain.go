@@ -0,0 +1,150 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+	providerconfigtypes "k8c.io/machine-controller/sdk/providerconfig"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// failureDomainFields maps the zone and subnet of a failure domain onto the
+// cloudProviderSpec fields of a provider.
+type failureDomainFields struct {
+	zone   func(spec map[string]interface{}, zone string)
+	subnet func(spec map[string]interface{}, subnet string)
+}
+
+func setField(name string) func(map[string]interface{}, string) {
+	return func(spec map[string]interface{}, value string) {
+		spec[name] = value
+	}
+}
+
+func setListField(name string) func(map[string]interface{}, string) {
+	return func(spec map[string]interface{}, value string) {
+		spec[name] = []interface{}{value}
+	}
+}
+
+var failureDomainProviders = map[providerconfigtypes.CloudProvider]failureDomainFields{
+	providerconfigtypes.CloudProviderAWS: {
+		zone:   setField("availabilityZone"),
+		subnet: setField("subnetId"),
+	},
+	providerconfigtypes.CloudProviderAzure: {
+		zone:   setListField("zones"),
+		subnet: setField("subnetName"),
+	},
+	providerconfigtypes.CloudProviderGoogle: {
+		zone:   setField("zone"),
+		subnet: setField("subnetwork"),
+	},
+	providerconfigtypes.CloudProviderOpenstack: {
+		zone:   setField("availabilityZone"),
+		subnet: setField("subnet"),
+	},
+	providerconfigtypes.CloudProviderVsphere: {
+		zone:   setField("cluster"),
+		subnet: setListField("networks"),
+	},
+}
+
+// FailureDomainSupported returns true if the zone and subnet of a failure domain can be
+// applied to the given provider. Failure domains with only a cloudProviderSpec patch work
+// for all providers.
+func FailureDomainSupported(provider providerconfigtypes.CloudProvider) bool {
+	_, ok := failureDomainProviders[provider]
+	return ok
+}
+
+// ApplyFailureDomain returns a copy of the providerSpec with the zone, subnet and
+// cloudProviderSpec patch of the failure domain applied.
+func ApplyFailureDomain(providerSpec clusterv1alpha1.ProviderSpec, failureDomain clusterv1alpha1.FailureDomain) (clusterv1alpha1.ProviderSpec, error) {
+	providerConfig, err := providerconfigtypes.GetConfig(providerSpec)
+	if err != nil {
+		return providerSpec, fmt.Errorf("failed to read providerSpec: %w", err)
+	}
+
+	cloudProviderSpec := map[string]interface{}{}
+	if len(providerConfig.CloudProviderSpec.Raw) > 0 {
+		if err := json.Unmarshal(providerConfig.CloudProviderSpec.Raw, &cloudProviderSpec); err != nil {
+			return providerSpec, fmt.Errorf("failed to unmarshal cloudProviderSpec: %w", err)
+		}
+	}
+
+	if failureDomain.Zone != "" || failureDomain.Subnet != "" {
+		fields, ok := failureDomainProviders[providerConfig.CloudProvider]
+		if !ok {
+			return providerSpec, fmt.Errorf("zone and subnet of failure domain %q are not supported for provider %q", failureDomain.Name, providerConfig.CloudProvider)
+		}
+		if failureDomain.Zone != "" {
+			fields.zone(cloudProviderSpec, failureDomain.Zone)
+		}
+		if failureDomain.Subnet != "" {
+			fields.subnet(cloudProviderSpec, failureDomain.Subnet)
+		}
+	}
+
+	if failureDomain.CloudProviderSpec != nil && len(failureDomain.CloudProviderSpec.Raw) > 0 {
+		patch := map[string]interface{}{}
+		if err := json.Unmarshal(failureDomain.CloudProviderSpec.Raw, &patch); err != nil {
+			return providerSpec, fmt.Errorf("failed to unmarshal cloudProviderSpec patch of failure domain %q: %w", failureDomain.Name, err)
+		}
+		cloudProviderSpec = mergePatch(cloudProviderSpec, patch)
+	}
+
+	providerConfig.CloudProviderSpec.Raw, err = json.Marshal(cloudProviderSpec)
+	if err != nil {
+		return providerSpec, fmt.Errorf("failed to marshal cloudProviderSpec: %w", err)
+	}
+
+	raw, err := json.Marshal(providerConfig)
+	if err != nil {
+		return providerSpec, fmt.Errorf("failed to marshal providerSpec: %w", err)
+	}
+
+	return clusterv1alpha1.ProviderSpec{Value: &runtime.RawExtension{Raw: raw}}, nil
+}
+
+// mergePatch applies a JSON merge patch (RFC 7386) to the target.
+func mergePatch(target, patch map[string]interface{}) map[string]interface{} {
+	for key, value := range patch {
+		if value == nil {
+			delete(target, key)
+			continue
+		}
+
+		patchObject, isObject := value.(map[string]interface{})
+		if !isObject {
+			target[key] = value
+			continue
+		}
+
+		targetObject, ok := target[key].(map[string]interface{})
+		if !ok {
+			targetObject = map[string]interface{}{}
+		}
+		target[key] = mergePatch(targetObject, patchObject)
+	}
+
+	return target
+}
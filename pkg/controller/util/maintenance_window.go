@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	// Embed the time zone database, so maintenance windows can be evaluated in any time
+	// zone, even if the container image does not ship one.
+	_ "time/tzdata"
+
+	"github.com/robfig/cron/v3"
+
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+)
+
+// ParseMaintenanceWindowSchedule parses the cron schedule of a maintenance window.
+func ParseMaintenanceWindowSchedule(schedule string) (cron.Schedule, error) {
+	return cron.ParseStandard(schedule)
+}
+
+// MaintenanceWindowLocation returns the time zone in which the maintenance windows are evaluated.
+func MaintenanceWindowLocation(windows *clusterv1alpha1.MaintenanceWindows) (*time.Location, error) {
+	if windows.TimeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(windows.TimeZone)
+}
+
+// MaintenanceWindowOpen returns whether one of the maintenance windows is open at the given time and
+// the start of the open window or, if none is open, of the next one. Without maintenance windows,
+// machines may be replaced at any time and a zero start is returned.
+func MaintenanceWindowOpen(windows *clusterv1alpha1.MaintenanceWindows, now time.Time) (bool, time.Time, error) {
+	if windows == nil || len(windows.Windows) == 0 {
+		return true, time.Time{}, nil
+	}
+
+	location, err := MaintenanceWindowLocation(windows)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("invalid time zone %q: %w", windows.TimeZone, err)
+	}
+	now = now.In(location)
+
+	var next time.Time
+	for _, window := range windows.Windows {
+		schedule, err := ParseMaintenanceWindowSchedule(window.Schedule)
+		if err != nil {
+			return false, time.Time{}, fmt.Errorf("invalid schedule %q: %w", window.Schedule, err)
+		}
+
+		// The first start after now minus the duration is either the start of the window
+		// which is open right now, or the start of the next window.
+		start := schedule.Next(now.Add(-window.Duration.Duration))
+		if start.IsZero() {
+			continue
+		}
+		if !start.After(now) {
+			return true, start, nil
+		}
+		if next.IsZero() || start.Before(next) {
+			next = start
+		}
+	}
+
+	if next.IsZero() {
+		return false, time.Time{}, errors.New("maintenance windows never open")
+	}
+
+	return false, next, nil
+}
+
+// RolloutPending returns true if machines of the MachineDeployment need to be replaced, because
+// there is no MachineSet for its current template yet or old MachineSets still have replicas. The
+// first MachineSet of a MachineDeployment is not a rollout.
+func RolloutPending(d *clusterv1alpha1.MachineDeployment, msList []*clusterv1alpha1.MachineSet) bool {
+	if len(msList) == 0 {
+		return false
+	}
+	if FindNewMachineSet(d, msList) == nil {
+		return true
+	}
+	_, oldMSs := FindOldMachineSets(d, msList)
+	return GetReplicaCountForMachineSets(oldMSs) > 0
+}
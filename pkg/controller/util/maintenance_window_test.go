@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMaintenanceWindowOpen(t *testing.T) {
+	nightly := &clusterv1alpha1.MaintenanceWindows{
+		TimeZone: "Europe/Berlin",
+		Windows: []clusterv1alpha1.MaintenanceWindow{
+			{Schedule: "0 22 * * *", Duration: metav1.Duration{Duration: 4 * time.Hour}},
+		},
+	}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		windows       *clusterv1alpha1.MaintenanceWindows
+		now           time.Time
+		expectedOpen  bool
+		expectedStart time.Time
+	}{
+		{
+			name:         "no maintenance windows",
+			now:          time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC),
+			expectedOpen: true,
+		},
+		{
+			name:          "before the window",
+			windows:       nightly,
+			now:           time.Date(2026, 3, 10, 12, 0, 0, 0, berlin),
+			expectedStart: time.Date(2026, 3, 10, 22, 0, 0, 0, berlin),
+		},
+		{
+			name:          "inside the window after midnight",
+			windows:       nightly,
+			now:           time.Date(2026, 3, 11, 1, 30, 0, 0, berlin),
+			expectedOpen:  true,
+			expectedStart: time.Date(2026, 3, 10, 22, 0, 0, 0, berlin),
+		},
+		{
+			name:          "after the window",
+			windows:       nightly,
+			now:           time.Date(2026, 3, 11, 2, 0, 0, 0, berlin),
+			expectedStart: time.Date(2026, 3, 11, 22, 0, 0, 0, berlin),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			open, start, err := MaintenanceWindowOpen(test.windows, test.now.UTC())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if open != test.expectedOpen {
+				t.Errorf("expected open to be %t, got %t", test.expectedOpen, open)
+			}
+			if !start.Equal(test.expectedStart) {
+				t.Errorf("expected start %v, got %v", test.expectedStart, start)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"strings"
+
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// SelectorsOverlap returns true if either of the selectors matches the template labels of the
+// other object. Controllers of both objects would then compete for the same objects.
+func SelectorsOverlap(selector *metav1.LabelSelector, templateLabels map[string]string, otherSelector *metav1.LabelSelector, otherTemplateLabels map[string]string) (bool, error) {
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false, err
+	}
+	otherSel, err := metav1.LabelSelectorAsSelector(otherSelector)
+	if err != nil {
+		return false, err
+	}
+
+	// Empty selectors are rejected by validation and match nothing in the controllers.
+	if sel.Empty() || otherSel.Empty() {
+		return false, nil
+	}
+
+	return sel.Matches(labels.Set(otherTemplateLabels)) || otherSel.Matches(labels.Set(templateLabels)), nil
+}
+
+// SetOwnershipConflictCondition sets the OwnershipConflict condition to true if there are conflicts,
+// using them as message, and to false otherwise. It returns true if the conditions were changed.
+func SetOwnershipConflictCondition(conditions *[]metav1.Condition, generation int64, conflicts []string) bool {
+	condition := metav1.Condition{
+		Type:               clusterv1alpha1.OwnershipConflictCondition,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             clusterv1alpha1.NoConflictReason,
+	}
+	if len(conflicts) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = clusterv1alpha1.SelectorOverlapReason
+		condition.Message = strings.Join(conflicts, "; ")
+	}
+
+	return meta.SetStatusCondition(conditions, condition)
+}
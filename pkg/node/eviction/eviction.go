@@ -20,6 +20,8 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -39,57 +41,151 @@ type NodeEviction struct {
 	nodeManager *nodemanager.NodeManager
 	nodeName    string
 	kubeClient  kubernetes.Interface
+	policy      Policy
+	drainStart  time.Time
 }
 
-// New returns a new NodeEviction.
-func New(nodeName string, client ctrlruntimeclient.Client, kubeClient kubernetes.Interface) *NodeEviction {
+// New returns a new NodeEviction. The drain start is used to determine whether the
+// pod eviction timeout of the policy passed.
+func New(nodeName string, client ctrlruntimeclient.Client, kubeClient kubernetes.Interface, policy Policy, drainStart time.Time) *NodeEviction {
 	return &NodeEviction{
 		nodeManager: nodemanager.New(client, nodeName),
 		nodeName:    nodeName,
 		kubeClient:  kubeClient,
+		policy:      policy,
+		drainStart:  drainStart,
 	}
 }
 
-// Run executes the eviction.
-func (ne *NodeEviction) Run(ctx context.Context, log *zap.SugaredLogger) (bool, error) {
+// Progress describes how far the drain of a node got.
+type Progress struct {
+	// PodsRemaining is the number of pods which still have to leave the node.
+	PodsRemaining int
+	// PodsBlockedByPDB is the number of pods whose eviction was refused by a PodDisruptionBudget.
+	PodsBlockedByPDB int
+	// PreDrainDelay is true while the drain waits after tainting the node.
+	PreDrainDelay bool
+}
+
+// Run executes the eviction. It returns true as long as pods still have to leave the node.
+func (ne *NodeEviction) Run(ctx context.Context, log *zap.SugaredLogger) (bool, Progress, error) {
 	nodeLog := log.With("node", ne.nodeName)
+	progress := Progress{}
 
 	node, err := ne.nodeManager.GetNode(ctx)
 	if err != nil {
-		return false, fmt.Errorf("failed to get node from lister: %w", err)
+		return false, progress, fmt.Errorf("failed to get node from lister: %w", err)
 	}
 	if _, exists := node.Annotations[nodetypes.SkipEvictionAnnotationKey]; exists {
 		nodeLog.Infof("Skipping eviction for node as it has a %s annotation", nodetypes.SkipEvictionAnnotationKey)
-		return false, nil
+		return false, progress, nil
+	}
+
+	if waiting, err := ne.preDrain(ctx, nodeLog, node); err != nil || waiting {
+		progress.PreDrainDelay = waiting
+		return waiting, progress, err
 	}
 
 	nodeLog.Info("Starting to evict node")
 
 	if err := ne.nodeManager.CordonNode(ctx, node); err != nil {
-		return false, fmt.Errorf("failed to cordon node %s: %w", ne.nodeName, err)
+		return false, progress, fmt.Errorf("failed to cordon node %s: %w", ne.nodeName, err)
 	}
 	nodeLog.Debug("Successfully cordoned node")
 
-	podsToEvict, err := ne.getFilteredPods(ctx)
+	podsToEvict, blockingPods, err := ne.getFilteredPods(ctx)
 	if err != nil {
-		return false, fmt.Errorf("failed to get Pods to evict for node %s: %w", ne.nodeName, err)
+		return false, progress, fmt.Errorf("failed to get Pods to evict for node %s: %w", ne.nodeName, err)
 	}
 	nodeLog.Debugf("Found %d pods to evict for node", len(podsToEvict))
 
+	return ne.drain(ctx, nodeLog, podsToEvict, blockingPods)
+}
+
+// drain evicts the pods, or stops waiting for them once the pod eviction timeout of the policy
+// passed. It returns true as long as pods still have to leave the node.
+func (ne *NodeEviction) drain(ctx context.Context, log *zap.SugaredLogger, podsToEvict, blockingPods []corev1.Pod) (bool, Progress, error) {
+	progress := Progress{PodsRemaining: len(podsToEvict) + len(blockingPods)}
+
+	// Pods with emptyDir volumes whose data may not be deleted are never evicted, so the
+	// drain has to wait until they are removed otherwise. The pod eviction timeout does
+	// not apply to them, as it would delete the Node and thereby their data anyway.
+	if len(blockingPods) > 0 {
+		log.Infow("Waiting for pods with emptyDir volumes to be removed, as deleting their data is not allowed", "pods", podKeys(blockingPods))
+	}
+
+	if ne.policy.PodEvictionTimeout > 0 && time.Since(ne.drainStart) > ne.policy.PodEvictionTimeout {
+		progress.PodsRemaining = len(blockingPods)
+		if len(podsToEvict) == 0 {
+			return len(blockingPods) > 0, progress, nil
+		}
+
+		if !ne.policy.DeleteAfterEvictionTimeout {
+			log.Infow("Pod eviction timeout passed, no longer waiting for pods", "timeout", ne.policy.PodEvictionTimeout, "pods", podKeys(podsToEvict))
+			return len(blockingPods) > 0, progress, nil
+		}
+
+		log.Infow("Pod eviction timeout passed, deleting remaining pods", "timeout", ne.policy.PodEvictionTimeout)
+		progress.PodsRemaining += len(podsToEvict)
+		if errs := ne.deletePods(ctx, log, podsToEvict); len(errs) > 0 {
+			return true, progress, fmt.Errorf("failed to delete pods, errors encountered: %v", errs)
+		}
+		return true, progress, nil
+	}
+
 	if len(podsToEvict) == 0 {
-		return false, nil
+		return len(blockingPods) > 0, progress, nil
 	}
 
 	// If we arrived here we have pods to evict, so tell the controller to retry later
-	if errs := ne.evictPods(ctx, nodeLog, podsToEvict); len(errs) > 0 {
-		return true, fmt.Errorf("failed to evict pods, errors encountered: %v", errs)
+	blocked, errs := ne.evictPods(ctx, log, podsToEvict)
+	progress.PodsBlockedByPDB = blocked
+	if len(errs) > 0 {
+		return true, progress, fmt.Errorf("failed to evict pods, errors encountered: %v", errs)
 	}
-	nodeLog.Debug("Successfully created evictions for all pods on node")
+	log.Debugw("Successfully created evictions for pods on node", "blockedByPDB", blocked)
+
+	return true, progress, nil
+}
 
-	return true, nil
+// PreDrain applies the pre-drain taint of the policy to the node and returns true as long as the
+// pre-drain delay did not pass yet. Run does this before cordoning the node, PreDrain is meant
+// for nodes which are deleted without being drained.
+func (ne *NodeEviction) PreDrain(ctx context.Context, log *zap.SugaredLogger) (bool, error) {
+	node, err := ne.nodeManager.GetNode(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get node from lister: %w", err)
+	}
+	return ne.preDrain(ctx, log.With("node", ne.nodeName), node)
 }
 
-func (ne *NodeEviction) getFilteredPods(ctx context.Context) ([]corev1.Pod, error) {
+func (ne *NodeEviction) preDrain(ctx context.Context, log *zap.SugaredLogger, node *corev1.Node) (bool, error) {
+	if ne.policy.PreDrainTaintEffect == "" {
+		return false, nil
+	}
+
+	taint, err := ne.nodeManager.TaintNode(ctx, node, corev1.Taint{
+		Key:    nodetypes.ToBeDeletedTaintKey,
+		Effect: ne.policy.PreDrainTaintEffect,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to taint node %s: %w", ne.nodeName, err)
+	}
+	if taint == nil || taint.TimeAdded == nil {
+		return false, nil
+	}
+
+	if remaining := ne.policy.PreDrainDelay - time.Since(taint.TimeAdded.Time); remaining > 0 {
+		log.Infow("Waiting after tainting node before draining it", "taint", nodetypes.ToBeDeletedTaintKey, "remaining", remaining.Round(time.Second))
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// getFilteredPods returns the pods to evict and the pods which block the drain, as they
+// use emptyDir volumes and the policy does not allow to delete their data.
+func (ne *NodeEviction) getFilteredPods(ctx context.Context) ([]corev1.Pod, []corev1.Pod, error) {
 	// The lister-backed client from the mgr automatically creates a lister for all objects requested through it.
 	// We explicitly do not want that for pods, hence we have to use the kubernetes core client
 	// TODO @alvaroaleman: Add source code ref for this
@@ -97,10 +193,10 @@ func (ne *NodeEviction) getFilteredPods(ctx context.Context) ([]corev1.Pod, erro
 		FieldSelector: fields.SelectorFromSet(fields.Set{"spec.nodeName": ne.nodeName}).String(),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list pods: %w", err)
+		return nil, nil, fmt.Errorf("failed to list pods: %w", err)
 	}
 
-	var filteredPods []corev1.Pod
+	var filteredPods, blockingPods []corev1.Pod
 	for _, candidatePod := range pods.Items {
 		if candidatePod.Status.Phase == corev1.PodSucceeded || candidatePod.Status.Phase == corev1.PodFailed {
 			continue
@@ -111,62 +207,90 @@ func (ne *NodeEviction) getFilteredPods(ctx context.Context) ([]corev1.Pod, erro
 		if _, found := candidatePod.Annotations[corev1.MirrorPodAnnotationKey]; found {
 			continue
 		}
+		if ne.policy.skipPod(&candidatePod) {
+			continue
+		}
+		if !ne.policy.DeleteEmptyDirData && hasEmptyDirVolume(&candidatePod) {
+			blockingPods = append(blockingPods, candidatePod)
+			continue
+		}
 		filteredPods = append(filteredPods, candidatePod)
 	}
 
-	return filteredPods, nil
+	return filteredPods, blockingPods, nil
 }
 
-func (ne *NodeEviction) evictPods(ctx context.Context, log *zap.SugaredLogger, pods []corev1.Pod) []error {
-	errCh := make(chan error, len(pods))
-	retErrs := []error{}
+// evictPods creates an eviction for every pod once and returns the number of pods whose
+// eviction was refused by a PodDisruptionBudget. These are evicted again when the controller
+// retries.
+func (ne *NodeEviction) evictPods(ctx context.Context, log *zap.SugaredLogger, pods []corev1.Pod) (int, []error) {
+	var blocked atomic.Int32
+	errs := ne.forEachPod(pods, func(p *corev1.Pod) error {
+		err := ne.evictPod(ctx, p)
+		if err == nil || apierrors.IsNotFound(err) {
+			log.Debugw("Successfully evicted pod on node", "pod", ctrlruntimeclient.ObjectKeyFromObject(p))
+			return nil
+		} else if apierrors.IsTooManyRequests(err) {
+			// PDB prevents eviction, make the controller retry later
+			blocked.Add(1)
+			return nil
+		}
+		return fmt.Errorf("error evicting pod %s/%s on node %s: %w", p.Namespace, p.Name, ne.nodeName, err)
+	})
+	return int(blocked.Load()), errs
+}
+
+// deletePods deletes the pods, bypassing PodDisruptionBudgets.
+func (ne *NodeEviction) deletePods(ctx context.Context, log *zap.SugaredLogger, pods []corev1.Pod) []error {
+	return ne.forEachPod(pods, func(p *corev1.Pod) error {
+		err := ne.kubeClient.CoreV1().Pods(p.Namespace).Delete(ctx, p.Name, ne.policy.deleteOptions())
+		if err == nil || apierrors.IsNotFound(err) {
+			log.Debugw("Successfully deleted pod on node", "pod", ctrlruntimeclient.ObjectKeyFromObject(p))
+			return nil
+		}
+		return fmt.Errorf("error deleting pod %s/%s on node %s: %w", p.Namespace, p.Name, ne.nodeName, err)
+	})
+}
 
-	var wg sync.WaitGroup
-	var isDone bool
-	defer func() { isDone = true }()
+func (ne *NodeEviction) forEachPod(pods []corev1.Pod, fn func(*corev1.Pod) error) []error {
+	var (
+		wg      sync.WaitGroup
+		lock    sync.Mutex
+		retErrs []error
+	)
 
 	wg.Add(len(pods))
 	for _, pod := range pods {
 		go func(p corev1.Pod) {
 			defer wg.Done()
-			for {
-				if isDone {
-					return
-				}
-				err := ne.evictPod(ctx, &p)
-				if err == nil || apierrors.IsNotFound(err) {
-					log.Debugw("Successfully evicted pod on node", "pod", ctrlruntimeclient.ObjectKeyFromObject(&p))
-					return
-				} else if apierrors.IsTooManyRequests(err) {
-					// PDB prevents eviction, return and make the controller retry later
-					return
-				}
-				errCh <- fmt.Errorf("error evicting pod %s/%s on node %s: %w", p.Namespace, p.Name, ne.nodeName, err)
+			if err := fn(&p); err != nil {
+				lock.Lock()
+				retErrs = append(retErrs, err)
+				lock.Unlock()
 			}
 		}(pod)
 	}
-
-	finished := make(chan struct{})
-	go func() { wg.Wait(); finished <- struct{}{} }()
-
-	select {
-	case <-finished:
-		log.Debug("All goroutines for eviction pods on node finished")
-		break
-	case err := <-errCh:
-		log.Debugw("Got an error from eviction goroutine for node", zap.Error(err))
-		retErrs = append(retErrs, err)
-	}
+	wg.Wait()
 
 	return retErrs
 }
 
 func (ne *NodeEviction) evictPod(ctx context.Context, pod *corev1.Pod) error {
+	deleteOptions := ne.policy.deleteOptions()
 	eviction := &policyv1beta1.Eviction{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      pod.Name,
 			Namespace: pod.Namespace,
 		},
+		DeleteOptions: &deleteOptions,
 	}
 	return ne.kubeClient.PolicyV1beta1().Evictions(eviction.Namespace).Evict(ctx, eviction)
 }
+
+func podKeys(pods []corev1.Pod) []string {
+	keys := make([]string, 0, len(pods))
+	for i := range pods {
+		keys = append(keys, ctrlruntimeclient.ObjectKeyFromObject(&pods[i]).String())
+	}
+	return keys
+}
@@ -19,13 +19,22 @@ package eviction
 import (
 	"context"
 	"testing"
+	"time"
 
 	"go.uber.org/zap"
 
+	"k8c.io/machine-controller/pkg/node/nodemanager"
+	nodetypes "k8c.io/machine-controller/sdk/node"
+
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	clienttesting "k8s.io/client-go/testing"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 // Unfortunately we can not directly test `EvictNode` as a List with a fieldSelector
@@ -35,6 +44,9 @@ func TestEvictPods(t *testing.T) {
 		Name          string
 		Pods          []runtime.Object
 		OutputObjects []runtime.Object
+		// Namespaces in which a PodDisruptionBudget refuses evictions
+		PDBNamespaces []string
+		BlockedByPDB  int
 	}{
 		{
 			Name: "TestEvictionsGetCreated",
@@ -49,6 +61,17 @@ func TestEvictPods(t *testing.T) {
 					Spec: corev1.PodSpec{NodeName: "node1"}},
 			},
 		},
+		{
+			Name: "TestEvictionsBlockedByPDB",
+			Pods: []runtime.Object{
+				&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "n1", Name: "pod1"},
+					Spec: corev1.PodSpec{NodeName: "node1"}},
+				&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "n2", Name: "pod2"},
+					Spec: corev1.PodSpec{NodeName: "node1"}},
+			},
+			PDBNamespaces: []string{"n2"},
+			BlockedByPDB:  1,
+		},
 	}
 
 	for _, test := range tests {
@@ -57,11 +80,22 @@ func TestEvictPods(t *testing.T) {
 			literalPods = append(literalPods, *(pod.(*corev1.Pod)))
 		}
 		client := kubefake.NewClientset(test.Pods...)
+		pdbNamespaces := sets.New(test.PDBNamespaces...)
+		client.PrependReactor("create", "pods", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			if action.GetSubresource() == "eviction" && pdbNamespaces.Has(action.GetNamespace()) {
+				return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+			}
+			return false, nil, nil
+		})
 		t.Run(test.Name, func(t *testing.T) {
-			ne := &NodeEviction{kubeClient: client, nodeName: "node1"}
-			if errs := ne.evictPods(context.Background(), zap.NewNop().Sugar(), literalPods); len(errs) > 0 {
+			ne := &NodeEviction{kubeClient: client, nodeName: "node1", policy: DefaultPolicy()}
+			blocked, errs := ne.evictPods(context.Background(), zap.NewNop().Sugar(), literalPods)
+			if len(errs) > 0 {
 				t.Fatalf("Got unexpected errors=%v when running evictPods", errs)
 			}
+			if blocked != test.BlockedByPDB {
+				t.Errorf("Expected %d pods blocked by PDB, got %d", test.BlockedByPDB, blocked)
+			}
 
 			actions := client.Actions()
 			for _, pod := range literalPods {
@@ -78,3 +112,201 @@ func TestEvictPods(t *testing.T) {
 		})
 	}
 }
+
+func TestDrainEvictionTimeout(t *testing.T) {
+	evictable := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "n1", Name: "pod1"}, Spec: corev1.PodSpec{NodeName: "node1"}}
+	blocking := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "n2", Name: "pod2"}, Spec: corev1.PodSpec{NodeName: "node1"}}
+
+	tests := []struct {
+		name                 string
+		podsToEvict          []corev1.Pod
+		blockingPods         []corev1.Pod
+		timeoutPassed        bool
+		deleteAfterTimeout   bool
+		expectedWaiting      bool
+		expectedSubresources []string
+	}{
+		{
+			name:            "waiting for pods with emptyDir volumes",
+			blockingPods:    []corev1.Pod{blocking},
+			expectedWaiting: true,
+		},
+		{
+			name:            "timeout passed for pods with emptyDir volumes",
+			blockingPods:    []corev1.Pod{blocking},
+			timeoutPassed:   true,
+			expectedWaiting: true,
+		},
+		{
+			name:                 "evicting pods",
+			podsToEvict:          []corev1.Pod{evictable},
+			blockingPods:         []corev1.Pod{blocking},
+			expectedWaiting:      true,
+			expectedSubresources: []string{"eviction"},
+		},
+		{
+			name:          "timeout passed for pods",
+			podsToEvict:   []corev1.Pod{evictable},
+			timeoutPassed: true,
+		},
+		{
+			name:            "timeout passed for pods and pods with emptyDir volumes",
+			podsToEvict:     []corev1.Pod{evictable},
+			blockingPods:    []corev1.Pod{blocking},
+			timeoutPassed:   true,
+			expectedWaiting: true,
+		},
+		{
+			name:                 "deleting pods after timeout",
+			podsToEvict:          []corev1.Pod{evictable},
+			blockingPods:         []corev1.Pod{blocking},
+			timeoutPassed:        true,
+			deleteAfterTimeout:   true,
+			expectedWaiting:      true,
+			expectedSubresources: []string{""},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := kubefake.NewClientset(&evictable, &blocking)
+
+			policy := DefaultPolicy()
+			policy.PodEvictionTimeout = time.Hour
+			policy.DeleteAfterEvictionTimeout = test.deleteAfterTimeout
+			drainStart := time.Now()
+			if test.timeoutPassed {
+				drainStart = drainStart.Add(-2 * time.Hour)
+			}
+			ne := &NodeEviction{kubeClient: client, nodeName: "node1", policy: policy, drainStart: drainStart}
+
+			waiting, _, err := ne.drain(context.Background(), zap.NewNop().Sugar(), test.podsToEvict, test.blockingPods)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if waiting != test.expectedWaiting {
+				t.Errorf("expected waiting to be %t, got %t", test.expectedWaiting, waiting)
+			}
+
+			var subresources []string
+			for _, action := range client.Actions() {
+				if action.GetVerb() == "create" || action.GetVerb() == "delete" {
+					if action.GetNamespace() != evictable.Namespace {
+						t.Errorf("expected only pod %s/%s to be evicted or deleted, got %v", evictable.Namespace, evictable.Name, action)
+					}
+					subresources = append(subresources, action.GetSubresource())
+				}
+			}
+			if len(subresources) != len(test.expectedSubresources) {
+				t.Fatalf("expected actions on subresources %q, got %q", test.expectedSubresources, subresources)
+			}
+			for i := range subresources {
+				if subresources[i] != test.expectedSubresources[i] {
+					t.Errorf("expected actions on subresources %q, got %q", test.expectedSubresources, subresources)
+				}
+			}
+		})
+	}
+}
+
+func TestRunEvictionTimeoutWithEmptyDirData(t *testing.T) {
+	evictable := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "n1", Name: "pod1"}, Spec: corev1.PodSpec{NodeName: "node1"}}
+	withEmptyDir := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "n2", Name: "pod2"},
+		Spec: corev1.PodSpec{
+			NodeName: "node1",
+			Volumes:  []corev1.Volume{{Name: "data", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+		},
+	}
+	client := kubefake.NewClientset(evictable, withEmptyDir)
+
+	policy := DefaultPolicy()
+	policy.DeleteEmptyDirData = false
+	policy.PodEvictionTimeout = time.Hour
+	policy.DeleteAfterEvictionTimeout = true
+	ne := &NodeEviction{kubeClient: client, nodeName: "node1", policy: policy, drainStart: time.Now().Add(-2 * time.Hour)}
+
+	podsToEvict, blockingPods, err := ne.getFilteredPods(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get pods: %v", err)
+	}
+	waiting, progress, err := ne.drain(context.Background(), zap.NewNop().Sugar(), podsToEvict, blockingPods)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !waiting {
+		t.Error("expected the drain to keep waiting for the pod with an emptyDir volume after the timeout")
+	}
+	if progress.PodsRemaining != 2 {
+		t.Errorf("expected 2 remaining pods, got %d", progress.PodsRemaining)
+	}
+
+	if _, err := client.CoreV1().Pods(withEmptyDir.Namespace).Get(context.Background(), withEmptyDir.Name, metav1.GetOptions{}); err != nil {
+		t.Errorf("expected pod with an emptyDir volume to be kept, got %v", err)
+	}
+	if _, err := client.CoreV1().Pods(evictable.Namespace).Get(context.Background(), evictable.Name, metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected pod without emptyDir volumes to be deleted, got %v", err)
+	}
+}
+
+func TestPreDrain(t *testing.T) {
+	tests := []struct {
+		name            string
+		taints          []corev1.Taint
+		expectedWaiting bool
+	}{
+		{
+			name:            "node gets tainted",
+			expectedWaiting: true,
+		},
+		{
+			name: "delay not passed yet",
+			taints: []corev1.Taint{{
+				Key:       nodetypes.ToBeDeletedTaintKey,
+				Effect:    corev1.TaintEffectPreferNoSchedule,
+				TimeAdded: &metav1.Time{Time: time.Now().Add(-time.Minute)},
+			}},
+			expectedWaiting: true,
+		},
+		{
+			name: "delay passed",
+			taints: []corev1.Taint{{
+				Key:       nodetypes.ToBeDeletedTaintKey,
+				Effect:    corev1.TaintEffectPreferNoSchedule,
+				TimeAdded: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+			}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+				Spec:       corev1.NodeSpec{Taints: test.taints},
+			}
+			client := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(node).Build()
+
+			policy := DefaultPolicy()
+			policy.PreDrainTaintEffect = corev1.TaintEffectPreferNoSchedule
+			policy.PreDrainDelay = 5 * time.Minute
+			ne := &NodeEviction{nodeManager: nodemanager.New(client, "node1"), nodeName: "node1", policy: policy}
+
+			waiting, err := ne.PreDrain(ctx, zap.NewNop().Sugar())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if waiting != test.expectedWaiting {
+				t.Errorf("expected waiting to be %t, got %t", test.expectedWaiting, waiting)
+			}
+
+			tainted, err := ne.nodeManager.GetNode(ctx)
+			if err != nil {
+				t.Fatalf("failed to get node: %v", err)
+			}
+			if len(tainted.Spec.Taints) != 1 || tainted.Spec.Taints[0].Key != nodetypes.ToBeDeletedTaintKey {
+				t.Errorf("expected node to have exactly the pre-drain taint, got %v", tainted.Spec.Taints)
+			}
+		})
+	}
+}
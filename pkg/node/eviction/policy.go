@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eviction
+
+import (
+	"fmt"
+	"time"
+
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Policy configures how the pods of a node are evicted.
+type Policy struct {
+	// GracePeriodSeconds overrides the termination grace period of the pods. If negative,
+	// the grace period of the pods is used.
+	GracePeriodSeconds int64
+	// SkipPodSelector selects pods which are not evicted. If nil, no pods are skipped.
+	SkipPodSelector labels.Selector
+	// DeleteEmptyDirData allows to evict pods using emptyDir volumes.
+	DeleteEmptyDirData bool
+	// PodEvictionTimeout is how long pods are evicted, counted from the start of the
+	// drain. Zero means no timeout.
+	PodEvictionTimeout time.Duration
+	// DeleteAfterEvictionTimeout deletes the remaining pods once the PodEvictionTimeout
+	// passed, instead of no longer waiting for them.
+	DeleteAfterEvictionTimeout bool
+	// PreDrainTaintEffect is the effect of the taint applied to the node before it is cordoned.
+	// If empty, no taint is applied.
+	PreDrainTaintEffect corev1.TaintEffect
+	// PreDrainDelay is how long to wait after applying the pre-drain taint.
+	PreDrainDelay time.Duration
+}
+
+// DefaultPolicy returns the policy which evicts all pods with their own grace period
+// and without a timeout.
+func DefaultPolicy() Policy {
+	return Policy{
+		GracePeriodSeconds: -1,
+		DeleteEmptyDirData: true,
+	}
+}
+
+// WithOverride returns the policy with the fields which are set in the override replaced.
+func (p Policy) WithOverride(override *clusterv1alpha1.DrainPolicy) (Policy, error) {
+	if override == nil {
+		return p, nil
+	}
+
+	if override.GracePeriodSeconds != nil {
+		p.GracePeriodSeconds = *override.GracePeriodSeconds
+	}
+	if override.SkipPodSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(override.SkipPodSelector)
+		if err != nil {
+			return p, fmt.Errorf("invalid skipPodSelector: %w", err)
+		}
+		p.SkipPodSelector = selector
+	}
+	if override.DeleteEmptyDirData != nil {
+		p.DeleteEmptyDirData = *override.DeleteEmptyDirData
+	}
+	if override.PodEvictionTimeout != nil {
+		p.PodEvictionTimeout = override.PodEvictionTimeout.Duration
+	}
+	if override.DeleteAfterEvictionTimeout != nil {
+		p.DeleteAfterEvictionTimeout = *override.DeleteAfterEvictionTimeout
+	}
+	if override.PreDrainTaintEffect != nil {
+		p.PreDrainTaintEffect = *override.PreDrainTaintEffect
+	}
+	if override.PreDrainDelay != nil {
+		p.PreDrainDelay = override.PreDrainDelay.Duration
+	}
+
+	return p, nil
+}
+
+// ValidatePreDrainTaintEffect returns an error if the effect can not be used for the pre-drain taint.
+// NoExecute is not allowed, as it would evict pods bypassing PodDisruptionBudgets.
+func ValidatePreDrainTaintEffect(effect corev1.TaintEffect) error {
+	switch effect {
+	case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule:
+		return nil
+	default:
+		return fmt.Errorf("unsupported taint effect %q, must be one of %q or %q", effect, corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule)
+	}
+}
+
+func (p Policy) skipPod(pod *corev1.Pod) bool {
+	return p.SkipPodSelector != nil && p.SkipPodSelector.Matches(labels.Set(pod.Labels))
+}
+
+func (p Policy) deleteOptions() metav1.DeleteOptions {
+	if p.GracePeriodSeconds < 0 {
+		return metav1.DeleteOptions{}
+	}
+	gracePeriod := p.GracePeriodSeconds
+	return metav1.DeleteOptions{GracePeriodSeconds: &gracePeriod}
+}
+
+func hasEmptyDirVolume(pod *corev1.Pod) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.EmptyDir != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eviction
+
+import (
+	"testing"
+	"time"
+
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestPolicyWithOverride(t *testing.T) {
+	defaults := DefaultPolicy()
+	defaults.PodEvictionTimeout = 10 * time.Minute
+
+	policy, err := defaults.WithOverride(&clusterv1alpha1.DrainPolicy{
+		GracePeriodSeconds: ptr.To[int64](30),
+		SkipPodSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"drain": "skip"}},
+		DeleteEmptyDirData: ptr.To(false),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if policy.GracePeriodSeconds != 30 {
+		t.Errorf("expected grace period 30, got %d", policy.GracePeriodSeconds)
+	}
+	if policy.DeleteEmptyDirData {
+		t.Error("expected emptyDir data not to be deleted")
+	}
+	if policy.PodEvictionTimeout != 10*time.Minute {
+		t.Errorf("expected the eviction timeout of the defaults, got %v", policy.PodEvictionTimeout)
+	}
+	if policy.DeleteAfterEvictionTimeout {
+		t.Error("expected pods not to be deleted after the eviction timeout")
+	}
+
+	skipped := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"drain": "skip"}}}
+	if !policy.skipPod(skipped) {
+		t.Error("expected pod matching the selector to be skipped")
+	}
+	if policy.skipPod(&corev1.Pod{}) {
+		t.Error("expected pod not matching the selector to be evicted")
+	}
+	if defaults.skipPod(skipped) {
+		t.Error("expected default policy not to skip pods")
+	}
+}
+
+func TestPolicyWithInvalidOverride(t *testing.T) {
+	_, err := DefaultPolicy().WithOverride(&clusterv1alpha1.DrainPolicy{
+		SkipPodSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "drain", Operator: "Unknown"},
+		}},
+	})
+	if err == nil {
+		t.Fatal("expected error for invalid selector")
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/retry"
@@ -76,6 +77,41 @@ func (nm *NodeManager) CordonNode(ctx context.Context, node *corev1.Node) error
 	})
 }
 
+// TaintNode adds the taint to the node, unless a taint with the same key and effect exists, and
+// returns the taint of the node. The time at which the taint was added is recorded, so callers
+// can wait for a while after tainting the node.
+func (nm *NodeManager) TaintNode(ctx context.Context, node *corev1.Node, taint corev1.Taint) (*corev1.Taint, error) {
+	if existing := findTaint(node, taint); existing != nil && existing.TimeAdded != nil {
+		return existing, nil
+	}
+
+	node, err := nm.updateNode(ctx, func(n *corev1.Node) {
+		now := metav1.Now()
+		if existing := findTaint(n, taint); existing != nil {
+			if existing.TimeAdded == nil {
+				existing.TimeAdded = &now
+			}
+			return
+		}
+		taint.TimeAdded = &now
+		n.Spec.Taints = append(n.Spec.Taints, taint)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return findTaint(node, taint), nil
+}
+
+func findTaint(node *corev1.Node, taint corev1.Taint) *corev1.Taint {
+	for i := range node.Spec.Taints {
+		if node.Spec.Taints[i].MatchTaint(&taint) {
+			return &node.Spec.Taints[i]
+		}
+	}
+	return nil
+}
+
 func (nm *NodeManager) updateNode(ctx context.Context, modify func(*corev1.Node)) (*corev1.Node, error) {
 	node := &corev1.Node{}
 	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
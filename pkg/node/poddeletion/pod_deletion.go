@@ -26,19 +26,36 @@ import (
 	"k8c.io/machine-controller/pkg/node/nodemanager"
 
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-const (
-	errorQueueLen = 100
-)
+// VolumeAttachmentNodeNameIndex is the field index of VolumeAttachments by the name of the
+// node the volume is attached to.
+const VolumeAttachmentNodeNameIndex = "spec.nodeName"
+
+// IndexVolumeAttachments registers the VolumeAttachmentNodeNameIndex, which is required by
+// NodeVolumeAttachmentsCleanup.
+func IndexVolumeAttachments(ctx context.Context, indexer ctrlruntimeclient.FieldIndexer) error {
+	return indexer.IndexField(ctx, &storagev1.VolumeAttachment{}, VolumeAttachmentNodeNameIndex, func(obj ctrlruntimeclient.Object) []string {
+		volumeAttachment, ok := obj.(*storagev1.VolumeAttachment)
+		if !ok {
+			return nil
+		}
+		return []string{volumeAttachment.Spec.NodeName}
+	})
+}
 
 type NodeVolumeAttachmentsCleanup struct {
 	nodeManager *nodemanager.NodeManager
 	nodeName    string
+	client      ctrlruntimeclient.Client
 	kubeClient  kubernetes.Interface
 }
 
@@ -47,11 +64,13 @@ func New(nodeName string, client ctrlruntimeclient.Client, kubeClient kubernetes
 	return &NodeVolumeAttachmentsCleanup{
 		nodeManager: nodemanager.New(client, nodeName),
 		nodeName:    nodeName,
+		client:      client,
 		kubeClient:  kubeClient,
 	}
 }
 
-// Run executes the pod deletion.
+// Run executes the pod deletion. It returns whether pods were deleted and whether all volumes
+// were detached from the node.
 func (vc *NodeVolumeAttachmentsCleanup) Run(ctx context.Context, log *zap.SugaredLogger) (bool, bool, error) {
 	node, err := vc.nodeManager.GetNode(ctx)
 	if err != nil {
@@ -61,14 +80,18 @@ func (vc *NodeVolumeAttachmentsCleanup) Run(ctx context.Context, log *zap.Sugare
 	nodeLog := log.With("node", vc.nodeName)
 	nodeLog.Info("Starting to cleanup node...")
 
-	// if there are no more volumeAttachments related to the node, then it can be deleted.
-	volumeAttachmentsDeleted, err := vc.nodeCanBeDeleted(ctx, nodeLog)
+	volumeAttachments, err := vc.volumeAttachments(ctx)
 	if err != nil {
-		return false, false, fmt.Errorf("failed to check volumeAttachments deletion: %w", err)
+		return false, false, err
 	}
-	if volumeAttachmentsDeleted {
+
+	// if there are no more volumeAttachments related to the node, then it can be deleted.
+	if len(volumeAttachments) == 0 {
 		return false, true, nil
 	}
+	for _, va := range volumeAttachments {
+		nodeLog.Infow("Waiting for VolumeAttachment to be deleted before deleting node", "volumeattachment", va.Name)
+	}
 
 	// cordon the node to be sure that the deleted pods are re-scheduled in the same node.
 	if err := vc.nodeManager.CordonNode(ctx, node); err != nil {
@@ -77,9 +100,9 @@ func (vc *NodeVolumeAttachmentsCleanup) Run(ctx context.Context, log *zap.Sugare
 	nodeLog.Debug("Successfully cordoned node.")
 
 	// get all the pods that needs to be deleted (i.e. those mounting volumes attached to the node that is going to be deleted).
-	podsToDelete, errors := vc.getFilteredPods(ctx)
-	if len(errors) > 0 {
-		return false, false, fmt.Errorf("failed to get Pods to delete for node %s, errors encountered: %w", vc.nodeName, err)
+	podsToDelete, err := vc.getFilteredPods(ctx, volumeAttachments)
+	if err != nil {
+		return false, false, fmt.Errorf("failed to get Pods to delete for node %s: %w", vc.nodeName, err)
 	}
 	nodeLog.Debugf("Found %d pods to delete for node", len(podsToDelete))
 
@@ -92,122 +115,94 @@ func (vc *NodeVolumeAttachmentsCleanup) Run(ctx context.Context, log *zap.Sugare
 		return false, false, fmt.Errorf("failed to delete pods, errors encountered: %v", errs)
 	}
 	nodeLog.Debug("Successfully deleted all pods mounting persistent volumes attached on node")
-	return true, false, err
+	return true, false, nil
 }
 
-func (vc *NodeVolumeAttachmentsCleanup) getFilteredPods(ctx context.Context) ([]corev1.Pod, []error) {
-	filteredPods := []corev1.Pod{}
-	lock := sync.Mutex{}
-	retErrs := []error{}
-
-	volumeAttachments, err := vc.kubeClient.StorageV1().VolumeAttachments().List(ctx, metav1.ListOptions{})
-	if err != nil {
-		retErrs = append(retErrs, fmt.Errorf("failed to list pods: %w", err))
-		return nil, retErrs
+// volumeAttachments returns the VolumeAttachments of the node, which have not been collected by
+// the CSI driver yet.
+func (vc *NodeVolumeAttachmentsCleanup) volumeAttachments(ctx context.Context) ([]storagev1.VolumeAttachment, error) {
+	volumeAttachments := &storagev1.VolumeAttachmentList{}
+	if err := vc.client.List(ctx, volumeAttachments, ctrlruntimeclient.MatchingFields{VolumeAttachmentNodeNameIndex: vc.nodeName}); err != nil {
+		return nil, fmt.Errorf("failed to list volumeAttachments: %w", err)
 	}
+	return volumeAttachments.Items, nil
+}
 
-	persistentVolumeClaims, err := vc.kubeClient.CoreV1().PersistentVolumeClaims(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
-	if err != nil {
-		retErrs = append(retErrs, fmt.Errorf("failed to list persistent volumes: %w", err))
-		return nil, retErrs
-	}
-
-	errCh := make(chan error, errorQueueLen)
-	wg := sync.WaitGroup{}
-	for _, va := range volumeAttachments.Items {
-		if va.Spec.NodeName == vc.nodeName {
-			for _, pvc := range persistentVolumeClaims.Items {
-				if va.Spec.Source.PersistentVolumeName != nil && *va.Spec.Source.PersistentVolumeName == pvc.Spec.VolumeName {
-					wg.Add(1)
-					go func(pvc corev1.PersistentVolumeClaim) {
-						defer wg.Done()
-						pods, err := vc.kubeClient.CoreV1().Pods(pvc.Namespace).List(ctx, metav1.ListOptions{})
-						switch {
-						case apierrors.IsTooManyRequests(err):
-							return
-						case err != nil:
-							errCh <- fmt.Errorf("failed to list pod: %w", err)
-						default:
-							for _, pod := range pods.Items {
-								if doesPodClaimVolume(pod, pvc.Name) && pod.Spec.NodeName == vc.nodeName {
-									lock.Lock()
-									filteredPods = append(filteredPods, pod)
-									lock.Unlock()
-								}
-							}
-						}
-					}(pvc)
-				}
+// getFilteredPods returns the pods on the node which claim one of the attached persistent volumes.
+func (vc *NodeVolumeAttachmentsCleanup) getFilteredPods(ctx context.Context, volumeAttachments []storagev1.VolumeAttachment) ([]corev1.Pod, error) {
+	claims := sets.New[types.NamespacedName]()
+	for _, va := range volumeAttachments {
+		if va.Spec.Source.PersistentVolumeName == nil {
+			continue
+		}
+
+		pv := &corev1.PersistentVolume{}
+		if err := vc.client.Get(ctx, types.NamespacedName{Name: *va.Spec.Source.PersistentVolumeName}, pv); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
 			}
+			return nil, fmt.Errorf("failed to get persistent volume %s: %w", *va.Spec.Source.PersistentVolumeName, err)
+		}
+		if pv.Spec.ClaimRef != nil {
+			claims.Insert(types.NamespacedName{Namespace: pv.Spec.ClaimRef.Namespace, Name: pv.Spec.ClaimRef.Name})
 		}
 	}
-	wg.Wait()
-	close(errCh)
 
-	for err := range errCh {
-		retErrs = append(retErrs, err)
+	if claims.Len() == 0 {
+		return nil, nil
 	}
 
-	return filteredPods, nil
-}
-
-// nodeCanBeDeleted checks if all the volumeAttachments related to the node have already been collected by the external CSI driver.
-func (vc *NodeVolumeAttachmentsCleanup) nodeCanBeDeleted(ctx context.Context, log *zap.SugaredLogger) (bool, error) {
-	volumeAttachments, err := vc.kubeClient.StorageV1().VolumeAttachments().List(ctx, metav1.ListOptions{})
+	// The lister-backed client does not cache pods, see the eviction package.
+	pods, err := vc.kubeClient.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.SelectorFromSet(fields.Set{"spec.nodeName": vc.nodeName}).String(),
+	})
 	if err != nil {
-		return false, fmt.Errorf("error while listing volumeAttachments: %w", err)
+		return nil, fmt.Errorf("failed to list pods: %w", err)
 	}
-	for _, va := range volumeAttachments.Items {
-		if va.Spec.NodeName == vc.nodeName {
-			log.Infow("Waiting for VolumeAttachment to be deleted before deleting node", "volumeattachment", va.Name)
-			return false, nil
+
+	var filteredPods []corev1.Pod
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName == vc.nodeName && doesPodClaimVolume(pod, claims) {
+			filteredPods = append(filteredPods, pod)
 		}
 	}
-	return true, nil
+
+	return filteredPods, nil
 }
 
 func (vc *NodeVolumeAttachmentsCleanup) deletePods(ctx context.Context, log *zap.SugaredLogger, pods []corev1.Pod) []error {
-	errCh := make(chan error, len(pods))
-	retErrs := []error{}
-
-	var wg sync.WaitGroup
-	var isDone bool
-	defer func() { isDone = true }()
+	var (
+		wg      sync.WaitGroup
+		lock    sync.Mutex
+		retErrs []error
+	)
 
 	wg.Add(len(pods))
 	for _, pod := range pods {
 		go func(p corev1.Pod) {
 			defer wg.Done()
-			for {
-				if isDone {
-					return
-				}
-				err := vc.kubeClient.CoreV1().Pods(p.Namespace).Delete(ctx, p.Name, metav1.DeleteOptions{})
-				if err == nil || apierrors.IsNotFound(err) {
-					log.Debugw("Successfully deleted pod on node", "pod", ctrlruntimeclient.ObjectKeyFromObject(&p))
-					return
-				} else if apierrors.IsTooManyRequests(err) {
-					// PDB prevents pod deletion, return and make the controller retry later.
-					return
-				}
-				errCh <- fmt.Errorf("error deleting pod %s/%s on node %s: %w", p.Namespace, p.Name, vc.nodeName, err)
+			err := vc.kubeClient.CoreV1().Pods(p.Namespace).Delete(ctx, p.Name, metav1.DeleteOptions{})
+			if err == nil || apierrors.IsNotFound(err) {
+				log.Debugw("Successfully deleted pod on node", "pod", ctrlruntimeclient.ObjectKeyFromObject(&p))
+				return
+			} else if apierrors.IsTooManyRequests(err) {
+				// PDB prevents pod deletion, make the controller retry later.
+				return
 			}
+			lock.Lock()
+			retErrs = append(retErrs, fmt.Errorf("error deleting pod %s/%s on node %s: %w", p.Namespace, p.Name, vc.nodeName, err))
+			lock.Unlock()
 		}(pod)
 	}
 	wg.Wait()
-	close(errCh)
-
-	for err := range errCh {
-		retErrs = append(retErrs, err)
-	}
 
 	return retErrs
 }
 
-// doesPodClaimVolume checks if the volume is mounted by the pod.
-func doesPodClaimVolume(pod corev1.Pod, pvcName string) bool {
-	for _, volumeMount := range pod.Spec.Volumes {
-		if volumeMount.PersistentVolumeClaim != nil && volumeMount.PersistentVolumeClaim.ClaimName == pvcName {
+// doesPodClaimVolume checks if one of the claims is mounted by the pod.
+func doesPodClaimVolume(pod corev1.Pod, claims sets.Set[types.NamespacedName]) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil && claims.Has(types.NamespacedName{Namespace: pod.Namespace, Name: volume.PersistentVolumeClaim.ClaimName}) {
 			return true
 		}
 	}
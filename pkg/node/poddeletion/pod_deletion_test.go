@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poddeletion
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newVolumeAttachment(name, nodeName, pvName string) *storagev1.VolumeAttachment {
+	return &storagev1.VolumeAttachment{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: storagev1.VolumeAttachmentSpec{
+			NodeName: nodeName,
+			Source:   storagev1.VolumeAttachmentSource{PersistentVolumeName: ptr.To(pvName)},
+		},
+	}
+}
+
+func newPVCPod(namespace, name, nodeName, claimName string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+			Volumes: []corev1.Volume{{
+				Name: "data",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+				},
+			}},
+		},
+	}
+}
+
+func TestGetFilteredPods(t *testing.T) {
+	ctx := context.Background()
+
+	client := fakectrlruntimeclient.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithIndex(&storagev1.VolumeAttachment{}, VolumeAttachmentNodeNameIndex, func(obj ctrlruntimeclient.Object) []string {
+			return []string{obj.(*storagev1.VolumeAttachment).Spec.NodeName}
+		}).
+		WithObjects(
+			newVolumeAttachment("va-1", "node1", "pv-1"),
+			newVolumeAttachment("va-2", "node2", "pv-2"),
+			&corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-1"},
+				Spec:       corev1.PersistentVolumeSpec{ClaimRef: &corev1.ObjectReference{Namespace: "n1", Name: "data"}},
+			},
+			&corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-2"},
+				Spec:       corev1.PersistentVolumeSpec{ClaimRef: &corev1.ObjectReference{Namespace: "n2", Name: "data"}},
+			},
+		).
+		Build()
+	kubeClient := kubefake.NewClientset(
+		newPVCPod("n1", "claiming", "node1", "data"),
+		// Same claim name, but in another namespace
+		newPVCPod("n2", "other-namespace", "node1", "data"),
+		newPVCPod("n1", "other-node", "node2", "data"),
+	)
+
+	vc := New("node1", client, kubeClient)
+
+	volumeAttachments, err := vc.volumeAttachments(ctx)
+	if err != nil {
+		t.Fatalf("failed to get volumeAttachments: %v", err)
+	}
+	if len(volumeAttachments) != 1 || volumeAttachments[0].Name != "va-1" {
+		t.Fatalf("expected only va-1 to be attached to node1, got %v", volumeAttachments)
+	}
+
+	pods, err := vc.getFilteredPods(ctx, volumeAttachments)
+	if err != nil {
+		t.Fatalf("failed to get pods: %v", err)
+	}
+	if len(pods) != 1 || pods[0].Name != "claiming" {
+		t.Errorf("expected only pod claiming to be deleted, got %v", pods)
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+
+	"k8c.io/machine-controller/sdk/providerconfig/configvar"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Config configures the secret stores external key references are resolved with.
+type Config struct {
+	// CacheTTL is how long secrets are cached. Secrets are not cached if it is not set.
+	CacheTTL metav1.Duration `json:"cacheTTL,omitempty"`
+
+	Stores []StoreConfig `json:"stores"`
+}
+
+// StoreConfig configures a single secret store. Exactly one backend must be set.
+type StoreConfig struct {
+	// Name is referenced by the `store` of external key references.
+	Name string `json:"name"`
+
+	// Namespaces are the namespaces whose objects may reference the store, `*` allows all
+	// namespaces. Configure a store per tenant to keep tenants from reading each other's secrets.
+	Namespaces []string `json:"namespaces"`
+
+	File *FileStoreConfig `json:"file,omitempty"`
+	HTTP *HTTPStoreConfig `json:"http,omitempty"`
+}
+
+// FileStoreConfig configures a store reading secrets from a directory, e.g. a volume mounted by
+// the Secrets Store CSI driver. The path of a reference is a directory below it and the key
+// is the name of a file in that directory.
+type FileStoreConfig struct {
+	Directory string `json:"directory"`
+}
+
+// HTTPStoreConfig configures a store reading secrets as JSON objects from an HTTP API, e.g. the
+// KV secrets engine of Vault.
+type HTTPStoreConfig struct {
+	// URL is joined with the path of a reference, e.g. `https://vault:8200/v1/secret/data`.
+	URL string `json:"url"`
+
+	// TokenFile is the path of a file holding the token sent with every request. It is read
+	// for every request, so the token can be rotated.
+	// +optional
+	TokenFile string `json:"tokenFile,omitempty"`
+
+	// TokenHeader is the header the token is sent in. Defaults to `X-Vault-Token`.
+	// +optional
+	TokenHeader string `json:"tokenHeader,omitempty"`
+
+	// DataPath is the dot separated path of the secret in the response, e.g. `data.data` for
+	// version 2 of the KV secrets engine of Vault. The whole response is used if it is empty.
+	// +optional
+	DataPath string `json:"dataPath,omitempty"`
+
+	// Timeout of a request. Defaults to 15 seconds.
+	// +optional
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+}
+
+// LoadConfig reads the configuration of the secret stores from a YAML or JSON file.
+func LoadConfig(filename string) (*Config, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	config := &Config{}
+	if err := yaml.UnmarshalStrict(content, config); err != nil {
+		return nil, fmt.Errorf("failed to decode file: %w", err)
+	}
+
+	return config, nil
+}
+
+// SetConfigFile creates the secret stores configured in the file and uses them to resolve
+// external key references of all resolvers created afterwards.
+func SetConfigFile(log *zap.SugaredLogger, filename string) error {
+	config, err := LoadConfig(filename)
+	if err != nil {
+		return err
+	}
+
+	store, err := New(log, config)
+	if err != nil {
+		return err
+	}
+
+	configvar.SetSecretStore(store)
+	return nil
+}
+
+func (c *StoreConfig) validate() error {
+	if c.Name == "" {
+		return errors.New("name must be set")
+	}
+	if len(c.Namespaces) == 0 {
+		return errors.New("namespaces must be set")
+	}
+	if (c.File == nil) == (c.HTTP == nil) {
+		return errors.New("exactly one of file and http must be set")
+	}
+	if c.File != nil && c.File.Directory == "" {
+		return errors.New("file.directory must be set")
+	}
+	if c.HTTP != nil && c.HTTP.URL == "" {
+		return errors.New("http.url must be set")
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// fileBackend reads every file of a directory below its directory as a key of a secret.
+type fileBackend struct {
+	directory string
+}
+
+func newFileBackend(config *FileStoreConfig) *fileBackend {
+	return &fileBackend{directory: config.Directory}
+}
+
+func (b *fileBackend) getSecret(_ context.Context, path string) (map[string]string, error) {
+	path = filepath.FromSlash(path)
+	if !filepath.IsLocal(path) {
+		return nil, fmt.Errorf("path %q is not below the directory of the store", path)
+	}
+
+	dir := filepath.Join(b.directory, path)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := map[string]string{}
+	for _, entry := range entries {
+		// Skip the hidden directories and links of projected volumes, e.g. `..data`.
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		secret[entry.Name()] = string(content)
+	}
+
+	return secret, nil
+}
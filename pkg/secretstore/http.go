@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"k8c.io/machine-controller/pkg/cloudprovider/util"
+)
+
+const (
+	defaultTokenHeader = "X-Vault-Token"
+	defaultHTTPTimeout = 15 * time.Second
+
+	// maxSecretSize limits the size of responses of secret stores.
+	maxSecretSize = 1 << 20
+)
+
+// httpBackend reads secrets as JSON objects from an HTTP API.
+type httpBackend struct {
+	// client does not use util.LogRoundTripper, which would log the secrets on high log levels.
+	client      *http.Client
+	url         string
+	tokenFile   string
+	tokenHeader string
+	dataPath    []string
+}
+
+func newHTTPBackend(config *HTTPStoreConfig) *httpBackend {
+	timeout := config.Timeout.Duration
+	if timeout <= 0 {
+		timeout = defaultHTTPTimeout
+	}
+
+	tokenHeader := config.TokenHeader
+	if tokenHeader == "" {
+		tokenHeader = defaultTokenHeader
+	}
+
+	var dataPath []string
+	if config.DataPath != "" {
+		dataPath = strings.Split(config.DataPath, ".")
+	}
+
+	return &httpBackend{
+		client: &http.Client{
+			Transport: &http.Transport{
+				Proxy: http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{
+					RootCAs: util.CABundle,
+				},
+			},
+			Timeout: timeout,
+		},
+		url:         config.URL,
+		tokenFile:   config.TokenFile,
+		tokenHeader: tokenHeader,
+		dataPath:    dataPath,
+	}
+}
+
+func (b *httpBackend) getSecret(ctx context.Context, path string) (map[string]string, error) {
+	for _, segment := range strings.Split(path, "/") {
+		if segment == ".." {
+			return nil, fmt.Errorf("path %q is not below the url of the store", path)
+		}
+	}
+
+	secretURL, err := url.JoinPath(b.url, path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, secretURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if b.tokenFile != "" {
+		token, err := os.ReadFile(b.tokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read token: %w", err)
+		}
+		req.Header.Set(b.tokenHeader, strings.TrimSpace(string(token)))
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// The body is not part of the error, as it might contain secrets.
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var data interface{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxSecretSize)).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	for _, field := range b.dataPath {
+		object, ok := data.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("response has no field %q", field)
+		}
+		data = object[field]
+	}
+
+	object, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("secret is no JSON object")
+	}
+
+	secret := map[string]string{}
+	for key, value := range object {
+		if s, ok := value.(string); ok {
+			secret[key] = s
+			continue
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		secret[key] = string(encoded)
+	}
+
+	return secret, nil
+}
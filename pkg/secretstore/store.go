@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package secretstore resolves external key references of providerSpecs with secret stores
+// outside of the cluster, so credentials do not have to be copied into Kubernetes Secrets.
+package secretstore
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"k8c.io/machine-controller/sdk/providerconfig"
+	"k8c.io/machine-controller/sdk/providerconfig/configvar"
+)
+
+// backend reads secrets from a secret store.
+type backend interface {
+	// getSecret returns the values of the secret at the path.
+	getSecret(ctx context.Context, path string) (map[string]string, error)
+}
+
+type cacheEntry struct {
+	secret  map[string]string
+	expires time.Time
+}
+
+// Store resolves external key references with the configured backends. Secrets are cached
+// for the configured TTL and every access is logged with the object it was made for.
+type Store struct {
+	log        *zap.SugaredLogger
+	backends   map[string]backend
+	namespaces map[string][]string
+	cacheTTL   time.Duration
+	now        func() time.Time
+
+	lock  sync.Mutex
+	cache map[string]cacheEntry
+}
+
+var _ configvar.SecretStore = &Store{}
+
+// New creates the Store of the configuration.
+func New(log *zap.SugaredLogger, config *Config) (*Store, error) {
+	store := &Store{
+		log:        log.Named("secretstore"),
+		backends:   map[string]backend{},
+		namespaces: map[string][]string{},
+		cacheTTL:   config.CacheTTL.Duration,
+		now:        time.Now,
+		cache:      map[string]cacheEntry{},
+	}
+
+	for _, storeConfig := range config.Stores {
+		if err := storeConfig.validate(); err != nil {
+			return nil, fmt.Errorf("invalid secret store %q: %w", storeConfig.Name, err)
+		}
+		if _, exists := store.backends[storeConfig.Name]; exists {
+			return nil, fmt.Errorf("secret store %q is configured more than once", storeConfig.Name)
+		}
+
+		store.namespaces[storeConfig.Name] = storeConfig.Namespaces
+
+		switch {
+		case storeConfig.File != nil:
+			store.backends[storeConfig.Name] = newFileBackend(storeConfig.File)
+		case storeConfig.HTTP != nil:
+			store.backends[storeConfig.Name] = newHTTPBackend(storeConfig.HTTP)
+		}
+	}
+
+	return store, nil
+}
+
+// CheckAccess implements configvar.SecretStore.
+func (s *Store) CheckAccess(ref providerconfig.ExternalKeySelector, namespace string) error {
+	namespaces, ok := s.namespaces[ref.Store]
+	if !ok {
+		return fmt.Errorf("secret store %q is not configured", ref.Store)
+	}
+	if !slices.Contains(namespaces, "*") && (namespace == "" || !slices.Contains(namespaces, namespace)) {
+		return fmt.Errorf("%w: secret store %q is not available to namespace %q", configvar.ErrCrossNamespaceReference, ref.Store, namespace)
+	}
+	return nil
+}
+
+// GetValue implements configvar.SecretStore.
+func (s *Store) GetValue(ctx context.Context, ref providerconfig.ExternalKeySelector, namespace, accessor string) (string, error) {
+	log := s.log.With("store", ref.Store, "path", ref.Path, "key", ref.Key, "namespace", namespace, "accessor", accessor)
+
+	if err := s.CheckAccess(ref, namespace); err != nil {
+		log.Infow("Denied access to secret store", zap.Error(err))
+		return "", err
+	}
+	backend := s.backends[ref.Store]
+
+	secret, cached, err := s.getSecret(ctx, ref.Store, backend, ref.Path)
+	if err != nil {
+		log.Infow("Failed to access secret", zap.Error(err))
+		return "", fmt.Errorf("failed to get secret %q from store %q: %w", ref.Path, ref.Store, err)
+	}
+
+	value, ok := secret[ref.Key]
+	if !ok {
+		log.Infow("Failed to access secret", "cached", cached, zap.Error(fmt.Errorf("no key %q", ref.Key)))
+		return "", fmt.Errorf("secret %q in store %q has no key %q", ref.Path, ref.Store, ref.Key)
+	}
+
+	log.Infow("Accessed secret", "cached", cached)
+	return value, nil
+}
+
+// getSecret returns the secret from the cache or the backend and whether it was cached.
+func (s *Store) getSecret(ctx context.Context, storeName string, backend backend, path string) (map[string]string, bool, error) {
+	cacheKey := storeName + "/" + strings.Trim(path, "/")
+
+	if s.cacheTTL > 0 {
+		s.lock.Lock()
+		entry, ok := s.cache[cacheKey]
+		if ok && s.now().Before(entry.expires) {
+			s.lock.Unlock()
+			return entry.secret, true, nil
+		}
+		delete(s.cache, cacheKey)
+		s.lock.Unlock()
+	}
+
+	secret, err := backend.getSecret(ctx, path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if s.cacheTTL > 0 {
+		s.lock.Lock()
+		s.cache[cacheKey] = cacheEntry{secret: secret, expires: s.now().Add(s.cacheTTL)}
+		s.lock.Unlock()
+	}
+
+	return secret, false, nil
+}
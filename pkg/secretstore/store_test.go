@@ -0,0 +1,259 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
+
+	"k8c.io/machine-controller/sdk/providerconfig"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+}
+
+func TestStoreGetValue(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "aws", "team-a", "AWS_ACCESS_KEY_ID"), "file-key")
+	writeFile(t, filepath.Join(dir, "aws", "team-a", "..data", "AWS_ACCESS_KEY_ID"), "hidden")
+	writeFile(t, filepath.Join(dir, "outside"), "outside")
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	writeFile(t, tokenFile, "s.token\n")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "s.token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path != "/v1/secret/data/aws/team-a" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"data":{"AWS_ACCESS_KEY_ID":"vault-key","port":8080},"metadata":{"version":3}}}`))
+	}))
+	defer server.Close()
+
+	store, err := New(zaptest.NewLogger(t).Sugar(), &Config{
+		Stores: []StoreConfig{
+			{Name: "files", Namespaces: []string{"team-a"}, File: &FileStoreConfig{Directory: filepath.Join(dir, "aws")}},
+			{Name: "vault", Namespaces: []string{"*"}, HTTP: &HTTPStoreConfig{URL: server.URL + "/v1/secret/data", TokenFile: tokenFile, DataPath: "data.data"}},
+			{Name: "vault-without-token", Namespaces: []string{"*"}, HTTP: &HTTPStoreConfig{URL: server.URL + "/v1/secret/data"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		ref           providerconfig.ExternalKeySelector
+		namespace     string
+		expectedValue string
+		expectErr     bool
+	}{
+		{
+			name:          "file",
+			ref:           providerconfig.ExternalKeySelector{Store: "files", Path: "team-a", Key: "AWS_ACCESS_KEY_ID"},
+			expectedValue: "file-key",
+		},
+		{
+			name:      "file from another namespace",
+			ref:       providerconfig.ExternalKeySelector{Store: "files", Path: "team-a", Key: "AWS_ACCESS_KEY_ID"},
+			namespace: "team-b",
+			expectErr: true,
+		},
+		{
+			name:      "file without namespace",
+			ref:       providerconfig.ExternalKeySelector{Store: "files", Path: "team-a", Key: "AWS_ACCESS_KEY_ID"},
+			namespace: "-",
+			expectErr: true,
+		},
+		{
+			name:      "file with missing key",
+			ref:       providerconfig.ExternalKeySelector{Store: "files", Path: "team-a", Key: "AWS_SECRET_ACCESS_KEY"},
+			expectErr: true,
+		},
+		{
+			name:      "file outside of the directory",
+			ref:       providerconfig.ExternalKeySelector{Store: "files", Path: "..", Key: "outside"},
+			expectErr: true,
+		},
+		{
+			name:          "http",
+			ref:           providerconfig.ExternalKeySelector{Store: "vault", Path: "aws/team-a", Key: "AWS_ACCESS_KEY_ID"},
+			expectedValue: "vault-key",
+		},
+		{
+			name:          "http value which is no string",
+			ref:           providerconfig.ExternalKeySelector{Store: "vault", Path: "aws/team-a", Key: "port"},
+			expectedValue: "8080",
+		},
+		{
+			name:      "http outside of the url",
+			ref:       providerconfig.ExternalKeySelector{Store: "vault", Path: "../../data/aws/team-a", Key: "AWS_ACCESS_KEY_ID"},
+			expectErr: true,
+		},
+		{
+			name:      "http without permission",
+			ref:       providerconfig.ExternalKeySelector{Store: "vault-without-token", Path: "aws/team-a", Key: "AWS_ACCESS_KEY_ID"},
+			expectErr: true,
+		},
+		{
+			name:      "unknown store",
+			ref:       providerconfig.ExternalKeySelector{Store: "unknown", Path: "aws/team-a", Key: "AWS_ACCESS_KEY_ID"},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			namespace := test.namespace
+			switch namespace {
+			case "":
+				namespace = "team-a"
+			case "-":
+				namespace = ""
+			}
+
+			value, err := store.GetValue(context.Background(), test.ref, namespace, "Machine team-a/test")
+			if test.expectErr {
+				if err == nil {
+					t.Fatalf("expected an error, got value %q", value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if value != test.expectedValue {
+				t.Errorf("expected %q, got %q", test.expectedValue, value)
+			}
+		})
+	}
+}
+
+func TestStoreCacheAndAudit(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "aws", "token"), "first")
+
+	core, logs := observer.New(zap.InfoLevel)
+	store, err := New(zap.New(core).Sugar(), &Config{
+		CacheTTL: metav1.Duration{Duration: time.Minute},
+		Stores:   []StoreConfig{{Name: "files", Namespaces: []string{"kube-system"}, File: &FileStoreConfig{Directory: dir}}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	ref := providerconfig.ExternalKeySelector{Store: "files", Path: "aws", Key: "token"}
+	getValue := func(expected string) {
+		t.Helper()
+		value, err := store.GetValue(context.Background(), ref, "kube-system", "Machine kube-system/test")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if value != expected {
+			t.Fatalf("expected %q, got %q", expected, value)
+		}
+	}
+
+	getValue("first")
+	writeFile(t, filepath.Join(dir, "aws", "token"), "second")
+	getValue("first")
+
+	now = now.Add(2 * time.Minute)
+	getValue("second")
+
+	entries := logs.FilterMessage("Accessed secret").All()
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 audit log entries, got %d", len(entries))
+	}
+	for i, expectedCached := range []bool{false, true, false} {
+		fields := entries[i].ContextMap()
+		if fields["accessor"] != "Machine kube-system/test" || fields["path"] != "aws" || fields["store"] != "files" {
+			t.Errorf("unexpected audit log entry %v", fields)
+		}
+		if fields["cached"] != expectedCached {
+			t.Errorf("expected cached to be %t in audit log entry %d, got %v", expectedCached, i, fields["cached"])
+		}
+	}
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		stores []StoreConfig
+	}{
+		{
+			name:   "missing name",
+			stores: []StoreConfig{{File: &FileStoreConfig{Directory: "/secrets"}}},
+		},
+		{
+			name:   "missing namespaces",
+			stores: []StoreConfig{{Name: "files", File: &FileStoreConfig{Directory: "/secrets"}}},
+		},
+		{
+			name:   "missing backend",
+			stores: []StoreConfig{{Name: "files", Namespaces: []string{"*"}}},
+		},
+		{
+			name: "more than one backend",
+			stores: []StoreConfig{{
+				Name:       "files",
+				Namespaces: []string{"*"},
+				File:       &FileStoreConfig{Directory: "/secrets"},
+				HTTP:       &HTTPStoreConfig{URL: "https://vault:8200"},
+			}},
+		},
+		{
+			name: "duplicate name",
+			stores: []StoreConfig{
+				{Name: "files", Namespaces: []string{"*"}, File: &FileStoreConfig{Directory: "/secrets"}},
+				{Name: "files", Namespaces: []string{"*"}, File: &FileStoreConfig{Directory: "/other-secrets"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := New(zaptest.NewLogger(t).Sugar(), &Config{Stores: test.stores}); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
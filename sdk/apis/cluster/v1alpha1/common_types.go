@@ -56,3 +56,39 @@ type MachineClassRef struct {
 	// +optional
 	Provider string `json:"provider,omitempty"`
 }
+
+// FailureDomain describes a failure domain, e.g. an availability zone, across which the
+// replicas of a MachineDeployment or MachineSet are spread.
+type FailureDomain struct {
+	// Name identifies the failure domain. Machines created in it get the
+	// "cluster.k8s.io/failure-domain" label set to this value.
+	Name string `json:"name"`
+
+	// Zone is the availability zone of the failure domain. It is set as availabilityZone
+	// for AWS and OpenStack, as zone for GCE, as zones for Azure and as cluster for vSphere.
+	// It is rejected for other providers, CloudProviderSpec must be used instead.
+	// +optional
+	Zone string `json:"zone,omitempty"`
+
+	// Subnet is the subnet of the failure domain. It is set as subnetId for AWS, as
+	// subnetName for Azure, as subnetwork for GCE, as subnet for OpenStack and as the only
+	// entry of networks for vSphere. It is rejected for other providers.
+	// +optional
+	Subnet string `json:"subnet,omitempty"`
+
+	// CloudProviderSpec is a JSON merge patch which is applied to the cloudProviderSpec
+	// of Machines created in this failure domain, after Zone and Subnet were set.
+	// +optional
+	CloudProviderSpec *runtime.RawExtension `json:"cloudProviderSpec,omitempty"`
+}
+
+const (
+	// OwnershipConflictCondition is set on MachineDeployments and MachineSets whose selector
+	// matches objects which are controlled by, or also selected by, another controller.
+	OwnershipConflictCondition = "OwnershipConflict"
+
+	// SelectorOverlapReason is the reason of a true OwnershipConflict condition.
+	SelectorOverlapReason = "SelectorOverlap"
+	// NoConflictReason is the reason of a false OwnershipConflict condition.
+	NoConflictReason = "NoConflict"
+)
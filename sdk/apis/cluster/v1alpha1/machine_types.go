@@ -30,6 +30,47 @@ const (
 
 	// MachineClusterLabelName is the label set on machines linked to a cluster.
 	MachineClusterLabelName = "cluster.k8s.io/cluster-name"
+
+	// MachineFailureDomainLabelName is the label set on machines created in a failure domain.
+	MachineFailureDomainLabelName = "cluster.k8s.io/failure-domain"
+
+	// MachineDrainingCondition is set on machines whose node is drained before they are deleted.
+	MachineDrainingCondition corev1.NodeConditionType = "Draining"
+
+	// DrainPreDrainDelayReason is the reason of a true Draining condition while the drain waits
+	// after tainting the node.
+	DrainPreDrainDelayReason = "PreDrainDelay"
+	// DrainInProgressReason is the reason of a true Draining condition while pods are evicted.
+	DrainInProgressReason = "DrainInProgress"
+	// DrainBlockedByPDBReason is the reason of a true Draining condition while PodDisruptionBudgets
+	// refuse the eviction of pods.
+	DrainBlockedByPDBReason = "BlockedByPodDisruptionBudget"
+	// DrainCompletedReason is the reason of a false Draining condition once the drain completed.
+	DrainCompletedReason = "Drained"
+
+	// MachineBootstrapSecretReadyCondition reports whether the bootstrap secret providing the userdata
+	// of the machine is available for its MachineDeployment revision.
+	MachineBootstrapSecretReadyCondition corev1.NodeConditionType = "BootstrapSecretReady"
+
+	// BootstrapSecretMissingReason is the reason of a false BootstrapSecretReady condition while the
+	// bootstrap secret does not exist.
+	BootstrapSecretMissingReason = "BootstrapSecretMissing"
+	// BootstrapSecretOutdatedReason is the reason of a false BootstrapSecretReady condition while the
+	// bootstrap secret was created for another revision of the MachineDeployment.
+	BootstrapSecretOutdatedReason = "BootstrapSecretOutdated"
+	// BootstrapSecretAvailableReason is the reason of a true BootstrapSecretReady condition.
+	BootstrapSecretAvailableReason = "BootstrapSecretAvailable"
+
+	// MachineCredentialsValidCondition reports whether the cloud provider accepted the credentials
+	// of the machine. It is only set once the credentials were rejected.
+	MachineCredentialsValidCondition corev1.NodeConditionType = "CredentialsValid"
+
+	// CredentialsRejectedReason is the reason of a false CredentialsValid condition while the cloud
+	// provider rejects the credentials of the machine.
+	CredentialsRejectedReason = "CredentialsRejected"
+	// CredentialsAcceptedReason is the reason of a true CredentialsValid condition once the cloud
+	// provider accepted the credentials again, e.g. after they were rotated.
+	CredentialsAcceptedReason = "CredentialsAccepted"
 )
 
 // +genclient
@@ -204,6 +245,26 @@ type MachineStatus struct {
 	// E.g. Pending, Running, Terminating, Failed etc.
 	// +optional
 	Phase *string `json:"phase,omitempty"`
+
+	// Drain describes the progress of draining the node before the machine is deleted.
+	// +optional
+	Drain *MachineDrainStatus `json:"drain,omitempty"`
+}
+
+// MachineDrainStatus describes the progress of draining the node of a machine.
+type MachineDrainStatus struct {
+	// StartTime is the time at which the drain started.
+	StartTime metav1.Time `json:"startTime"`
+
+	// CompletionTime is the time at which the drain completed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// PodsRemaining is the number of pods which still have to leave the node.
+	PodsRemaining int32 `json:"podsRemaining"`
+
+	// PodsBlockedByPDB is the number of pods whose eviction was refused by a PodDisruptionBudget.
+	PodsBlockedByPDB int32 `json:"podsBlockedByPDB"`
 }
 
 // LastOperation represents the detail of the last performed operation on the MachineObject.
@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"k8c.io/machine-controller/sdk/apis/cluster/common"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -66,10 +67,151 @@ type MachineDeploymentSpec struct {
 	// reason will be surfaced in the deployment status. Note that progress will
 	// not be estimated during the time a deployment is paused. Defaults to 600s.
 	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+
+	// FailureDomains lists the failure domains across which the machines are spread
+	// evenly. Each failure domain overrides the zone and subnet of the template's
+	// providerSpec. Changing the failure domains does not trigger a rollout, instead
+	// they are balanced on the next scale up or down.
+	// +optional
+	FailureDomains []FailureDomain `json:"failureDomains,omitempty"`
+
+	// MaintenanceWindows restricts the replacement of machines after changes of the
+	// template to the given windows. Scaling because of changes of the replicas is
+	// always allowed.
+	// +optional
+	MaintenanceWindows *MaintenanceWindows `json:"maintenanceWindows,omitempty"`
+
+	// DrainPolicy overrides the drain policy of the machine-controller for the nodes
+	// of this deployment. Unset fields keep the values of the machine-controller.
+	// +optional
+	DrainPolicy *DrainPolicy `json:"drainPolicy,omitempty"`
+
+	// Bootstrap selects how the userdata of the machines of this deployment is provided.
+	// Defaults to the bootstrap secret of operating-system-manager.
+	// +optional
+	Bootstrap *Bootstrap `json:"bootstrap,omitempty"`
 }
 
 /// [MachineDeploymentSpec]
 
+// BootstrapProviderType is the type of a provider of the userdata of machines.
+type BootstrapProviderType string
+
+const (
+	// BootstrapProviderOSM reads the userdata from the bootstrap secret which operating-system-manager
+	// creates in the cloud-init-settings namespace.
+	BootstrapProviderOSM BootstrapProviderType = "osm"
+	// BootstrapProviderTemplate renders the userdata from a Go template.
+	BootstrapProviderTemplate BootstrapProviderType = "template"
+	// BootstrapProviderHTTP requests the userdata from an HTTP endpoint.
+	BootstrapProviderHTTP BootstrapProviderType = "http"
+)
+
+// Bootstrap configures the provider of the userdata of machines.
+type Bootstrap struct {
+	// Provider is the type of the provider, one of osm, template or http.
+	// +kubebuilder:validation:Enum=osm;template;http
+	Provider BootstrapProviderType `json:"provider"`
+
+	// Template is the Ignition or cloud-init userdata as Go template. It is rendered with the
+	// .Machine and .MachineDeployment. Required for the template provider.
+	// +optional
+	Template string `json:"template,omitempty"`
+
+	// HTTP configures the endpoint of the http provider.
+	// +optional
+	HTTP *HTTPBootstrap `json:"http,omitempty"`
+}
+
+// HTTPBootstrap configures an HTTP endpoint which returns the userdata of a machine. The machine
+// is posted as JSON and the response body of a 200 response is used as userdata.
+type HTTPBootstrap struct {
+	// URL of the endpoint, must use https.
+	URL string `json:"url"`
+
+	// CABundle is a PEM encoded bundle of certificates to verify the endpoint with. Defaults
+	// to the system certificates.
+	// +optional
+	CABundle string `json:"caBundle,omitempty"`
+
+	// BearerTokenSecretRef references the key of a secret in the namespace of the
+	// MachineDeployment, whose value is sent as bearer token.
+	// +optional
+	BearerTokenSecretRef *corev1.SecretKeySelector `json:"bearerTokenSecretRef,omitempty"`
+
+	// Timeout of the requests. Defaults to 30 seconds.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// DrainPolicy configures how the node of a machine is drained before the machine is deleted.
+type DrainPolicy struct {
+	// GracePeriodSeconds overrides the termination grace period of evicted pods.
+	// If negative, the grace period of the pods is used.
+	// +optional
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+
+	// SkipPodSelector selects pods which are not evicted.
+	// +optional
+	SkipPodSelector *metav1.LabelSelector `json:"skipPodSelector,omitempty"`
+
+	// DeleteEmptyDirData allows to evict pods using emptyDir volumes, whose data is
+	// lost. If false, such pods are never deleted and the drain waits until they were
+	// removed otherwise, also after the PodEvictionTimeout passed.
+	// +optional
+	DeleteEmptyDirData *bool `json:"deleteEmptyDirData,omitempty"`
+
+	// PodEvictionTimeout is how long, counted from the start of the drain, pods are
+	// evicted, e.g. while a PodDisruptionBudget blocks their eviction. Afterwards, the
+	// drain stops waiting for the remaining pods. Zero means no timeout.
+	// +optional
+	PodEvictionTimeout *metav1.Duration `json:"podEvictionTimeout,omitempty"`
+
+	// DeleteAfterEvictionTimeout deletes the remaining pods, bypassing PodDisruptionBudgets,
+	// once the PodEvictionTimeout passed.
+	// +optional
+	DeleteAfterEvictionTimeout *bool `json:"deleteAfterEvictionTimeout,omitempty"`
+
+	// PreDrainTaintEffect is the effect of the ToBeDeletedByMachineController taint, which is
+	// applied to the node before it is cordoned, so workloads can prepare for their eviction.
+	// Either NoSchedule or PreferNoSchedule. If empty, no taint is applied.
+	// +optional
+	PreDrainTaintEffect *corev1.TaintEffect `json:"preDrainTaintEffect,omitempty"`
+
+	// PreDrainDelay is how long to wait after applying the pre-drain taint, before the node is
+	// cordoned and drained.
+	// +optional
+	PreDrainDelay *metav1.Duration `json:"preDrainDelay,omitempty"`
+
+	// WaitForVolumeDetach waits until all volumes were detached from the node before the
+	// instance is deleted, deleting pods which still use them. Defaults to the setting of the
+	// machine-controller for the cloud provider.
+	// +optional
+	WaitForVolumeDetach *bool `json:"waitForVolumeDetach,omitempty"`
+}
+
+// MaintenanceWindows defines recurring time windows in which machines may be replaced.
+type MaintenanceWindows struct {
+	// TimeZone is the IANA time zone in which the schedules are evaluated, e.g.
+	// "Europe/Berlin". Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Windows lists the maintenance windows. Machines may be replaced while any
+	// of them is open.
+	Windows []MaintenanceWindow `json:"windows"`
+}
+
+// MaintenanceWindow is a recurring time window.
+type MaintenanceWindow struct {
+	// Schedule is a cron expression with the fields minute, hour, day of month, month
+	// and day of week, at which the window opens, e.g. "0 22 * * 1-5".
+	Schedule string `json:"schedule"`
+
+	// Duration is how long the window stays open, e.g. "4h".
+	Duration metav1.Duration `json:"duration"`
+}
+
 // / [MachineDeploymentStrategy]
 // MachineDeploymentStrategy describes how to replace existing machines
 // with new ones.
@@ -158,6 +300,18 @@ type MachineDeploymentStatus struct {
 	// that still have not been created.
 	// +optional
 	UnavailableReplicas int32 `json:"unavailableReplicas,omitempty" protobuf:"varint,5,opt,name=unavailableReplicas"`
+
+	// NextMaintenanceWindow is the start of the currently open maintenance window or,
+	// if none is open, of the next one.
+	// +optional
+	NextMaintenanceWindow *metav1.Time `json:"nextMaintenanceWindow,omitempty"`
+
+	// Conditions represent the latest available observations of the deployment's state,
+	// e.g. the OwnershipConflict condition.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 /// [MachineDeploymentStatus]
@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MachinePolicy contains rules the Machines, MachineSets and MachineDeployments
+// of the selected namespaces have to satisfy to be admitted.
+// +k8s:openapi-gen=true
+// +resource:path=machinepolicies
+// +kubebuilder:resource:scope=Cluster
+type MachinePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec MachinePolicySpec `json:"spec,omitempty"`
+}
+
+// MachinePolicySpec defines the rules of a MachinePolicy.
+type MachinePolicySpec struct {
+	// NamespaceSelector selects the namespaces the policy applies to.
+	// The policy applies to all namespaces if it is not set.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Rules are evaluated against the providerSpec of every admitted machine.
+	Rules []MachinePolicyRule `json:"rules"`
+}
+
+// MachinePolicyRule is a single CEL rule of a MachinePolicy.
+type MachinePolicyRule struct {
+	// Name identifies the rule in violations.
+	Name string `json:"name"`
+
+	// Expression is a CEL expression which must evaluate to true for a machine
+	// to be admitted. The variables `providerConfig`, `cloudProviderSpec` and
+	// `machineNamespace` hold the decoded providerSpec, its cloudProviderSpec
+	// and the namespace of the object.
+	Expression string `json:"expression"`
+
+	// Message is returned when the rule is violated.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// FieldPath is the path of the violating field relative to the providerSpec
+	// value, e.g. `cloudProviderSpec.instanceType`.
+	// +optional
+	FieldPath string `json:"fieldPath,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MachinePolicyList contains a list of MachinePolicies.
+type MachinePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MachinePolicy `json:"items"`
+}
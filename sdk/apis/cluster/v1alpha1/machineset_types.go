@@ -61,8 +61,8 @@ type MachineSetSpec struct {
 	MinReadySeconds int32 `json:"minReadySeconds,omitempty"`
 
 	// DeletePolicy defines the policy used to identify nodes to delete when downscaling.
-	// Defaults to "Random".  Valid values are "Random, "Newest", "Oldest"
-	// +kubebuilder:validation:Enum=Random,Newest,Oldest
+	// Defaults to "Random".  Valid values are "Random, "Newest", "Oldest", "LeastUtilized"
+	// +kubebuilder:validation:Enum=Random,Newest,Oldest,LeastUtilized
 	DeletePolicy string `json:"deletePolicy,omitempty"`
 
 	// Selector is a label query over machines that should match the replica count.
@@ -75,6 +75,10 @@ type MachineSetSpec struct {
 	// insufficient replicas are detected.
 	// +optional
 	Template MachineTemplateSpec `json:"template,omitempty"`
+	// FailureDomains lists the failure domains across which the machines are spread
+	// evenly. If empty, all machines are created from the unmodified template.
+	// +optional
+	FailureDomains []FailureDomain `json:"failureDomains,omitempty"`
 }
 
 // MachineSetDeletePolicy defines how priority is assigned to nodes to delete when
@@ -99,6 +103,16 @@ const (
 	// (Status.ErrorReason or Status.ErrorMessage are set to a non-empty value).
 	// It then prioritizes the oldest Machines for deletion based on the Machine's CreationTimestamp.
 	OldestMachineSetDeletePolicy MachineSetDeletePolicy = "Oldest"
+
+	// LeastUtilizedMachineSetDeletePolicy prioritizes both Machines that have the annotation
+	// "cluster.k8s.io/delete-machine=yes" and Machines that are unhealthy
+	// (Status.ErrorReason or Status.ErrorMessage are set to a non-empty value).
+	// It then prioritizes Machines whose Nodes have the lowest requested CPU and memory,
+	// preferring Nodes running only DaemonSet Pods and avoiding Nodes with Pods using local
+	// storage. Machines whose Nodes run Pods annotated with
+	// "cluster-autoscaler.kubernetes.io/safe-to-evict=false" are never deleted, instead a
+	// MachinesNotSafeToEvict event is recorded and the scale down is retried later.
+	LeastUtilizedMachineSetDeletePolicy MachineSetDeletePolicy = "LeastUtilized"
 )
 
 /// [MachineSetSpec] // doxygen marker
@@ -163,6 +177,13 @@ type MachineSetStatus struct {
 	ErrorReason *common.MachineSetStatusError `json:"errorReason,omitempty"`
 	// +optional
 	ErrorMessage *string `json:"errorMessage,omitempty"`
+
+	// Conditions represent the latest available observations of the MachineSet's state,
+	// e.g. the OwnershipConflict condition.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 /// [MachineSetStatus]
@@ -0,0 +1,65 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ProviderCredentials references the credentials of a cloud provider account, which
+// the machines of the selected namespaces can use by referencing it from their
+// providerSpec. Creating machines with it requires the `use` verb on it.
+// +k8s:openapi-gen=true
+// +resource:path=providercredentials
+// +kubebuilder:resource:scope=Cluster
+type ProviderCredentials struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ProviderCredentialsSpec `json:"spec,omitempty"`
+}
+
+// ProviderCredentialsSpec defines the credentials and who may use them.
+type ProviderCredentialsSpec struct {
+	// Type is the cloud provider the credentials are for, e.g. `aws`.
+	Type string `json:"type"`
+
+	// SecretRef references the secret holding the credentials. Its keys are the
+	// names of the environment variables the cloud provider falls back to,
+	// e.g. `AWS_ACCESS_KEY_ID` and `AWS_SECRET_ACCESS_KEY`.
+	SecretRef corev1.SecretReference `json:"secretRef"`
+
+	// NamespaceSelector selects the namespaces whose machines may use the credentials.
+	// Only the namespace of the secret may use them if it is not set.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ProviderCredentialsList contains a list of ProviderCredentials.
+type ProviderCredentialsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProviderCredentials `json:"items"`
+}
@@ -56,8 +56,12 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&MachineClassList{},
 		&MachineDeployment{},
 		&MachineDeploymentList{},
+		&MachinePolicy{},
+		&MachinePolicyList{},
 		&MachineSet{},
 		&MachineSetList{},
+		&ProviderCredentials{},
+		&ProviderCredentialsList{},
 	)
 
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
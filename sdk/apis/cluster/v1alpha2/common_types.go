@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// CloudProvider is the name of a cloud provider, e.g. "aws" or "hetzner".
+type CloudProvider string
+
+// ProviderSpec defines the configuration to use during node creation. It is a union
+// discriminated by CloudProvider.
+// +union
+type ProviderSpec struct {
+	// CloudProvider is the cloud provider the machine is created at. It replaces the
+	// cloudProvider field of the v1alpha1 providerSpec value.
+	// +unionDiscriminator
+	// +optional
+	CloudProvider CloudProvider `json:"cloudProvider,omitempty"`
+
+	// No more than one of the following may be specified.
+
+	// Value is an inlined, serialized representation of the configuration of the
+	// CloudProvider, e.g. its cloudProviderSpec and the operatingSystem. It must be
+	// a JSON object without a cloudProvider field and is required if CloudProvider
+	// is set.
+	// +optional
+	Value *runtime.RawExtension `json:"value,omitempty"`
+
+	// Source for the provider configuration. Cannot be used if value is
+	// not empty.
+	// +optional
+	ValueFrom *ProviderSpecSource `json:"valueFrom,omitempty"`
+}
+
+// ProviderSpecSource represents a source for the provider-specific
+// resource configuration.
+type ProviderSpecSource struct {
+	// The machine class from which the provider config should be sourced.
+	// +optional
+	MachineClass *MachineClassRef `json:"machineClass,omitempty"`
+}
+
+// MachineClassRef is a reference to the MachineClass object. Controllers should find the right MachineClass using this reference.
+type MachineClassRef struct {
+	// +optional
+	*corev1.ObjectReference `json:",inline"`
+
+	// Provider is the name of the cloud-provider which MachineClass is intended for.
+	// +optional
+	Provider string `json:"provider,omitempty"`
+}
+
+// FailureDomain describes a failure domain, e.g. an availability zone, across which the
+// replicas of a MachineDeployment or MachineSet are spread.
+type FailureDomain struct {
+	// Name identifies the failure domain. Machines created in it get the
+	// "cluster.k8s.io/failure-domain" label set to this value.
+	Name string `json:"name"`
+
+	// Zone is the availability zone of the failure domain. It is set as availabilityZone
+	// for AWS and OpenStack, as zone for GCE, as zones for Azure and as cluster for vSphere.
+	// It is rejected for other providers, CloudProviderSpec must be used instead.
+	// +optional
+	Zone string `json:"zone,omitempty"`
+
+	// Subnet is the subnet of the failure domain. It is set as subnetId for AWS, as
+	// subnetName for Azure, as subnetwork for GCE, as subnet for OpenStack and as the only
+	// entry of networks for vSphere. It is rejected for other providers.
+	// +optional
+	Subnet string `json:"subnet,omitempty"`
+
+	// CloudProviderSpec is a JSON merge patch which is applied to the cloudProviderSpec
+	// of Machines created in this failure domain, after Zone and Subnet were set.
+	// +optional
+	CloudProviderSpec *runtime.RawExtension `json:"cloudProviderSpec,omitempty"`
+}
+
+const (
+	// OwnershipConflictCondition is set on MachineDeployments and MachineSets whose selector
+	// matches objects which are controlled by, or also selected by, another controller.
+	OwnershipConflictCondition = "OwnershipConflict"
+
+	// SelectorOverlapReason is the reason of a true OwnershipConflict condition.
+	SelectorOverlapReason = "SelectorOverlap"
+	// NoConflictReason is the reason of a false OwnershipConflict condition.
+	NoConflictReason = "NoConflict"
+)
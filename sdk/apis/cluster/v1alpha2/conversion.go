@@ -0,0 +1,222 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// cloudProviderField is the field of the v1alpha1 providerSpec value which holds the
+// discriminator of the v1alpha2 ProviderSpec.
+const cloudProviderField = "cloudProvider"
+
+// ConvertTo converts the Machine to the hub version v1alpha1.
+func (m *Machine) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*clusterv1alpha1.Machine)
+	if !ok {
+		return fmt.Errorf("unsupported hub type %T", dstRaw)
+	}
+
+	dst.ObjectMeta = *m.ObjectMeta.DeepCopy()
+	if err := jsonCast(m.Spec, &dst.Spec); err != nil {
+		return fmt.Errorf("failed to convert spec: %w", err)
+	}
+	if err := convertProviderSpecToHub(&m.Spec.ProviderSpec, &dst.Spec.ProviderSpec); err != nil {
+		return err
+	}
+	if err := jsonCast(m.Status, &dst.Status); err != nil {
+		return fmt.Errorf("failed to convert status: %w", err)
+	}
+	return nil
+}
+
+// ConvertFrom converts the hub version v1alpha1 to the Machine.
+func (m *Machine) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*clusterv1alpha1.Machine)
+	if !ok {
+		return fmt.Errorf("unsupported hub type %T", srcRaw)
+	}
+
+	m.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	if err := jsonCast(src.Spec, &m.Spec); err != nil {
+		return fmt.Errorf("failed to convert spec: %w", err)
+	}
+	if err := convertProviderSpecFromHub(&src.Spec.ProviderSpec, &m.Spec.ProviderSpec); err != nil {
+		return err
+	}
+	if err := jsonCast(src.Status, &m.Status); err != nil {
+		return fmt.Errorf("failed to convert status: %w", err)
+	}
+	return nil
+}
+
+// ConvertTo converts the MachineSet to the hub version v1alpha1.
+func (m *MachineSet) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*clusterv1alpha1.MachineSet)
+	if !ok {
+		return fmt.Errorf("unsupported hub type %T", dstRaw)
+	}
+
+	dst.ObjectMeta = *m.ObjectMeta.DeepCopy()
+	if err := jsonCast(m.Spec, &dst.Spec); err != nil {
+		return fmt.Errorf("failed to convert spec: %w", err)
+	}
+	if err := convertProviderSpecToHub(&m.Spec.Template.Spec.ProviderSpec, &dst.Spec.Template.Spec.ProviderSpec); err != nil {
+		return err
+	}
+	if err := jsonCast(m.Status, &dst.Status); err != nil {
+		return fmt.Errorf("failed to convert status: %w", err)
+	}
+	return nil
+}
+
+// ConvertFrom converts the hub version v1alpha1 to the MachineSet.
+func (m *MachineSet) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*clusterv1alpha1.MachineSet)
+	if !ok {
+		return fmt.Errorf("unsupported hub type %T", srcRaw)
+	}
+
+	m.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	if err := jsonCast(src.Spec, &m.Spec); err != nil {
+		return fmt.Errorf("failed to convert spec: %w", err)
+	}
+	if err := convertProviderSpecFromHub(&src.Spec.Template.Spec.ProviderSpec, &m.Spec.Template.Spec.ProviderSpec); err != nil {
+		return err
+	}
+	if err := jsonCast(src.Status, &m.Status); err != nil {
+		return fmt.Errorf("failed to convert status: %w", err)
+	}
+	return nil
+}
+
+// ConvertTo converts the MachineDeployment to the hub version v1alpha1.
+func (m *MachineDeployment) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*clusterv1alpha1.MachineDeployment)
+	if !ok {
+		return fmt.Errorf("unsupported hub type %T", dstRaw)
+	}
+
+	dst.ObjectMeta = *m.ObjectMeta.DeepCopy()
+	if err := jsonCast(m.Spec, &dst.Spec); err != nil {
+		return fmt.Errorf("failed to convert spec: %w", err)
+	}
+	if err := convertProviderSpecToHub(&m.Spec.Template.Spec.ProviderSpec, &dst.Spec.Template.Spec.ProviderSpec); err != nil {
+		return err
+	}
+	if err := jsonCast(m.Status, &dst.Status); err != nil {
+		return fmt.Errorf("failed to convert status: %w", err)
+	}
+	return nil
+}
+
+// ConvertFrom converts the hub version v1alpha1 to the MachineDeployment.
+func (m *MachineDeployment) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*clusterv1alpha1.MachineDeployment)
+	if !ok {
+		return fmt.Errorf("unsupported hub type %T", srcRaw)
+	}
+
+	m.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	if err := jsonCast(src.Spec, &m.Spec); err != nil {
+		return fmt.Errorf("failed to convert spec: %w", err)
+	}
+	if err := convertProviderSpecFromHub(&src.Spec.Template.Spec.ProviderSpec, &m.Spec.Template.Spec.ProviderSpec); err != nil {
+		return err
+	}
+	if err := jsonCast(src.Status, &m.Status); err != nil {
+		return fmt.Errorf("failed to convert status: %w", err)
+	}
+	return nil
+}
+
+// jsonCast converts between the types of v1alpha1 and v1alpha2. Apart from the ProviderSpec
+// they serialize identically, so we cast by serializing and deserializing.
+func jsonCast[T any](in interface{}, out *T) error {
+	raw, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	var zero T
+	*out = zero
+	return json.Unmarshal(raw, out)
+}
+
+// convertProviderSpecToHub moves the CloudProvider into the value of the already casted
+// v1alpha1 providerSpec.
+func convertProviderSpecToHub(in *ProviderSpec, out *clusterv1alpha1.ProviderSpec) error {
+	if in.CloudProvider == "" {
+		return nil
+	}
+
+	fields := map[string]json.RawMessage{}
+	if in.Value != nil {
+		if err := json.Unmarshal(in.Value.Raw, &fields); err != nil || fields == nil {
+			return errors.New("providerSpec.value must be a JSON object if providerSpec.cloudProvider is set")
+		}
+		if _, ok := fields[cloudProviderField]; ok {
+			return errors.New("providerSpec.value must not contain the cloudProvider, it is set in providerSpec.cloudProvider")
+		}
+	}
+
+	cloudProvider, err := json.Marshal(in.CloudProvider)
+	if err != nil {
+		return err
+	}
+	fields[cloudProviderField] = cloudProvider
+
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("failed to marshal providerSpec.value: %w", err)
+	}
+	out.Value = &runtime.RawExtension{Raw: raw}
+	return nil
+}
+
+// convertProviderSpecFromHub moves the cloudProvider of the v1alpha1 providerSpec value into
+// the CloudProvider of the already casted ProviderSpec. Values which are no JSON object or
+// have no cloudProvider string are kept as they are.
+func convertProviderSpecFromHub(in *clusterv1alpha1.ProviderSpec, out *ProviderSpec) error {
+	if in.Value == nil {
+		return nil
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(in.Value.Raw, &fields); err != nil || fields == nil {
+		return nil
+	}
+	var cloudProvider string
+	if err := json.Unmarshal(fields[cloudProviderField], &cloudProvider); err != nil || cloudProvider == "" {
+		return nil
+	}
+	delete(fields, cloudProviderField)
+
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("failed to marshal providerSpec.value: %w", err)
+	}
+	out.CloudProvider = CloudProvider(cloudProvider)
+	out.Value = &runtime.RawExtension{Raw: raw}
+	return nil
+}
@@ -0,0 +1,280 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	fuzz "github.com/google/gofuzz"
+
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiconversion "k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+const fuzzIterations = 500
+
+// semantic compares times independent of their location and raw extensions as JSON.
+var semantic = apiconversion.EqualitiesOrDie(
+	func(a, b metav1.Time) bool {
+		return a.UTC() == b.UTC()
+	},
+	func(a, b runtime.RawExtension) bool {
+		var aValue, bValue interface{}
+		if err := json.Unmarshal(a.Raw, &aValue); err != nil {
+			return false
+		}
+		if err := json.Unmarshal(b.Raw, &bValue); err != nil {
+			return false
+		}
+		return reflect.DeepEqual(aValue, bValue)
+	},
+)
+
+// randomJSONObject returns a JSON object, which does not contain the cloudProvider field.
+func randomJSONObject(c fuzz.Continue) []byte {
+	fields := map[string]interface{}{}
+	for i := c.Intn(4); i > 0; i-- {
+		switch c.Intn(4) {
+		case 0:
+			fields[c.RandString()] = c.RandString()
+		case 1:
+			fields[c.RandString()] = c.Int63()
+		case 2:
+			fields[c.RandString()] = c.RandBool()
+		default:
+			fields[c.RandString()] = map[string]interface{}{c.RandString(): []string{c.RandString()}}
+		}
+	}
+	delete(fields, cloudProviderField)
+
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		panic(err)
+	}
+	return raw
+}
+
+func fuzzerFuncs() []interface{} {
+	return []interface{}{
+		// TypeMeta is not converted, it is set by the caller.
+		func(j *metav1.TypeMeta, c fuzz.Continue) {},
+		func(j *metav1.ManagedFieldsEntry, c fuzz.Continue) {
+			c.FuzzNoCustom(j)
+			j.FieldsV1 = &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{}}`)}
+		},
+		func(j *metav1.Duration, c fuzz.Continue) {
+			j.Duration = time.Duration(c.Int63n(int64(1000 * time.Hour)))
+		},
+		func(j *runtime.RawExtension, c fuzz.Continue) {
+			j.Raw = randomJSONObject(c)
+		},
+		func(j *ProviderSpec, c fuzz.Continue) {
+			c.FuzzNoCustom(j)
+			// The value is required if the cloud provider is set.
+			if j.CloudProvider != "" && j.Value == nil {
+				j.Value = &runtime.RawExtension{Raw: []byte("{}")}
+			}
+		},
+		func(j *clusterv1alpha1.ProviderSpec, c fuzz.Continue) {
+			c.FuzzNoCustom(j)
+			if j.Value != nil && c.RandBool() {
+				fields := map[string]interface{}{}
+				if err := json.Unmarshal(j.Value.Raw, &fields); err != nil {
+					panic(err)
+				}
+				fields[cloudProviderField] = c.RandString()
+				raw, err := json.Marshal(fields)
+				if err != nil {
+					panic(err)
+				}
+				j.Value.Raw = raw
+			}
+		},
+	}
+}
+
+type conversionTest struct {
+	name  string
+	hub   func() conversion.Hub
+	spoke func() conversion.Convertible
+}
+
+var conversionTests = []conversionTest{
+	{
+		name:  "Machine",
+		hub:   func() conversion.Hub { return &clusterv1alpha1.Machine{} },
+		spoke: func() conversion.Convertible { return &Machine{} },
+	},
+	{
+		name:  "MachineSet",
+		hub:   func() conversion.Hub { return &clusterv1alpha1.MachineSet{} },
+		spoke: func() conversion.Convertible { return &MachineSet{} },
+	},
+	{
+		name:  "MachineDeployment",
+		hub:   func() conversion.Hub { return &clusterv1alpha1.MachineDeployment{} },
+		spoke: func() conversion.Convertible { return &MachineDeployment{} },
+	},
+}
+
+// testRoundTrips converts fuzzed objects from the spoke to the hub version and back and vice versa
+// and verifies that no information is lost.
+func testRoundTrips(test conversionTest, fuzzer *fuzz.Fuzzer) error {
+	spoke := test.spoke()
+	fuzzer.Fuzz(spoke)
+	hub := test.hub()
+	if err := spoke.ConvertTo(hub); err != nil {
+		return fmt.Errorf("failed to convert to hub: %w", err)
+	}
+	spokeAfterRoundTrip := test.spoke()
+	if err := spokeAfterRoundTrip.ConvertFrom(hub); err != nil {
+		return fmt.Errorf("failed to convert from hub: %w", err)
+	}
+	if !semantic.DeepEqual(spoke, spokeAfterRoundTrip) {
+		return fmt.Errorf("spoke changed after round trip:\n%#v\n%#v", spoke, spokeAfterRoundTrip)
+	}
+
+	hub = test.hub()
+	fuzzer.Fuzz(hub)
+	spoke = test.spoke()
+	if err := spoke.ConvertFrom(hub); err != nil {
+		return fmt.Errorf("failed to convert from hub: %w", err)
+	}
+	hubAfterRoundTrip := test.hub()
+	if err := spoke.ConvertTo(hubAfterRoundTrip); err != nil {
+		return fmt.Errorf("failed to convert to hub: %w", err)
+	}
+	if !semantic.DeepEqual(hub, hubAfterRoundTrip) {
+		return fmt.Errorf("hub changed after round trip:\n%#v\n%#v", hub, hubAfterRoundTrip)
+	}
+
+	return nil
+}
+
+func TestConversionRoundTrip(t *testing.T) {
+	for _, test := range conversionTests {
+		t.Run(test.name, func(t *testing.T) {
+			seed := time.Now().UnixNano()
+			fuzzer := fuzz.New().NilChance(.2).NumElements(0, 2).RandSource(rand.NewSource(seed)).Funcs(fuzzerFuncs()...)
+			for i := 0; i < fuzzIterations; i++ {
+				if err := testRoundTrips(test, fuzzer); err != nil {
+					t.Fatalf("seed %d: %v", seed, err)
+				}
+			}
+		})
+	}
+}
+
+func FuzzConversionRoundTrip(f *testing.F) {
+	f.Add([]byte("machine-controller"))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, test := range conversionTests {
+			fuzzer := fuzz.NewFromGoFuzz(data).NilChance(.2).NumElements(0, 2).Funcs(fuzzerFuncs()...)
+			if err := testRoundTrips(test, fuzzer); err != nil {
+				t.Fatalf("%s: %v", test.name, err)
+			}
+		}
+	})
+}
+
+func TestConvertProviderSpec(t *testing.T) {
+	tests := []struct {
+		name                  string
+		hubValue              string
+		expectedCloudProvider CloudProvider
+		expectedValue         string
+	}{
+		{
+			name:                  "cloud provider is moved to the discriminator",
+			hubValue:              `{"cloudProvider":"aws","operatingSystem":"ubuntu","cloudProviderSpec":{"instanceType":"t3.small"}}`,
+			expectedCloudProvider: "aws",
+			expectedValue:         `{"cloudProviderSpec":{"instanceType":"t3.small"},"operatingSystem":"ubuntu"}`,
+		},
+		{
+			name:          "value without cloud provider",
+			hubValue:      `{"operatingSystem":"ubuntu"}`,
+			expectedValue: `{"operatingSystem":"ubuntu"}`,
+		},
+		{
+			name:          "cloud provider which is no string",
+			hubValue:      `{"cloudProvider":42}`,
+			expectedValue: `{"cloudProvider":42}`,
+		},
+		{
+			name:          "value which is no object",
+			hubValue:      `["aws"]`,
+			expectedValue: `["aws"]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hub := &clusterv1alpha1.Machine{}
+			hub.Spec.ProviderSpec.Value = &runtime.RawExtension{Raw: []byte(test.hubValue)}
+
+			machine := &Machine{}
+			if err := machine.ConvertFrom(hub); err != nil {
+				t.Fatalf("failed to convert from hub: %v", err)
+			}
+			if machine.Spec.ProviderSpec.CloudProvider != test.expectedCloudProvider {
+				t.Errorf("expected cloud provider %q, got %q", test.expectedCloudProvider, machine.Spec.ProviderSpec.CloudProvider)
+			}
+			if value := string(machine.Spec.ProviderSpec.Value.Raw); value != test.expectedValue {
+				t.Errorf("expected value %s, got %s", test.expectedValue, value)
+			}
+		})
+	}
+}
+
+func TestConvertInvalidProviderSpec(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{
+			name:  "cloud provider in value",
+			value: `{"cloudProvider":"gce"}`,
+		},
+		{
+			name:  "value which is no object",
+			value: `"ubuntu"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			machine := &Machine{}
+			machine.Spec.ProviderSpec = ProviderSpec{
+				CloudProvider: "aws",
+				Value:         &runtime.RawExtension{Raw: []byte(test.value)},
+			}
+
+			if err := machine.ConvertTo(&clusterv1alpha1.Machine{}); err == nil {
+				t.Error("expected the conversion to fail")
+			}
+		})
+	}
+}
@@ -1,5 +1,5 @@
 /*
-Copyright 2019 The Machine Controller Authors.
+Copyright 2026 The Machine Controller Authors.
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -14,28 +14,9 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package aws
-
-import (
-	"bytes"
-	"compress/gzip"
-)
-
-func gzipString(s string) (string, error) {
-	var b bytes.Buffer
-	gz := gzip.NewWriter(&b)
-
-	if _, err := gz.Write([]byte(s)); err != nil {
-		return "", err
-	}
-
-	if err := gz.Flush(); err != nil {
-		return "", err
-	}
-
-	if err := gz.Close(); err != nil {
-		return "", err
-	}
-
-	return b.String(), nil
-}
+// Package v1alpha2 contains API Schema definitions for the cluster v1alpha2 API group.
+// The objects are stored as v1alpha1, which is the hub of the conversions.
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=package,register
+// +groupName=cluster.k8s.io
+package v1alpha2
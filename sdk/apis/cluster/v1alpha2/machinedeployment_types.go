@@ -0,0 +1,330 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"k8c.io/machine-controller/sdk/apis/cluster/common"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// MachineDeploymentSpec defines the desired state of MachineDeployment.
+type MachineDeploymentSpec struct {
+	// Number of desired machines. Defaults to 1.
+	// This is a pointer to distinguish between explicit zero and not specified.
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Label selector for machines. Existing MachineSets whose machines are
+	// selected by this will be the ones affected by this deployment.
+	// It must match the machine template's labels.
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// Template describes the machines that will be created.
+	Template MachineTemplateSpec `json:"template"`
+
+	// The deployment strategy to use to replace existing machines with
+	// new ones.
+	// +optional
+	Strategy *MachineDeploymentStrategy `json:"strategy,omitempty"`
+
+	// Minimum number of seconds for which a newly created machine should
+	// be ready.
+	// Defaults to 0 (machine will be considered available as soon as it
+	// is ready)
+	// +optional
+	MinReadySeconds *int32 `json:"minReadySeconds,omitempty"`
+
+	// The number of old MachineSets to retain to allow rollback.
+	// This is a pointer to distinguish between explicit zero and not specified.
+	// Defaults to 1.
+	// +optional
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+
+	// Indicates that the deployment is paused.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// The maximum time in seconds for a deployment to make progress before it
+	// is considered to be failed. The deployment controller will continue to
+	// process failed deployments and a condition with a ProgressDeadlineExceeded
+	// reason will be surfaced in the deployment status. Note that progress will
+	// not be estimated during the time a deployment is paused. Defaults to 600s.
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+
+	// FailureDomains lists the failure domains across which the machines are spread
+	// evenly. Each failure domain overrides the zone and subnet of the template's
+	// providerSpec. Changing the failure domains does not trigger a rollout, instead
+	// they are balanced on the next scale up or down.
+	// +optional
+	FailureDomains []FailureDomain `json:"failureDomains,omitempty"`
+
+	// MaintenanceWindows restricts the replacement of machines after changes of the
+	// template to the given windows. Scaling because of changes of the replicas is
+	// always allowed.
+	// +optional
+	MaintenanceWindows *MaintenanceWindows `json:"maintenanceWindows,omitempty"`
+
+	// DrainPolicy overrides the drain policy of the machine-controller for the nodes
+	// of this deployment. Unset fields keep the values of the machine-controller.
+	// +optional
+	DrainPolicy *DrainPolicy `json:"drainPolicy,omitempty"`
+
+	// Bootstrap selects how the userdata of the machines of this deployment is provided.
+	// Defaults to the bootstrap secret of operating-system-manager.
+	// +optional
+	Bootstrap *Bootstrap `json:"bootstrap,omitempty"`
+}
+
+// BootstrapProviderType is the type of a provider of the userdata of machines.
+type BootstrapProviderType string
+
+const (
+	// BootstrapProviderOSM reads the userdata from the bootstrap secret which operating-system-manager
+	// creates in the cloud-init-settings namespace.
+	BootstrapProviderOSM BootstrapProviderType = "osm"
+	// BootstrapProviderTemplate renders the userdata from a Go template.
+	BootstrapProviderTemplate BootstrapProviderType = "template"
+	// BootstrapProviderHTTP requests the userdata from an HTTP endpoint.
+	BootstrapProviderHTTP BootstrapProviderType = "http"
+)
+
+// Bootstrap configures the provider of the userdata of machines.
+type Bootstrap struct {
+	// Provider is the type of the provider, one of osm, template or http.
+	// +kubebuilder:validation:Enum=osm;template;http
+	Provider BootstrapProviderType `json:"provider"`
+
+	// Template is the Ignition or cloud-init userdata as Go template. It is rendered with the
+	// .Machine and .MachineDeployment. Required for the template provider.
+	// +optional
+	Template string `json:"template,omitempty"`
+
+	// HTTP configures the endpoint of the http provider.
+	// +optional
+	HTTP *HTTPBootstrap `json:"http,omitempty"`
+}
+
+// HTTPBootstrap configures an HTTP endpoint which returns the userdata of a machine. The machine
+// is posted as JSON and the response body of a 200 response is used as userdata.
+type HTTPBootstrap struct {
+	// URL of the endpoint, must use https.
+	URL string `json:"url"`
+
+	// CABundle is a PEM encoded bundle of certificates to verify the endpoint with. Defaults
+	// to the system certificates.
+	// +optional
+	CABundle string `json:"caBundle,omitempty"`
+
+	// BearerTokenSecretRef references the key of a secret in the namespace of the
+	// MachineDeployment, whose value is sent as bearer token.
+	// +optional
+	BearerTokenSecretRef *corev1.SecretKeySelector `json:"bearerTokenSecretRef,omitempty"`
+
+	// Timeout of the requests. Defaults to 30 seconds.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// DrainPolicy configures how the node of a machine is drained before the machine is deleted.
+type DrainPolicy struct {
+	// GracePeriodSeconds overrides the termination grace period of evicted pods.
+	// If negative, the grace period of the pods is used.
+	// +optional
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+
+	// SkipPodSelector selects pods which are not evicted.
+	// +optional
+	SkipPodSelector *metav1.LabelSelector `json:"skipPodSelector,omitempty"`
+
+	// DeleteEmptyDirData allows to evict pods using emptyDir volumes, whose data is
+	// lost. If false, such pods are never deleted and the drain waits until they were
+	// removed otherwise, also after the PodEvictionTimeout passed.
+	// +optional
+	DeleteEmptyDirData *bool `json:"deleteEmptyDirData,omitempty"`
+
+	// PodEvictionTimeout is how long, counted from the start of the drain, pods are
+	// evicted, e.g. while a PodDisruptionBudget blocks their eviction. Afterwards, the
+	// drain stops waiting for the remaining pods. Zero means no timeout.
+	// +optional
+	PodEvictionTimeout *metav1.Duration `json:"podEvictionTimeout,omitempty"`
+
+	// DeleteAfterEvictionTimeout deletes the remaining pods, bypassing PodDisruptionBudgets,
+	// once the PodEvictionTimeout passed.
+	// +optional
+	DeleteAfterEvictionTimeout *bool `json:"deleteAfterEvictionTimeout,omitempty"`
+
+	// PreDrainTaintEffect is the effect of the ToBeDeletedByMachineController taint, which is
+	// applied to the node before it is cordoned, so workloads can prepare for their eviction.
+	// Either NoSchedule or PreferNoSchedule. If empty, no taint is applied.
+	// +optional
+	PreDrainTaintEffect *corev1.TaintEffect `json:"preDrainTaintEffect,omitempty"`
+
+	// PreDrainDelay is how long to wait after applying the pre-drain taint, before the node is
+	// cordoned and drained.
+	// +optional
+	PreDrainDelay *metav1.Duration `json:"preDrainDelay,omitempty"`
+
+	// WaitForVolumeDetach waits until all volumes were detached from the node before the
+	// instance is deleted, deleting pods which still use them. Defaults to the setting of the
+	// machine-controller for the cloud provider.
+	// +optional
+	WaitForVolumeDetach *bool `json:"waitForVolumeDetach,omitempty"`
+}
+
+// MaintenanceWindows defines recurring time windows in which machines may be replaced.
+type MaintenanceWindows struct {
+	// TimeZone is the IANA time zone in which the schedules are evaluated, e.g.
+	// "Europe/Berlin". Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Windows lists the maintenance windows. Machines may be replaced while any
+	// of them is open.
+	Windows []MaintenanceWindow `json:"windows"`
+}
+
+// MaintenanceWindow is a recurring time window.
+type MaintenanceWindow struct {
+	// Schedule is a cron expression with the fields minute, hour, day of month, month
+	// and day of week, at which the window opens, e.g. "0 22 * * 1-5".
+	Schedule string `json:"schedule"`
+
+	// Duration is how long the window stays open, e.g. "4h".
+	Duration metav1.Duration `json:"duration"`
+}
+
+// MachineDeploymentStrategy describes how to replace existing machines
+// with new ones.
+type MachineDeploymentStrategy struct {
+	// Type of deployment. Currently the only supported strategy is
+	// "RollingUpdate".
+	// Default is RollingUpdate.
+	// +optional
+	Type common.MachineDeploymentStrategyType `json:"type,omitempty"`
+
+	// Rolling update config params. Present only if
+	// MachineDeploymentStrategyType = RollingUpdate.
+	// +optional
+	RollingUpdate *MachineRollingUpdateDeployment `json:"rollingUpdate,omitempty"`
+}
+
+// Spec to control the desired behavior of rolling update.
+type MachineRollingUpdateDeployment struct {
+	// The maximum number of machines that can be unavailable during the update.
+	// Value can be an absolute number (ex: 5) or a percentage of desired
+	// machines (ex: 10%).
+	// Absolute number is calculated from percentage by rounding down.
+	// This can not be 0 if MaxSurge is 0.
+	// Defaults to 0.
+	// Example: when this is set to 30%, the old MachineSet can be scaled
+	// down to 70% of desired machines immediately when the rolling update
+	// starts. Once new machines are ready, old MachineSet can be scaled
+	// down further, followed by scaling up the new MachineSet, ensuring
+	// that the total number of machines available at all times
+	// during the update is at least 70% of desired machines.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty" protobuf:"bytes,1,opt,name=maxUnavailable"`
+
+	// The maximum number of machines that can be scheduled above the
+	// desired number of machines.
+	// Value can be an absolute number (ex: 5) or a percentage of
+	// desired machines (ex: 10%).
+	// This can not be 0 if MaxUnavailable is 0.
+	// Absolute number is calculated from percentage by rounding up.
+	// Defaults to 1.
+	// Example: when this is set to 30%, the new MachineSet can be scaled
+	// up immediately when the rolling update starts, such that the total
+	// number of old and new machines do not exceed 130% of desired
+	// machines. Once old machines have been killed, new MachineSet can
+	// be scaled up further, ensuring that total number of machines running
+	// at any time during the update is at most 130% of desired machines.
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty" protobuf:"bytes,2,opt,name=maxSurge"`
+}
+
+// MachineDeploymentStatus defines the observed state of MachineDeployment.
+type MachineDeploymentStatus struct {
+	// The generation observed by the deployment controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty" protobuf:"varint,1,opt,name=observedGeneration"`
+
+	// Total number of non-terminated machines targeted by this deployment
+	// (their labels match the selector).
+	// +optional
+	Replicas int32 `json:"replicas,omitempty" protobuf:"varint,2,opt,name=replicas"`
+
+	// Total number of non-terminated machines targeted by this deployment
+	// that have the desired template spec.
+	// +optional
+	UpdatedReplicas int32 `json:"updatedReplicas,omitempty" protobuf:"varint,3,opt,name=updatedReplicas"`
+
+	// Total number of ready machines targeted by this deployment.
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty" protobuf:"varint,7,opt,name=readyReplicas"`
+
+	// Total number of available machines (ready for at least minReadySeconds)
+	// targeted by this deployment.
+	// +optional
+	AvailableReplicas int32 `json:"availableReplicas,omitempty" protobuf:"varint,4,opt,name=availableReplicas"`
+
+	// Total number of unavailable machines targeted by this deployment.
+	// This is the total number of machines that are still required for
+	// the deployment to have 100% available capacity. They may either
+	// be machines that are running but not yet available or machines
+	// that still have not been created.
+	// +optional
+	UnavailableReplicas int32 `json:"unavailableReplicas,omitempty" protobuf:"varint,5,opt,name=unavailableReplicas"`
+
+	// NextMaintenanceWindow is the start of the currently open maintenance window or,
+	// if none is open, of the next one.
+	// +optional
+	NextMaintenanceWindow *metav1.Time `json:"nextMaintenanceWindow,omitempty"`
+
+	// Conditions represent the latest available observations of the deployment's state,
+	// e.g. the OwnershipConflict condition.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MachineDeployment is the Schema for the machinedeployments API
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:shortName=md
+// +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.labelSelector
+type MachineDeployment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MachineDeploymentSpec   `json:"spec,omitempty"`
+	Status MachineDeploymentStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MachineDeploymentList contains a list of MachineDeployment.
+type MachineDeploymentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MachineDeployment `json:"items"`
+}
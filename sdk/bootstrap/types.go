@@ -34,4 +34,7 @@ const (
 	CloudInitSettingsNamespace = "cloud-init-settings"
 	// MachineDeploymentRevision is the revision for Machine Deployment.
 	MachineDeploymentRevision = "k8c.io/machine-deployment-revision"
+	// BootstrapTokenPlaceholder is replaced with a bootstrap token which is only valid for the Machine,
+	// if machine-controller is configured to manage bootstrap tokens.
+	BootstrapTokenPlaceholder = "<MACHINE_BOOTSTRAP_TOKEN>"
 )
@@ -28,6 +28,10 @@ type RawConfig struct {
 	AssumeRoleARN        providerconfig.ConfigVarString `json:"assumeRoleARN,omitempty"`
 	AssumeRoleExternalID providerconfig.ConfigVarString `json:"assumeRoleExternalID,omitempty"`
 
+	// UseWorkloadIdentity assumes the AssumeRoleARN with the projected ServiceAccount token of the
+	// machine-controller instead of using an access key.
+	UseWorkloadIdentity providerconfig.ConfigVarBool `json:"useWorkloadIdentity,omitempty"`
+
 	Region             providerconfig.ConfigVarString   `json:"region"`
 	AvailabilityZone   providerconfig.ConfigVarString   `json:"availabilityZone,omitempty"`
 	VpcID              providerconfig.ConfigVarString   `json:"vpcId"`
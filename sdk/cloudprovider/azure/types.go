@@ -28,6 +28,10 @@ type RawConfig struct {
 	ClientID       providerconfig.ConfigVarString `json:"clientID,omitempty"`
 	ClientSecret   providerconfig.ConfigVarString `json:"clientSecret,omitempty"`
 
+	// UseWorkloadIdentity authenticates as the client with a federated credential for the projected
+	// ServiceAccount token of the machine-controller instead of the client secret.
+	UseWorkloadIdentity providerconfig.ConfigVarBool `json:"useWorkloadIdentity,omitempty"`
+
 	Location                    providerconfig.ConfigVarString `json:"location"`
 	ResourceGroup               providerconfig.ConfigVarString `json:"resourceGroup"`
 	VNetResourceGroup           providerconfig.ConfigVarString `json:"vnetResourceGroup"`
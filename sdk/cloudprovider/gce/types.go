@@ -52,6 +52,16 @@ type CloudProviderSpec struct {
 	MinCPUPlatform               providerconfig.ConfigVarString  `json:"minCPUPlatform,omitempty"`
 	GuestOSFeatures              []string                        `json:"guestOSFeatures,omitempty"`
 	ProjectID                    providerconfig.ConfigVarString  `json:"projectID,omitempty"`
+
+	// UseWorkloadIdentity exchanges the projected ServiceAccount token of the machine-controller
+	// with the WorkloadIdentityProvider and impersonates the ServiceAccountEmail instead of
+	// using the key of the ServiceAccount.
+	UseWorkloadIdentity providerconfig.ConfigVarBool `json:"useWorkloadIdentity,omitempty"`
+	// WorkloadIdentityProvider is the audience of the workload identity pool provider, e.g.
+	// `//iam.googleapis.com/projects/<number>/locations/global/workloadIdentityPools/<pool>/providers/<provider>`.
+	WorkloadIdentityProvider providerconfig.ConfigVarString `json:"workloadIdentityProvider,omitempty"`
+	// ServiceAccountEmail is the service account which is impersonated and used for the machines.
+	ServiceAccountEmail providerconfig.ConfigVarString `json:"serviceAccountEmail,omitempty"`
 }
 
 // UpdateProviderSpec updates the given provider spec with changed
@@ -39,6 +39,14 @@ type RawConfig struct {
 	InstanceReadyCheckTimeout   providerconfig.ConfigVarString `json:"instanceReadyCheckTimeout,omitempty"`
 	ComputeAPIVersion           providerconfig.ConfigVarString `json:"computeAPIVersion,omitempty"`
 
+	// UseWorkloadIdentity exchanges the projected ServiceAccount token of the machine-controller for
+	// a Keystone token of the IdentityProvider with OpenID Connect federation instead of using a
+	// password or application credential.
+	UseWorkloadIdentity providerconfig.ConfigVarBool   `json:"useWorkloadIdentity,omitempty"`
+	IdentityProvider    providerconfig.ConfigVarString `json:"identityProvider,omitempty"`
+	// Protocol is the federation protocol of the IdentityProvider. Defaults to `openid`.
+	Protocol providerconfig.ConfigVarString `json:"protocol,omitempty"`
+
 	// Machine details
 	Image                 providerconfig.ConfigVarString   `json:"image"`
 	Flavor                providerconfig.ConfigVarString   `json:"flavor"`
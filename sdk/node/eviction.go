@@ -18,4 +18,8 @@ package node
 
 const (
 	SkipEvictionAnnotationKey = "kubermatic.io/skip-eviction"
+
+	// ToBeDeletedTaintKey is the key of the taint which is applied to nodes before they are
+	// drained, so workloads can prepare for their eviction.
+	ToBeDeletedTaintKey = "ToBeDeletedByMachineController"
 )
@@ -18,28 +18,204 @@ package configvar
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
 	"time"
 
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
 	"k8c.io/machine-controller/sdk/providerconfig"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// SecretStore resolves references to secret stores outside of the cluster.
+type SecretStore interface {
+	// GetValue returns the referenced value for an object in the namespace. The accessor
+	// describes the object the value is resolved for.
+	GetValue(ctx context.Context, ref providerconfig.ExternalKeySelector, namespace, accessor string) (string, error)
+	// CheckAccess returns an error wrapping ErrCrossNamespaceReference if objects in the
+	// namespace may not use the reference.
+	CheckAccess(ref providerconfig.ExternalKeySelector, namespace string) error
+}
+
+// secretStore is the SecretStore used by new resolvers.
+var secretStore SecretStore
+
+// SetSecretStore sets the SecretStore external key references are resolved with. It must be
+// called before any resolver is created.
+func SetSecretStore(store SecretStore) {
+	secretStore = store
+}
+
+// ErrCrossNamespaceReference is returned for secret and configmap references to another namespace
+// than the one the resolver is restricted to, and for references to secret stores which are not
+// available to the namespace.
+var ErrCrossNamespaceReference = errors.New("references to other namespaces are not allowed")
+
+// CheckExternalKeyRef returns an error if objects in the namespace may not use the reference to the
+// secret store set with SetSecretStore.
+func CheckExternalKeyRef(ref providerconfig.ExternalKeySelector, namespace string) error {
+	if secretStore == nil {
+		return fmt.Errorf("no secret store is configured to resolve the reference to store '%s'", ref.Store)
+	}
+	return secretStore.CheckAccess(ref, namespace)
+}
+
 type Resolver struct {
 	ctx    context.Context
 	client ctrlruntimeclient.Client
+
+	namespace               string
+	allowCrossNamespaceRefs bool
+
+	credentialsName string
+	cloudProvider   providerconfig.CloudProvider
+	credentials     map[string][]byte
+
+	secretStore SecretStore
+	accessor    string
+}
+
+// ResolverOption configures a Resolver.
+type ResolverOption func(*Resolver)
+
+// WithNamespace restricts secret and configmap references to the namespace. ProviderCredentials
+// must be available to the namespace.
+func WithNamespace(namespace string) ResolverOption {
+	return func(r *Resolver) {
+		r.namespace = namespace
+	}
+}
+
+// WithCrossNamespaceRefs allows secret and configmap references to other namespaces than the
+// one set with WithNamespace.
+func WithCrossNamespaceRefs(allowed bool) ResolverOption {
+	return func(r *Resolver) {
+		r.allowCrossNamespaceRefs = allowed
+	}
+}
+
+// WithProviderCredentials makes the *OrEnv functions fall back to the ProviderCredentials with the
+// given name instead of the environment. Their type must match the cloud provider.
+func WithProviderCredentials(name string, cloudProvider providerconfig.CloudProvider) ResolverOption {
+	return func(r *Resolver) {
+		r.credentialsName = name
+		r.cloudProvider = cloudProvider
+	}
+}
+
+// WithSecretStore overrides the SecretStore set with SetSecretStore.
+func WithSecretStore(store SecretStore) ResolverOption {
+	return func(r *Resolver) {
+		r.secretStore = store
+	}
+}
+
+// WithAccessor sets the description of the object values are resolved for, which is passed
+// to the SecretStore, e.g. `Machine kube-system/worker-abc`.
+func WithAccessor(accessor string) ResolverOption {
+	return func(r *Resolver) {
+		r.accessor = accessor
+	}
+}
+
+// ForConfig returns the options to resolve the config variables of the providerSpec of an object
+// in the given namespace.
+func ForConfig(namespace string, config *providerconfig.Config, allowCrossNamespaceRefs bool) []ResolverOption {
+	opts := []ResolverOption{
+		WithNamespace(namespace),
+		WithCrossNamespaceRefs(allowCrossNamespaceRefs),
+	}
+	if config.CredentialsRef != nil {
+		opts = append(opts, WithProviderCredentials(config.CredentialsRef.Name, config.CloudProvider))
+	}
+	return opts
+}
+
+func NewResolver(ctx context.Context, client ctrlruntimeclient.Client, opts ...ResolverOption) *Resolver {
+	r := &Resolver{
+		ctx:         ctx,
+		client:      client,
+		secretStore: secretStore,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// CheckProviderCredentials returns an error if the credentials are not for the cloud provider or not
+// available to the namespace. The namespace is not checked if it is empty.
+func CheckProviderCredentials(ctx context.Context, client ctrlruntimeclient.Client, credentials *clusterv1alpha1.ProviderCredentials, cloudProvider providerconfig.CloudProvider, namespace string) error {
+	if credentials.Spec.Type != string(cloudProvider) {
+		return fmt.Errorf("ProviderCredentials '%s' are of type '%s' and can not be used for '%s'", credentials.Name, credentials.Spec.Type, cloudProvider)
+	}
+	if namespace == "" {
+		return nil
+	}
+
+	if credentials.Spec.NamespaceSelector == nil {
+		if namespace != credentials.Spec.SecretRef.Namespace {
+			return fmt.Errorf("ProviderCredentials '%s' can not be used in namespace '%s'", credentials.Name, namespace)
+		}
+		return nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := client.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		return fmt.Errorf("error retrieving namespace '%s': '%w'", namespace, err)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(credentials.Spec.NamespaceSelector)
+	if err != nil {
+		return fmt.Errorf("invalid namespace selector of ProviderCredentials '%s': '%w'", credentials.Name, err)
+	}
+	if !selector.Matches(labels.Set(ns.Labels)) {
+		return fmt.Errorf("ProviderCredentials '%s' can not be used in namespace '%s'", credentials.Name, namespace)
+	}
+
+	return nil
+}
+
+// providerCredentials returns the data of the secret of the ProviderCredentials.
+func (r *Resolver) providerCredentials() (map[string][]byte, error) {
+	if r.credentials != nil {
+		return r.credentials, nil
+	}
+
+	credentials := &clusterv1alpha1.ProviderCredentials{}
+	if err := r.client.Get(r.ctx, types.NamespacedName{Name: r.credentialsName}, credentials); err != nil {
+		return nil, fmt.Errorf("error retrieving ProviderCredentials '%s': '%w'", r.credentialsName, err)
+	}
+	if err := CheckProviderCredentials(r.ctx, r.client, credentials, r.cloudProvider, r.namespace); err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{}
+	name := types.NamespacedName{Namespace: credentials.Spec.SecretRef.Namespace, Name: credentials.Spec.SecretRef.Name}
+	if err := r.client.Get(r.ctx, name, secret); err != nil {
+		return nil, fmt.Errorf("error retrieving secret '%s' of ProviderCredentials '%s': '%w'", name, r.credentialsName, err)
+	}
+
+	r.credentials = secret.Data
+	if r.credentials == nil {
+		r.credentials = map[string][]byte{}
+	}
+	return r.credentials, nil
 }
 
-func NewResolver(ctx context.Context, client ctrlruntimeclient.Client) *Resolver {
-	return &Resolver{
-		ctx:    ctx,
-		client: client,
+// checkNamespace returns an error wrapping ErrCrossNamespaceReference if references to the
+// namespace are not allowed.
+func (r *Resolver) checkNamespace(namespace string) error {
+	if r.namespace == "" || r.allowCrossNamespaceRefs || namespace == r.namespace {
+		return nil
 	}
+	return fmt.Errorf("%w: namespace '%s' is referenced from namespace '%s'", ErrCrossNamespaceReference, namespace, r.namespace)
 }
 
 var _ providerconfig.ConfigVarResolver = &Resolver{}
@@ -78,6 +254,9 @@ func (r *Resolver) GetDurationValueOrDefault(configVar providerconfig.ConfigVarS
 func (r *Resolver) GetStringValue(configVar providerconfig.ConfigVarString) (string, error) {
 	// We need all three of these to fetch and use a secret
 	if configVar.SecretKeyRef.Name != "" && configVar.SecretKeyRef.Namespace != "" && configVar.SecretKeyRef.Key != "" {
+		if err := r.checkNamespace(configVar.SecretKeyRef.Namespace); err != nil {
+			return "", err
+		}
 		secret := &corev1.Secret{}
 		name := types.NamespacedName{Namespace: configVar.SecretKeyRef.Namespace, Name: configVar.SecretKeyRef.Name}
 		if err := r.client.Get(r.ctx, name, secret); err != nil {
@@ -91,6 +270,9 @@ func (r *Resolver) GetStringValue(configVar providerconfig.ConfigVarString) (str
 
 	// We need all three of these to fetch and use a configmap
 	if configVar.ConfigMapKeyRef.Name != "" && configVar.ConfigMapKeyRef.Namespace != "" && configVar.ConfigMapKeyRef.Key != "" {
+		if err := r.checkNamespace(configVar.ConfigMapKeyRef.Namespace); err != nil {
+			return "", err
+		}
 		configMap := &corev1.ConfigMap{}
 		name := types.NamespacedName{Namespace: configVar.ConfigMapKeyRef.Namespace, Name: configVar.ConfigMapKeyRef.Name}
 		if err := r.client.Get(r.ctx, name, configMap); err != nil {
@@ -102,17 +284,37 @@ func (r *Resolver) GetStringValue(configVar providerconfig.ConfigVarString) (str
 		return "", fmt.Errorf("configmap '%s' in namespace '%s' has no key '%s'", configVar.ConfigMapKeyRef.Name, configVar.ConfigMapKeyRef.Namespace, configVar.ConfigMapKeyRef.Key)
 	}
 
+	if configVar.ExternalKeyRef != nil {
+		if r.secretStore == nil {
+			return "", fmt.Errorf("no secret store is configured to resolve the reference to store '%s'", configVar.ExternalKeyRef.Store)
+		}
+		return r.secretStore.GetValue(r.ctx, *configVar.ExternalKeyRef, r.namespace, r.accessor)
+	}
+
 	return configVar.Value, nil
 }
 
 // GetStringValueOrEnv tries to get the value from ConfigVarString, when it fails, it falls back to
-// getting the value from an environment variable specified by envVarName parameter.
+// getting the value from an environment variable specified by envVarName parameter. If ProviderCredentials
+// are configured, the key envVarName of their secret is used instead of the environment.
 func (r *Resolver) GetStringValueOrEnv(configVar providerconfig.ConfigVarString, envVarName string) (string, error) {
 	cfgVar, err := r.GetStringValue(configVar)
+	// Falling back would silently use other credentials than the referenced ones.
+	if errors.Is(err, ErrCrossNamespaceReference) || (err != nil && configVar.ExternalKeyRef != nil) {
+		return "", err
+	}
 	if err == nil && len(cfgVar) > 0 {
 		return cfgVar, err
 	}
 
+	if r.credentialsName != "" {
+		credentials, err := r.providerCredentials()
+		if err != nil {
+			return "", err
+		}
+		return string(credentials[envVarName]), nil
+	}
+
 	envVal, _ := os.LookupEnv(envVarName)
 	return envVal, nil
 }
@@ -122,6 +324,9 @@ func (r *Resolver) GetStringValueOrEnv(configVar providerconfig.ConfigVarString,
 func (r *Resolver) GetBoolValue(configVar providerconfig.ConfigVarBool) (bool, bool, error) {
 	// We need all three of these to fetch and use a secret
 	if configVar.SecretKeyRef.Name != "" && configVar.SecretKeyRef.Namespace != "" && configVar.SecretKeyRef.Key != "" {
+		if err := r.checkNamespace(configVar.SecretKeyRef.Namespace); err != nil {
+			return false, false, err
+		}
 		secret := &corev1.Secret{}
 		name := types.NamespacedName{Namespace: configVar.SecretKeyRef.Namespace, Name: configVar.SecretKeyRef.Name}
 		if err := r.client.Get(r.ctx, name, secret); err != nil {
@@ -136,6 +341,9 @@ func (r *Resolver) GetBoolValue(configVar providerconfig.ConfigVarBool) (bool, b
 
 	// We need all three of these to fetch and use a configmap
 	if configVar.ConfigMapKeyRef.Name != "" && configVar.ConfigMapKeyRef.Namespace != "" && configVar.ConfigMapKeyRef.Key != "" {
+		if err := r.checkNamespace(configVar.ConfigMapKeyRef.Namespace); err != nil {
+			return false, false, err
+		}
 		configMap := &corev1.ConfigMap{}
 		name := types.NamespacedName{Namespace: configVar.ConfigMapKeyRef.Namespace, Name: configVar.ConfigMapKeyRef.Name}
 		if err := r.client.Get(r.ctx, name, configMap); err != nil {
@@ -157,10 +365,24 @@ func (r *Resolver) GetBoolValue(configVar providerconfig.ConfigVarBool) (bool, b
 
 func (r *Resolver) GetBoolValueOrEnv(configVar providerconfig.ConfigVarBool, envVarName string) (bool, error) {
 	boolVal, valid, err := r.GetBoolValue(configVar)
+	if errors.Is(err, ErrCrossNamespaceReference) {
+		return false, err
+	}
 	if valid && err == nil {
 		return boolVal, nil
 	}
 
+	if r.credentialsName != "" {
+		credentials, err := r.providerCredentials()
+		if err != nil {
+			return false, err
+		}
+		if val, ok := credentials[envVarName]; ok {
+			return strconv.ParseBool(string(val))
+		}
+		return false, nil
+	}
+
 	envVal, envValFound := os.LookupEnv(envVarName)
 	if envValFound {
 		envValBool, err := strconv.ParseBool(envVal)
@@ -0,0 +1,244 @@
+/*
+Copyright 2026 The Machine Controller Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configvar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	clusterv1alpha1 "k8c.io/machine-controller/sdk/apis/cluster/v1alpha1"
+	"k8c.io/machine-controller/sdk/providerconfig"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newFakeClient(t *testing.T, objects ...ctrlruntimeclient.Object) ctrlruntimeclient.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add client-go scheme: %v", err)
+	}
+	if err := clusterv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add cluster scheme: %v", err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
+
+func TestGetStringValueOrEnv(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "from-env")
+
+	objects := []ctrlruntimeclient.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"team": "a"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "aws", Namespace: "credentials"},
+			Data:       map[string][]byte{"AWS_ACCESS_KEY_ID": []byte("from-credentials")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "aws", Namespace: "team-b"},
+			Data:       map[string][]byte{"accessKeyID": []byte("from-secret")},
+		},
+		&clusterv1alpha1.ProviderCredentials{
+			ObjectMeta: metav1.ObjectMeta{Name: "aws"},
+			Spec: clusterv1alpha1.ProviderCredentialsSpec{
+				Type:              string(providerconfig.CloudProviderAWS),
+				SecretRef:         corev1.SecretReference{Name: "aws", Namespace: "credentials"},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+			},
+		},
+		&clusterv1alpha1.ProviderCredentials{
+			ObjectMeta: metav1.ObjectMeta{Name: "aws-unscoped"},
+			Spec: clusterv1alpha1.ProviderCredentialsSpec{
+				Type:      string(providerconfig.CloudProviderAWS),
+				SecretRef: corev1.SecretReference{Name: "aws", Namespace: "credentials"},
+			},
+		},
+	}
+
+	secretRef := providerconfig.ConfigVarString{SecretKeyRef: providerconfig.GlobalSecretKeySelector{
+		ObjectReference: corev1.ObjectReference{Name: "aws", Namespace: "team-b"},
+		Key:             "accessKeyID",
+	}}
+
+	tests := []struct {
+		name          string
+		opts          []ResolverOption
+		configVar     providerconfig.ConfigVarString
+		expectedValue string
+		expectedErr   error
+		expectErr     bool
+	}{
+		{
+			name:          "environment without options",
+			expectedValue: "from-env",
+		},
+		{
+			name:          "references are not restricted without namespace",
+			configVar:     secretRef,
+			expectedValue: "from-secret",
+		},
+		{
+			name:          "reference to the own namespace",
+			opts:          []ResolverOption{WithNamespace("team-b")},
+			configVar:     secretRef,
+			expectedValue: "from-secret",
+		},
+		{
+			name:        "reference to another namespace",
+			opts:        []ResolverOption{WithNamespace("team-a")},
+			configVar:   secretRef,
+			expectedErr: ErrCrossNamespaceReference,
+		},
+		{
+			name:          "allowed reference to another namespace",
+			opts:          []ResolverOption{WithNamespace("team-a"), WithCrossNamespaceRefs(true)},
+			configVar:     secretRef,
+			expectedValue: "from-secret",
+		},
+		{
+			name:          "provider credentials instead of environment",
+			opts:          []ResolverOption{WithNamespace("team-a"), WithProviderCredentials("aws", providerconfig.CloudProviderAWS)},
+			expectedValue: "from-credentials",
+		},
+		{
+			name:          "value takes precedence over provider credentials",
+			opts:          []ResolverOption{WithNamespace("team-a"), WithProviderCredentials("aws", providerconfig.CloudProviderAWS)},
+			configVar:     providerconfig.ConfigVarString{Value: "from-value"},
+			expectedValue: "from-value",
+		},
+		{
+			name:      "provider credentials not selecting the namespace",
+			opts:      []ResolverOption{WithNamespace("team-b"), WithProviderCredentials("aws", providerconfig.CloudProviderAWS)},
+			expectErr: true,
+		},
+		{
+			name:      "provider credentials without selector in another namespace",
+			opts:      []ResolverOption{WithNamespace("team-a"), WithProviderCredentials("aws-unscoped", providerconfig.CloudProviderAWS)},
+			expectErr: true,
+		},
+		{
+			name:      "provider credentials of another type",
+			opts:      []ResolverOption{WithNamespace("team-a"), WithProviderCredentials("aws", providerconfig.CloudProviderHetzner)},
+			expectErr: true,
+		},
+		{
+			name:      "missing provider credentials",
+			opts:      []ResolverOption{WithNamespace("team-a"), WithProviderCredentials("gce", providerconfig.CloudProviderGoogle)},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resolver := NewResolver(context.Background(), newFakeClient(t, objects...), test.opts...)
+
+			value, err := resolver.GetStringValueOrEnv(test.configVar, "AWS_ACCESS_KEY_ID")
+			if test.expectedErr != nil || test.expectErr {
+				if err == nil {
+					t.Fatalf("expected an error, got value %q", value)
+				}
+				if test.expectedErr != nil && !errors.Is(err, test.expectedErr) {
+					t.Fatalf("expected error %v, got %v", test.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if value != test.expectedValue {
+				t.Errorf("expected %q, got %q", test.expectedValue, value)
+			}
+		})
+	}
+}
+
+type fakeSecretStore struct {
+	values    map[string]string
+	accessors []string
+	// namespaces may use the references to the store, all namespaces may if it is nil.
+	namespaces []string
+}
+
+func (s *fakeSecretStore) CheckAccess(ref providerconfig.ExternalKeySelector, namespace string) error {
+	if s.namespaces != nil && !slices.Contains(s.namespaces, namespace) {
+		return fmt.Errorf("%w: store '%s' is not available to namespace '%s'", ErrCrossNamespaceReference, ref.Store, namespace)
+	}
+	return nil
+}
+
+func (s *fakeSecretStore) GetValue(_ context.Context, ref providerconfig.ExternalKeySelector, namespace, accessor string) (string, error) {
+	if err := s.CheckAccess(ref, namespace); err != nil {
+		return "", err
+	}
+	s.accessors = append(s.accessors, accessor)
+	value, ok := s.values[ref.Store+"/"+ref.Path+"/"+ref.Key]
+	if !ok {
+		return "", errors.New("not found")
+	}
+	return value, nil
+}
+
+func TestGetStringValueOrEnvExternalKeyRef(t *testing.T) {
+	t.Setenv("HZ_TOKEN", "from-env")
+
+	store := &fakeSecretStore{values: map[string]string{"vault/hetzner/token": "from-store"}}
+	resolver := NewResolver(context.Background(), newFakeClient(t), WithSecretStore(store), WithAccessor("Machine kube-system/test"))
+
+	value, err := resolver.GetStringValueOrEnv(providerconfig.ConfigVarString{
+		ExternalKeyRef: &providerconfig.ExternalKeySelector{Store: "vault", Path: "hetzner", Key: "token"},
+	}, "HZ_TOKEN")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value != "from-store" {
+		t.Errorf("expected value from the secret store, got %q", value)
+	}
+	if len(store.accessors) != 1 || store.accessors[0] != "Machine kube-system/test" {
+		t.Errorf("expected the accessor to be passed to the store, got %v", store.accessors)
+	}
+
+	// Failing references must not fall back to the environment.
+	value, err = resolver.GetStringValueOrEnv(providerconfig.ConfigVarString{
+		ExternalKeyRef: &providerconfig.ExternalKeySelector{Store: "vault", Path: "hetzner", Key: "missing"},
+	}, "HZ_TOKEN")
+	if err == nil {
+		t.Errorf("expected an error, got value %q", value)
+	}
+
+	// References to stores which are not available to the namespace must not be resolved.
+	store.namespaces = []string{"team-a"}
+	value, err = NewResolver(context.Background(), newFakeClient(t), WithSecretStore(store), WithNamespace("team-b")).GetStringValueOrEnv(providerconfig.ConfigVarString{
+		ExternalKeyRef: &providerconfig.ExternalKeySelector{Store: "vault", Path: "hetzner", Key: "token"},
+	}, "HZ_TOKEN")
+	if !errors.Is(err, ErrCrossNamespaceReference) {
+		t.Errorf("expected a cross namespace reference error, got value %q and error %v", value, err)
+	}
+
+	if _, err := NewResolver(context.Background(), newFakeClient(t)).GetStringValue(providerconfig.ConfigVarString{
+		ExternalKeyRef: &providerconfig.ExternalKeySelector{Store: "vault", Path: "hetzner", Key: "token"},
+	}); err == nil {
+		t.Error("expected an error without secret store")
+	}
+}
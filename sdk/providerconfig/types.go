@@ -160,6 +160,12 @@ type Config struct {
 	CloudProvider     CloudProvider        `json:"cloudProvider"`
 	CloudProviderSpec runtime.RawExtension `json:"cloudProviderSpec"`
 
+	// CredentialsRef references the ProviderCredentials the cloud provider reads the
+	// credentials from, which are not set in the cloudProviderSpec, instead of the
+	// environment of the machine-controller.
+	// +optional
+	CredentialsRef *corev1.LocalObjectReference `json:"credentialsRef,omitempty"`
+
 	OperatingSystem     OperatingSystem      `json:"operatingSystem"`
 	OperatingSystemSpec runtime.RawExtension `json:"operatingSystemSpec"`
 
@@ -180,10 +186,26 @@ type GlobalObjectKeySelector struct {
 type GlobalSecretKeySelector GlobalObjectKeySelector
 type GlobalConfigMapKeySelector GlobalObjectKeySelector
 
+// ExternalKeySelector references a value in a secret store outside of the cluster,
+// which is configured in the machine-controller and its webhook.
+type ExternalKeySelector struct {
+	// Store is the name of the configured secret store.
+	Store string `json:"store"`
+	// Path is the path of the secret in the store.
+	Path string `json:"path"`
+	// Key is the key of the value in the secret.
+	Key string `json:"key"`
+}
+
+func (s ExternalKeySelector) String() string {
+	return fmt.Sprintf("%s:%s#%s", s.Store, s.Path, s.Key)
+}
+
 type ConfigVarString struct {
 	Value           string                     `json:"value,omitempty"`
 	SecretKeyRef    GlobalSecretKeySelector    `json:"secretKeyRef,omitempty"`
 	ConfigMapKeyRef GlobalConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+	ExternalKeyRef  *ExternalKeySelector       `json:"externalKeyRef,omitempty"`
 }
 
 // This type only exists to have the same fields as ConfigVarString but
@@ -209,7 +231,7 @@ func (configVarString ConfigVarString) MarshalJSON() ([]byte, error) {
 		configMapKeyRefEmpty = true
 	}
 
-	if secretKeyRefEmpty && configMapKeyRefEmpty {
+	if secretKeyRefEmpty && configMapKeyRefEmpty && configVarString.ExternalKeyRef == nil {
 		return []byte(fmt.Sprintf(`"%s"`, configVarString.Value)), nil
 	}
 
@@ -234,6 +256,18 @@ func (configVarString ConfigVarString) MarshalJSON() ([]byte, error) {
 		fmt.Fprintf(buffer, `%s"configMapKeyRef":%s`, leadingComma, jsonVal)
 	}
 
+	if configVarString.ExternalKeyRef != nil {
+		var leadingComma string
+		if !secretKeyRefEmpty || !configMapKeyRefEmpty {
+			leadingComma = ","
+		}
+		jsonVal, err := json.Marshal(configVarString.ExternalKeyRef)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(buffer, `%s"externalKeyRef":%s`, leadingComma, jsonVal)
+	}
+
 	if configVarString.Value != "" {
 		fmt.Fprintf(buffer, `,"value":"%s"`, configVarString.Value)
 	}
@@ -268,6 +302,7 @@ func (configVarString *ConfigVarString) UnmarshalJSON(b []byte) error {
 	configVarString.Value = cvsDummy.Value
 	configVarString.SecretKeyRef = cvsDummy.SecretKeyRef
 	configVarString.ConfigMapKeyRef = cvsDummy.ConfigMapKeyRef
+	configVarString.ExternalKeyRef = cvsDummy.ExternalKeyRef
 	return nil
 }
 
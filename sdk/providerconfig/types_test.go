@@ -106,6 +106,10 @@ func TestConfigVarStringMarshalling(t *testing.T) {
 			cvs:      ConfigVarString{SecretKeyRef: GlobalSecretKeySelector{ObjectReference: corev1.ObjectReference{Namespace: "ns", Name: "name"}, Key: "key"}},
 			expected: `{"secretKeyRef":{"namespace":"ns","name":"name","key":"key"}}`,
 		},
+		{
+			cvs:      ConfigVarString{ExternalKeyRef: &ExternalKeySelector{Store: "vault", Path: "aws/team-a", Key: "accessKeyID"}},
+			expected: `{"externalKeyRef":{"store":"vault","path":"aws/team-a","key":"accessKeyID"}}`,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -178,6 +182,12 @@ func TestConfigVarStringMarshallingAndUnmarshalling(t *testing.T) {
 			ConfigMapKeyRef: GlobalConfigMapKeySelector{ObjectReference: corev1.ObjectReference{Namespace: "ns", Name: "name"}, Key: "key"},
 			SecretKeyRef:    GlobalSecretKeySelector{ObjectReference: corev1.ObjectReference{Namespace: "ns", Name: "name"}, Key: "key"},
 		},
+		{ExternalKeyRef: &ExternalKeySelector{Store: "vault", Path: "aws/team-a", Key: "accessKeyID"}},
+		{
+			Value:          "val",
+			SecretKeyRef:   GlobalSecretKeySelector{ObjectReference: corev1.ObjectReference{Namespace: "ns", Name: "name"}, Key: "key"},
+			ExternalKeyRef: &ExternalKeySelector{Store: "files", Path: "aws", Key: "accessKeyID"},
+		},
 	}
 
 	for _, cvs := range testCases {